@@ -0,0 +1,20 @@
+//go:build jsonschema
+
+package discovered_impls
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Notification) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(
+	Notification.Schema,
+	jsonschema.WithInterface(Notification{}.Channels,
+		jsonschema.Implementations(),
+		jsonschema.SnakeCaseWireValues(),
+		jsonschema.Impl("sms", SMSChannel{}),
+	),
+)
@@ -0,0 +1,60 @@
+package discovered_impls
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNotificationRoundTripsDiscoveredChannels(t *testing.T) {
+	input := `{"message":"deployed","channels":[` +
+		`{"type":"email_channel","address":"ops@example.com"},` +
+		`{"type":"sms","phone":"+15550100"},` +
+		`{"type":"webhook_channel","url":"https://example.com/hook"}]}`
+	var notification Notification
+	if err := json.Unmarshal([]byte(input), &notification); err != nil {
+		t.Fatal(err)
+	}
+	want := []Channel{
+		EmailChannel{Address: "ops@example.com"},
+		SMSChannel{Phone: "+15550100"},
+		&WebhookChannel{URL: "https://example.com/hook"},
+	}
+	if !reflect.DeepEqual(notification.Channels, want) {
+		t.Fatalf("Channels = %#v, want %#v", notification.Channels, want)
+	}
+
+	data, err := json.Marshal(notification)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Fatalf("json.Marshal = %s, want %s", data, input)
+	}
+}
+
+func TestNotificationSchemaListsDiscoveredChannels(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			Items struct {
+				AnyOf []struct {
+					Properties struct {
+						Type struct {
+							Const string `json:"const"`
+						} `json:"type"`
+					} `json:"properties"`
+				} `json:"anyOf"`
+			} `json:"items"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(Notification{}.Schema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	var values []string
+	for _, option := range schema.Properties["channels"].Items.AnyOf {
+		values = append(values, option.Properties.Type.Const)
+	}
+	if want := []string{"email_channel", "sms", "webhook_channel"}; !reflect.DeepEqual(values, want) {
+		t.Fatalf("wire values = %v, want %v", values, want)
+	}
+}
@@ -0,0 +1,38 @@
+// Package discovered_impls shows an interface union whose implementations
+// are found by the generator rather than listed one by one.
+package discovered_impls
+
+//go:generate go run ../../gen-jsonschema/ --pretty
+
+// Channel delivers a notification. Its unexported method seals it, so only
+// types in this package implement it.
+type Channel interface {
+	isChannel()
+}
+
+// EmailChannel sends the notification by email.
+type EmailChannel struct {
+	Address string `json:"address"`
+}
+
+func (EmailChannel) isChannel() {}
+
+// SMSChannel sends the notification as a text message.
+type SMSChannel struct {
+	Phone string `json:"phone"`
+}
+
+func (SMSChannel) isChannel() {}
+
+// WebhookChannel posts the notification to a URL.
+type WebhookChannel struct {
+	URL string `json:"url"`
+}
+
+func (*WebhookChannel) isChannel() {}
+
+// Notification is a message and the channels that deliver it.
+type Notification struct {
+	Message  string    `json:"message"`
+	Channels []Channel `json:"channels"`
+}
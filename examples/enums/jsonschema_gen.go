@@ -54,3 +54,31 @@ func (SliceOfStatus) Schema() json.RawMessage {
 	}
 	return data
 }
+
+// IsValid reports whether v is one of the Priority constants.
+func (v Priority) IsValid() bool {
+	switch v {
+	case PriorityLow, PriorityMedium, PriorityHigh:
+		return true
+	}
+	return false
+}
+
+// PriorityValues returns the Priority constants in declaration order.
+func PriorityValues() []Priority {
+	return []Priority{PriorityLow, PriorityMedium, PriorityHigh}
+}
+
+// IsValid reports whether v is one of the Status constants.
+func (v Status) IsValid() bool {
+	switch v {
+	case StatusPending, StatusInProgress, StatusCompleted, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// StatusValues returns the Status constants in declaration order.
+func StatusValues() []Status {
+	return []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed}
+}
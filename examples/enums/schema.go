@@ -37,8 +37,11 @@ var (
 	// Register Priority for schema generation
 	_ = jsonschema.NewJSONSchemaMethod(Priority.Schema)
 
-	// Register Task for schema generation
-	_ = jsonschema.NewJSONSchemaMethod(Task.Schema)
+	// Register Task for schema generation. EnumDescriptions documents each
+	// Status value with its constant's doc comment.
+	_ = jsonschema.NewJSONSchemaMethod(Task.Schema,
+		jsonschema.WithEnum(Task{}.Status, jsonschema.EnumDescriptions()),
+	)
 
 	// Register SliceOfStatus for schema generation
 	_ = jsonschema.NewJSONSchemaMethod(SliceOfStatus.Schema)
@@ -0,0 +1,16 @@
+//go:build jsonschema
+
+package interface_containers
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Workflow) Schema() json.RawMessage { panic("not implemented") }
+
+var (
+	_ = jsonschema.NewJSONSchemaMethod(Workflow.Schema)
+	_ = jsonschema.NewInterfaceImpl[Step](Run{}, (*Wait)(nil))
+)
@@ -0,0 +1,80 @@
+package interface_containers
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func TestWorkflowRoundTripsNestedContainers(t *testing.T) {
+	workflow := Workflow{
+		Stages:   [][]Step{{Run{Command: "build"}, &Wait{Seconds: 1}}, nil, {}},
+		Handlers: map[string]Step{"failure": Run{Command: "page"}},
+		Bracket:  [2]Step{Run{Command: "setup"}, Run{Command: "teardown"}},
+		Cleanup:  Steps{&Wait{Seconds: 2}},
+		Retries:  jsonschema.Optional[[]Step]{Present: true, Value: []Step{Run{Command: "retry"}}},
+		Hooks:    map[string][]Step{"start": {&Wait{Seconds: 3}}, "stop": nil},
+	}
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"stages":[[{"type":"Run","command":"build"},{"type":"Wait","seconds":1}],null,[]]`) {
+		t.Fatalf("json.Marshal = %s, want discriminated nested stages", data)
+	}
+	if !strings.Contains(string(data), `"hooks":{"start":[{"type":"Wait","seconds":3}],"stop":null}`) {
+		t.Fatalf("json.Marshal = %s, want discriminated hooks", data)
+	}
+
+	var decoded Workflow
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, workflow) {
+		t.Fatalf("round trip = %#v, want %#v", decoded, workflow)
+	}
+}
+
+func TestWorkflowUnmarshalErrorsLocateTheValue(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: `{"stages":[[],[{"type":"Other"}]]}`, want: "field stages[1][0]: "},
+		{input: `{"handlers":{"failure":{"command":"page"}}}`, want: `field handlers["failure"]: `},
+		{input: `{"hooks":{"start":{}}}`, want: `field hooks["start"]: `},
+	}
+	for _, test := range tests {
+		original := Workflow{Cleanup: Steps{Run{Command: "keep"}}}
+		got := original
+		err := json.Unmarshal([]byte(test.input), &got)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Fatalf("json.Unmarshal(%s) error = %v, want %q", test.input, err, test.want)
+		}
+		if !reflect.DeepEqual(got, original) {
+			t.Fatalf("failed decode mutated destination: got %#v", got)
+		}
+	}
+}
+
+func TestWorkflowUnmarshalMissingFieldsKeepValues(t *testing.T) {
+	original := Workflow{
+		Handlers: map[string]Step{"failure": Run{Command: "page"}},
+		Bracket:  [2]Step{Run{Command: "setup"}},
+	}
+	got := original
+	if err := json.Unmarshal([]byte(`{"cleanup":[{"type":"Run","command":"tidy"}]}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := original
+	want.Cleanup = Steps{Run{Command: "tidy"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("json.Unmarshal = %#v, want %#v", got, want)
+	}
+	if got.Retries.Present {
+		t.Fatal("absent retries decoded as present")
+	}
+}
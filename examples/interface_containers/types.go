@@ -0,0 +1,45 @@
+// Package interface_containers shows registered interface unions nested in
+// slices, arrays and maps.
+package interface_containers
+
+//go:generate go run ../../gen-jsonschema/ --pretty
+
+import jsonschema "github.com/tylergannon/go-gen-jsonschema"
+
+// Step is one action of a workflow.
+type Step interface {
+	isStep()
+}
+
+// Run executes a command.
+type Run struct {
+	Command string `json:"command"`
+}
+
+func (Run) isStep() {}
+
+// Wait pauses the workflow.
+type Wait struct {
+	Seconds int `json:"seconds"`
+}
+
+func (*Wait) isStep() {}
+
+// Steps is a named slice of steps.
+type Steps []Step
+
+// Workflow holds steps in every supported container shape.
+type Workflow struct {
+	// Stages run in order; the steps of a stage run in parallel.
+	Stages [][]Step `json:"stages"`
+	// Handlers maps an event name to the step that handles it.
+	Handlers map[string]Step `json:"handlers"`
+	// Setup and Teardown bracket the workflow.
+	Bracket [2]Step `json:"bracket"`
+	// Cleanup runs after every stage.
+	Cleanup Steps `json:"cleanup"`
+	// Retries are the steps to run on failure, when given.
+	Retries jsonschema.Optional[[]Step] `json:"retries,omitzero"`
+	// Hooks maps an event name to its steps.
+	Hooks map[string][]Step `json:"hooks"`
+}
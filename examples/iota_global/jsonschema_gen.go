@@ -27,3 +27,33 @@ func (Task) Schema() json.RawMessage {
 	}
 	return data
 }
+
+// IsValid reports whether v is one of the Priority constants.
+func (v Priority) IsValid() bool {
+	switch v {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// PriorityValues returns the Priority constants in declaration order.
+func PriorityValues() []Priority {
+	return []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+}
+
+// ParsePriority returns the Priority constant whose decimal value is s.
+func ParsePriority(s string) (Priority, error) {
+	switch s {
+	case "0":
+		return PriorityLow, nil
+	case "1":
+		return PriorityMedium, nil
+	case "2":
+		return PriorityHigh, nil
+	case "3":
+		return PriorityUrgent, nil
+	}
+	var zero Priority
+	return zero, fmt.Errorf("unknown Priority %q", s)
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	jsonschema "github.com/santhosh-tekuri/jsonschema/v6"
+	genjsonschema "github.com/tylergannon/go-gen-jsonschema"
 )
 
 //go:embed jsonschema
@@ -29,32 +30,35 @@ var (
 )
 
 func init() {
-	compile := func(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
-		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
-		}
-		c := jsonschema.NewCompiler()
-		url := typeName + ".json"
-		if err := c.AddResource(url, doc); err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
-		}
-		sch, err := c.Compile(url)
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
-		}
-		return sch
-	}
-
 	{
 		var __zero Config
-		__gen_jsonschema_compiled_Config = compile("Config", __zero.Schema())
+		__gen_jsonschema_compiled_Config = __gen_jsonschema_compile("Config", __zero.Schema())
 	}
 
 	{
 		var __zero NumericConfig
-		__gen_jsonschema_compiled_NumericConfig = compile("NumericConfig", __zero.Schema())
+		__gen_jsonschema_compiled_NumericConfig = __gen_jsonschema_compile("NumericConfig", __zero.Schema())
+	}
+}
+
+// __gen_jsonschema_compile compiles a generated schema. The schemas are
+// produced by the generator, so a failure here is a generator bug.
+func __gen_jsonschema_compile(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
+	}
+	c := jsonschema.NewCompiler()
+	c.AssertFormat()
+	url := typeName + ".json"
+	if err := c.AddResource(url, doc); err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
+	}
+	sch, err := c.Compile(url)
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
 	}
+	return sch
 }
 
 func (Config) Schema() json.RawMessage {
@@ -76,21 +80,61 @@ func (NumericConfig) Schema() json.RawMessage {
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Config.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Config) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Config.Validate(inst)
+	if err = __gen_jsonschema_compiled_Config.Validate(inst); err != nil {
+		var __zero Config
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseConfig validates data against the schema for Config and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseConfig(data []byte) (Config, error) {
+	var value Config
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Config", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Config
+		return zero, genjsonschema.NewParseError("Config", err)
+	}
+	return value, nil
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for NumericConfig.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (NumericConfig) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_NumericConfig.Validate(inst)
+	if err = __gen_jsonschema_compiled_NumericConfig.Validate(inst); err != nil {
+		var __zero NumericConfig
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseNumericConfig validates data against the schema for NumericConfig and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseNumericConfig(data []byte) (NumericConfig, error) {
+	var value NumericConfig
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("NumericConfig", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero NumericConfig
+		return zero, genjsonschema.NewParseError("NumericConfig", err)
+	}
+	return value, nil
 }
 
 // UnmarshalJSON is a generated custom json.Unmarshaler implementation for
@@ -117,6 +161,29 @@ func (c *Config) UnmarshalJSON(data []byte) (err error) {
 	*c = __next
 	return nil
 }
+
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// Config. Interface values are written with their discriminator.
+func (c Config) MarshalJSON() ([]byte, error) {
+	type Alias Config
+	type Wrapper struct {
+		Alias
+		Pet json.RawMessage `json:"pet,omitzero"`
+	}
+	var (
+		wrapper = Wrapper{Alias: Alias(c)}
+		err     error
+	)
+
+	if c.Pet.Present {
+		if wrapper.Pet, err = __jsonMarshal__optionality__Pet__Config__Pet(c.Pet.Value); err != nil {
+			return nil, fmt.Errorf("field pet: %w", err)
+		}
+	}
+
+	return json.Marshal(wrapper)
+}
+
 func __jsonUnmarshal__optionality__Pet__Config__Pet(data []byte) (Pet, error) {
 	var (
 		temp          map[string]json.RawMessage
@@ -150,6 +217,62 @@ func __jsonUnmarshal__optionality__Pet__Config__Pet(data []byte) (Pet, error) {
 	}
 }
 
+func __jsonMarshal__optionality__Pet__Config__Pet(value Pet) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case Dog, *Dog:
+		discriminator = "Dog"
+	case Cat, *Cat:
+		discriminator = "Cat"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of Pet: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("!kind", discriminator, value)
+}
+
 func __jsonschema__unmarshalDiscriminatorError(discriminator json.RawMessage, err error) error {
 	return fmt.Errorf("unable to unmarshal discriminator value %v: %w", discriminator, err)
 }
+
+// __jsonschema__marshalWithDiscriminator encodes value, which must be a JSON
+// object, with the discriminator property written first. A value whose own
+// MarshalJSON already writes the discriminator is kept as it is.
+func __jsonschema__marshalWithDiscriminator(propName, discriminator string, value any) (json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("%T must marshal to a JSON object to carry discriminator %q", value, discriminator)
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if existing, ok := fields[propName]; ok {
+		var written string
+		if err = json.Unmarshal(existing, &written); err != nil || written != discriminator {
+			return nil, fmt.Errorf("%T writes %s %s, want %q", value, propName, existing, discriminator)
+		}
+		return data, nil
+	}
+	key, err := json.Marshal(propName)
+	if err != nil {
+		return nil, err
+	}
+	val, err := json.Marshal(discriminator)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)+len(key)+len(val)+2)
+	out = append(out, '{')
+	out = append(out, key...)
+	out = append(out, ':')
+	out = append(out, val...)
+	if data[1] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, data[1:]...), nil
+}
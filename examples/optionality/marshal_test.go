@@ -0,0 +1,20 @@
+package optionality
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func TestMarshalKeepsDiscriminatorWrittenByImplementation(t *testing.T) {
+	config := Config{Name: "pet", Pet: jsonschema.Optional[Pet]{Present: true, Value: Dog{Name: "Rex"}}}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"name":"pet","timeout":null,"detail":null,"pet":{"!kind":"Dog","name":"Rex"}}`
+	if string(data) != want {
+		t.Fatalf("json.Marshal = %s, want %s", data, want)
+	}
+}
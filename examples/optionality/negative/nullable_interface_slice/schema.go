@@ -1,6 +1,6 @@
 //go:build jsonschema
 
-package nullable_interface
+package nullable_interface_slice
 
 import (
 	"encoding/json"
@@ -12,6 +12,6 @@ func (Config) Schema() json.RawMessage { panic("not implemented") }
 
 var _ = jsonschema.NewJSONSchemaMethod(
 	Config.Schema,
-	jsonschema.WithInterface(Config{}.Value),
-	jsonschema.WithInterfaceImpls(Config{}.Value, Text{}),
+	jsonschema.WithInterface(Config{}.Values),
+	jsonschema.WithInterfaceImpls(Config{}.Values, Text{}),
 )
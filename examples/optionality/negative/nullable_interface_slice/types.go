@@ -1,4 +1,4 @@
-package nullable_interface
+package nullable_interface_slice
 
 import jsonschema "github.com/tylergannon/go-gen-jsonschema"
 
@@ -11,5 +11,5 @@ type Text struct {
 func (Text) value() {}
 
 type Config struct {
-	Value jsonschema.Nullable[Value] `json:"value"`
+	Values jsonschema.Nullable[[]Value] `json:"values"`
 }
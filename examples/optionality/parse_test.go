@@ -0,0 +1,58 @@
+package optionality
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func TestParseConfigValidatesBeforeDecoding(t *testing.T) {
+	config, err := ParseConfig([]byte(`{"name":"api","timeout":null,"detail":{"message":"ok"}}`))
+	if err != nil {
+		t.Fatalf("ParseConfig: %v", err)
+	}
+	if config.Name != "api" || config.Timeout.Present || !config.Detail.Present || config.MaxRetries.Present {
+		t.Fatalf("ParseConfig = %+v", config)
+	}
+
+	// json.Unmarshal alone accepts a missing Nullable key and reports it as
+	// null; ParseConfig rejects it against the schema.
+	missing := []byte(`{"name":"api","detail":null}`)
+	var decoded Config
+	if err := json.Unmarshal(missing, &decoded); err != nil || decoded.Timeout.Present {
+		t.Fatalf("json.Unmarshal = %+v, %v", decoded, err)
+	}
+	_, err = ParseConfig(missing)
+	var parseErr *jsonschema.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Kind != jsonschema.ParseSchemaViolation || parseErr.Type != "Config" {
+		t.Fatalf("ParseConfig error = %#v, want a Config schema violation", err)
+	}
+	violation, ok := parseErr.SchemaViolation()
+	if !ok || !slices.Equal(violation.InstanceLocations(), []string{"/timeout"}) {
+		t.Fatalf("SchemaViolation = %v, %v", violation, ok)
+	}
+}
+
+func TestParseConfigReportsDecodeFailures(t *testing.T) {
+	for name, data := range map[string]string{
+		"malformed": `{"name":`,
+		"overflow":  `{"name":"api","timeout":100000000000000000000,"detail":null}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			config, err := ParseConfig([]byte(data))
+			var parseErr *jsonschema.ParseError
+			if !errors.As(err, &parseErr) || parseErr.Kind != jsonschema.ParseDecodeFailure {
+				t.Fatalf("ParseConfig error = %v, want a decode failure", err)
+			}
+			if _, ok := parseErr.SchemaViolation(); ok {
+				t.Fatal("decode failure reported a schema violation")
+			}
+			if config.Name != "" {
+				t.Fatalf("ParseConfig returned a partial value %+v", config)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Package actions declares the Action interface. Each kind of action lives
+// in its own package beside this one.
+package actions
+
+// Action is one step a workflow runs.
+type Action interface {
+	Describe() string
+}
@@ -0,0 +1,10 @@
+// Package email sends email from a workflow.
+package email
+
+// Send emails a message.
+type Send struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+}
+
+func (s Send) Describe() string { return "email " + s.To }
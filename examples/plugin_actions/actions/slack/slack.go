@@ -0,0 +1,10 @@
+// Package slack posts to Slack from a workflow.
+package slack
+
+// Post posts a message to a channel.
+type Post struct {
+	Channel string `json:"channel"`
+	Text    string `json:"text"`
+}
+
+func (p *Post) Describe() string { return "post to " + p.Channel }
@@ -0,0 +1,22 @@
+//go:build jsonschema
+
+package workflow
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+	"github.com/tylergannon/go-gen-jsonschema/examples/plugin_actions/actions/email"
+	"github.com/tylergannon/go-gen-jsonschema/examples/plugin_actions/actions/slack"
+)
+
+func (Workflow) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(
+	Workflow.Schema,
+	jsonschema.WithInterface(Workflow{}.Steps,
+		jsonschema.Impl("email", email.Send{}),
+		jsonschema.Impl("slack", (*slack.Post)(nil)),
+		jsonschema.Impl("wait", Wait{}),
+	),
+)
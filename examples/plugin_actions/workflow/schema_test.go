@@ -0,0 +1,45 @@
+package workflow
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/tylergannon/go-gen-jsonschema/examples/plugin_actions/actions"
+	"github.com/tylergannon/go-gen-jsonschema/examples/plugin_actions/actions/email"
+	"github.com/tylergannon/go-gen-jsonschema/examples/plugin_actions/actions/slack"
+)
+
+func TestWorkflowDecodesActionsFromSiblingPackages(t *testing.T) {
+	const input = `{"name":"onboard","steps":[` +
+		`{"type":"email","to":"new@example.com","subject":"Welcome"},` +
+		`{"type":"wait","seconds":60},` +
+		`{"type":"slack","channel":"#hires","text":"Say hi"}]}`
+	want := Workflow{
+		Name: "onboard",
+		Steps: []actions.Action{
+			email.Send{To: "new@example.com", Subject: "Welcome"},
+			Wait{Seconds: 60},
+			&slack.Post{Channel: "#hires", Text: "Say hi"},
+		},
+	}
+
+	if err := (Workflow{}).ValidateJSON([]byte(input)); err != nil {
+		t.Fatal(err)
+	}
+	var workflow Workflow
+	if err := json.Unmarshal([]byte(input), &workflow); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(workflow, want) {
+		t.Fatalf("json.Unmarshal = %#v, want %#v", workflow, want)
+	}
+
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Fatalf("json.Marshal = %s, want %s", data, input)
+	}
+}
@@ -0,0 +1,20 @@
+// Package workflow registers a schema whose interface implementations are
+// declared in sibling packages.
+package workflow
+
+//go:generate go run ../../../gen-jsonschema/ --pretty --validate
+
+import "github.com/tylergannon/go-gen-jsonschema/examples/plugin_actions/actions"
+
+// Wait pauses the workflow. It implements actions.Action in this package.
+type Wait struct {
+	Seconds int `json:"seconds"`
+}
+
+func (w Wait) Describe() string { return "wait" }
+
+// Workflow is a named list of actions.
+type Workflow struct {
+	Name  string           `json:"name"`
+	Steps []actions.Action `json:"steps"`
+}
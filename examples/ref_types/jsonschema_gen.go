@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	jsonschema "github.com/santhosh-tekuri/jsonschema/v6"
+	genjsonschema "github.com/tylergannon/go-gen-jsonschema"
 )
 
 //go:embed jsonschema
@@ -30,37 +31,40 @@ var (
 )
 
 func init() {
-	compile := func(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
-		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
-		}
-		c := jsonschema.NewCompiler()
-		url := typeName + ".json"
-		if err := c.AddResource(url, doc); err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
-		}
-		sch, err := c.Compile(url)
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
-		}
-		return sch
-	}
-
 	{
 		var __zero Shared
-		__gen_jsonschema_compiled_Shared = compile("Shared", __zero.Schema())
+		__gen_jsonschema_compiled_Shared = __gen_jsonschema_compile("Shared", __zero.Schema())
 	}
 
 	{
 		var __zero Container
-		__gen_jsonschema_compiled_Container = compile("Container", __zero.Schema())
+		__gen_jsonschema_compiled_Container = __gen_jsonschema_compile("Container", __zero.Schema())
 	}
 
 	{
 		var __zero NullableConfig
-		__gen_jsonschema_compiled_NullableConfig = compile("NullableConfig", __zero.Schema())
+		__gen_jsonschema_compiled_NullableConfig = __gen_jsonschema_compile("NullableConfig", __zero.Schema())
+	}
+}
+
+// __gen_jsonschema_compile compiles a generated schema. The schemas are
+// produced by the generator, so a failure here is a generator bug.
+func __gen_jsonschema_compile(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
+	}
+	c := jsonschema.NewCompiler()
+	c.AssertFormat()
+	url := typeName + ".json"
+	if err := c.AddResource(url, doc); err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
+	}
+	sch, err := c.Compile(url)
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
 	}
+	return sch
 }
 
 func (Shared) Schema() json.RawMessage {
@@ -91,28 +95,114 @@ func (NullableConfig) Schema() json.RawMessage {
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Shared.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Shared) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Shared.Validate(inst)
+	if err = __gen_jsonschema_compiled_Shared.Validate(inst); err != nil {
+		var __zero Shared
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseShared validates data against the schema for Shared and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseShared(data []byte) (Shared, error) {
+	var value Shared
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Shared", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Shared
+		return zero, genjsonschema.NewParseError("Shared", err)
+	}
+	return value, nil
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Container.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Container) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Container.Validate(inst)
+	if err = __gen_jsonschema_compiled_Container.Validate(inst); err != nil {
+		var __zero Container
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseContainer validates data against the schema for Container and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseContainer(data []byte) (Container, error) {
+	var value Container
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Container", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Container
+		return zero, genjsonschema.NewParseError("Container", err)
+	}
+	return value, nil
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for NullableConfig.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (NullableConfig) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_NullableConfig.Validate(inst)
+	if err = __gen_jsonschema_compiled_NullableConfig.Validate(inst); err != nil {
+		var __zero NullableConfig
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseNullableConfig validates data against the schema for NullableConfig and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseNullableConfig(data []byte) (NullableConfig, error) {
+	var value NullableConfig
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("NullableConfig", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero NullableConfig
+		return zero, genjsonschema.NewParseError("NullableConfig", err)
+	}
+	return value, nil
+}
+
+// IsValid reports whether v is one of the Mode constants.
+func (v Mode) IsValid() bool {
+	switch v {
+	case ModeFast, ModeSafe:
+		return true
+	}
+	return false
+}
+
+// ModeValues returns the Mode constants in declaration order.
+func ModeValues() []Mode {
+	return []Mode{ModeFast, ModeSafe}
+}
+
+// ParseMode returns the Mode constant whose value is s.
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "fast":
+		return ModeFast, nil
+	case "safe":
+		return ModeSafe, nil
+	}
+	var zero Mode
+	return zero, fmt.Errorf("unknown Mode %q", s)
 }
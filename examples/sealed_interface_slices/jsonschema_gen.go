@@ -64,6 +64,35 @@ func (b *Batch) UnmarshalJSON(data []byte) (err error) {
 	*b = __next
 	return nil
 }
+
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// Batch. Interface values are written with their discriminator.
+func (b Batch) MarshalJSON() ([]byte, error) {
+	type Alias Batch
+	type Wrapper struct {
+		Alias
+		Events json.RawMessage `json:"events"`
+	}
+	var (
+		wrapper = Wrapper{Alias: Alias(b)}
+		err     error
+	)
+
+	if b.Events != nil {
+		__raw0 := make([]json.RawMessage, len(b.Events))
+		for __index, __value := range b.Events {
+			if __raw0[__index], err = __jsonMarshal__sealed_interface_slices__Event__Batch__Events(__value); err != nil {
+				return nil, fmt.Errorf("field events[%d]: %w", __index, err)
+			}
+		}
+		if wrapper.Events, err = json.Marshal(__raw0); err != nil {
+			return nil, fmt.Errorf("field events: %w", err)
+		}
+	}
+
+	return json.Marshal(wrapper)
+}
+
 func __jsonUnmarshal__sealed_interface_slices__Event__Batch__Events(data []byte) (Event, error) {
 	var (
 		temp          map[string]json.RawMessage
@@ -97,6 +126,62 @@ func __jsonUnmarshal__sealed_interface_slices__Event__Batch__Events(data []byte)
 	}
 }
 
+func __jsonMarshal__sealed_interface_slices__Event__Batch__Events(value Event) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case Created, *Created:
+		discriminator = "Created"
+	case *Deleted:
+		discriminator = "Deleted"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of Event: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("!kind", discriminator, value)
+}
+
 func __jsonschema__unmarshalDiscriminatorError(discriminator json.RawMessage, err error) error {
 	return fmt.Errorf("unable to unmarshal discriminator value %v: %w", discriminator, err)
 }
+
+// __jsonschema__marshalWithDiscriminator encodes value, which must be a JSON
+// object, with the discriminator property written first. A value whose own
+// MarshalJSON already writes the discriminator is kept as it is.
+func __jsonschema__marshalWithDiscriminator(propName, discriminator string, value any) (json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("%T must marshal to a JSON object to carry discriminator %q", value, discriminator)
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if existing, ok := fields[propName]; ok {
+		var written string
+		if err = json.Unmarshal(existing, &written); err != nil || written != discriminator {
+			return nil, fmt.Errorf("%T writes %s %s, want %q", value, propName, existing, discriminator)
+		}
+		return data, nil
+	}
+	key, err := json.Marshal(propName)
+	if err != nil {
+		return nil, err
+	}
+	val, err := json.Marshal(discriminator)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)+len(key)+len(val)+2)
+	out = append(out, '{')
+	out = append(out, key...)
+	out = append(out, ':')
+	out = append(out, val...)
+	if data[1] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, data[1:]...), nil
+}
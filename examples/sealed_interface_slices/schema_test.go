@@ -2,6 +2,7 @@ package sealed_interface_slices
 
 import (
 	"encoding/json"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -42,6 +43,43 @@ func TestBatchSchemaPlacesUnionUnderArrayItems(t *testing.T) {
 	}
 }
 
+func TestBatchGeminiDialectUsesEnumDiscriminators(t *testing.T) {
+	data, err := os.ReadFile("jsonschema/Batch.gemini.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		PropertyOrdering []string `json:"propertyOrdering"`
+		Properties       map[string]struct {
+			Items struct {
+				AnyOf []struct {
+					Properties map[string]struct {
+						Const *string  `json:"const"`
+						Enum  []string `json:"enum"`
+					} `json:"properties"`
+					PropertyOrdering []string `json:"propertyOrdering"`
+				} `json:"anyOf"`
+			} `json:"items"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(schema.PropertyOrdering, []string{"events"}) {
+		t.Fatalf("propertyOrdering = %v, want [events]", schema.PropertyOrdering)
+	}
+	for i, want := range []string{"Created", "Deleted"} {
+		option := schema.Properties["events"].Items.AnyOf[i]
+		kind := option.Properties["!kind"]
+		if kind.Const != nil || !reflect.DeepEqual(kind.Enum, []string{want}) {
+			t.Fatalf("anyOf[%d] !kind = const %v enum %v, want enum [%s]", i, kind.Const, kind.Enum, want)
+		}
+		if len(option.PropertyOrdering) == 0 || option.PropertyOrdering[0] != "!kind" {
+			t.Fatalf("anyOf[%d] propertyOrdering = %v, want !kind first", i, option.PropertyOrdering)
+		}
+	}
+}
+
 func TestBatchUnmarshalInterfaceSlice(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		var got Batch
@@ -67,7 +105,7 @@ func TestBatchUnmarshalInterfaceSlice(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if string(remarshaled) != `{"events":[{"name":"first"},{"id":"gone"}]}` {
+		if string(remarshaled) != `{"events":[{"!kind":"Created","name":"first"},{"!kind":"Deleted","id":"gone"}]}` {
 			t.Fatalf("re-marshaled batch = %s", remarshaled)
 		}
 	})
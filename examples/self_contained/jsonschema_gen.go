@@ -27,3 +27,61 @@ func (Issue) Schema() json.RawMessage {
 	}
 	return data
 }
+
+// IsValid reports whether v is one of the Priority constants.
+func (v Priority) IsValid() bool {
+	switch v {
+	case PriorityLow, PriorityMedium, PriorityHigh:
+		return true
+	}
+	return false
+}
+
+// PriorityValues returns the Priority constants in declaration order.
+func PriorityValues() []Priority {
+	return []Priority{PriorityLow, PriorityMedium, PriorityHigh}
+}
+
+// ParsePriority returns the Priority constant whose value is s.
+func ParsePriority(s string) (Priority, error) {
+	switch s {
+	case "low":
+		return PriorityLow, nil
+	case "medium":
+		return PriorityMedium, nil
+	case "high":
+		return PriorityHigh, nil
+	}
+	var zero Priority
+	return zero, fmt.Errorf("unknown Priority %q", s)
+}
+
+// IsValid reports whether v is one of the Severity constants.
+func (v Severity) IsValid() bool {
+	switch v {
+	case SeverityInfo, SeverityWarning, SeverityError, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// SeverityValues returns the Severity constants in declaration order.
+func SeverityValues() []Severity {
+	return []Severity{SeverityInfo, SeverityWarning, SeverityError, SeverityCritical}
+}
+
+// ParseSeverity returns the Severity constant whose value is s.
+func ParseSeverity(s string) (Severity, error) {
+	switch s {
+	case "info":
+		return SeverityInfo, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	case "critical":
+		return SeverityCritical, nil
+	}
+	var zero Severity
+	return zero, fmt.Errorf("unknown Severity %q", s)
+}
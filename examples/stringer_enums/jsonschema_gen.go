@@ -36,3 +36,181 @@ func (Task) Schema() json.RawMessage {
 	}
 	return data
 }
+
+// UnmarshalJSON is a generated custom json.Unmarshaler implementation for
+// ApplicationConfig.
+func (a *ApplicationConfig) UnmarshalJSON(data []byte) (err error) {
+	type Alias ApplicationConfig
+	type Wrapper struct {
+		Alias
+		DefaultPriority __jsonschema_enum_Priority `json:"default_priority"`
+		LogLevel        __jsonschema_enum_LogLevel `json:"log_level"`
+	}
+	var wrapper Wrapper
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return err
+	}
+	__next := ApplicationConfig(wrapper.Alias)
+	__next.DefaultPriority = Priority(wrapper.DefaultPriority)
+	__next.LogLevel = LogLevel(wrapper.LogLevel)
+
+	*a = __next
+	return nil
+}
+
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// ApplicationConfig.
+// Stringer enum fields are written as constant names.
+func (a ApplicationConfig) MarshalJSON() ([]byte, error) {
+	type Alias ApplicationConfig
+	type Wrapper struct {
+		Alias
+		DefaultPriority __jsonschema_enum_Priority `json:"default_priority"`
+		LogLevel        __jsonschema_enum_LogLevel `json:"log_level"`
+	}
+	wrapper := Wrapper{Alias: Alias(a)}
+	wrapper.DefaultPriority = __jsonschema_enum_Priority(a.DefaultPriority)
+	wrapper.LogLevel = __jsonschema_enum_LogLevel(a.LogLevel)
+
+	return json.Marshal(wrapper)
+}
+
+// __jsonschema_enum_Priority encodes Priority fields by constant name for their
+// owners' generated JSON methods.
+type __jsonschema_enum_Priority Priority
+
+// MarshalText encodes Priority as its constant name, the form its
+// WithStringerEnum schema lists.
+func (v __jsonschema_enum_Priority) MarshalText() ([]byte, error) {
+	switch Priority(v) {
+	case PriorityLow:
+		return []byte("low"), nil
+	case PriorityNormal:
+		return []byte("normal"), nil
+	case PriorityHigh:
+		return []byte("high"), nil
+	case PriorityUrgent:
+		return []byte("urgent"), nil
+	}
+	return nil, fmt.Errorf("invalid Priority value %d", v)
+}
+
+// UnmarshalText decodes Priority from its constant name.
+func (v *__jsonschema_enum_Priority) UnmarshalText(data []byte) error {
+	switch string(data) {
+	case "low":
+		*v = __jsonschema_enum_Priority(PriorityLow)
+	case "normal":
+		*v = __jsonschema_enum_Priority(PriorityNormal)
+	case "high":
+		*v = __jsonschema_enum_Priority(PriorityHigh)
+	case "urgent":
+		*v = __jsonschema_enum_Priority(PriorityUrgent)
+	default:
+		return fmt.Errorf("unknown Priority %q", data)
+	}
+	return nil
+}
+
+// __jsonschema_enum_LogLevel encodes LogLevel fields by constant name for their
+// owners' generated JSON methods.
+type __jsonschema_enum_LogLevel LogLevel
+
+// MarshalText encodes LogLevel as its constant name, the form its
+// WithStringerEnum schema lists.
+func (v __jsonschema_enum_LogLevel) MarshalText() ([]byte, error) {
+	switch LogLevel(v) {
+	case LogDebug:
+		return []byte("debug"), nil
+	case LogInfo:
+		return []byte("info"), nil
+	case LogWarning:
+		return []byte("warning"), nil
+	case LogError:
+		return []byte("error"), nil
+	case LogFatal:
+		return []byte("fatal"), nil
+	}
+	return nil, fmt.Errorf("invalid LogLevel value %d", v)
+}
+
+// UnmarshalText decodes LogLevel from its constant name.
+func (v *__jsonschema_enum_LogLevel) UnmarshalText(data []byte) error {
+	switch string(data) {
+	case "debug":
+		*v = __jsonschema_enum_LogLevel(LogDebug)
+	case "info":
+		*v = __jsonschema_enum_LogLevel(LogInfo)
+	case "warning":
+		*v = __jsonschema_enum_LogLevel(LogWarning)
+	case "error":
+		*v = __jsonschema_enum_LogLevel(LogError)
+	case "fatal":
+		*v = __jsonschema_enum_LogLevel(LogFatal)
+	default:
+		return fmt.Errorf("unknown LogLevel %q", data)
+	}
+	return nil
+}
+
+// IsValid reports whether v is one of the LogLevel constants.
+func (v LogLevel) IsValid() bool {
+	switch v {
+	case LogDebug, LogInfo, LogWarning, LogError, LogFatal:
+		return true
+	}
+	return false
+}
+
+// LogLevelValues returns the LogLevel constants in declaration order.
+func LogLevelValues() []LogLevel {
+	return []LogLevel{LogDebug, LogInfo, LogWarning, LogError, LogFatal}
+}
+
+// ParseLogLevel returns the LogLevel constant whose WithStringerEnum name is s.
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch s {
+	case "debug":
+		return LogDebug, nil
+	case "info":
+		return LogInfo, nil
+	case "warning":
+		return LogWarning, nil
+	case "error":
+		return LogError, nil
+	case "fatal":
+		return LogFatal, nil
+	}
+	var zero LogLevel
+	return zero, fmt.Errorf("unknown LogLevel %q", s)
+}
+
+// IsValid reports whether v is one of the Priority constants.
+func (v Priority) IsValid() bool {
+	switch v {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// PriorityValues returns the Priority constants in declaration order.
+func PriorityValues() []Priority {
+	return []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent}
+}
+
+// ParsePriority returns the Priority constant whose WithStringerEnum name is s.
+func ParsePriority(s string) (Priority, error) {
+	switch s {
+	case "low":
+		return PriorityLow, nil
+	case "normal":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	case "urgent":
+		return PriorityUrgent, nil
+	}
+	var zero Priority
+	return zero, fmt.Errorf("unknown Priority %q", s)
+}
@@ -15,6 +15,12 @@ var _ = jsonschema.NewJSONSchemaMethod(
 	ApplicationConfig.Schema,
 	jsonschema.WithStringerEnum(ApplicationConfig{}.LogLevel),
 	jsonschema.WithStringerEnum(ApplicationConfig{}.DefaultPriority),
+	// LogLevel names its constants with jsonschema:"name" comments; these
+	// name Priority's.
+	jsonschema.WithEnumName(PriorityLow, "low"),
+	jsonschema.WithEnumName(PriorityNormal, "normal"),
+	jsonschema.WithEnumName(PriorityHigh, "high"),
+	jsonschema.WithEnumName(PriorityUrgent, "urgent"),
 )
 
 // Task schema with regular WithEnum (also WITHOUT NewEnumType!)
@@ -0,0 +1,73 @@
+package stringer_enums
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestApplicationConfigRoundTripsConstantNames(t *testing.T) {
+	config := ApplicationConfig{
+		AppName:         "api",
+		LogLevel:        LogWarning,
+		DefaultPriority: PriorityHigh,
+		MaxConnections:  10,
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["log_level"] != "warning" || fields["default_priority"] != "high" {
+		t.Fatalf("json.Marshal = %s, want constant names", data)
+	}
+
+	var decoded ApplicationConfig
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != config {
+		t.Fatalf("round trip = %+v, want %+v", decoded, config)
+	}
+
+	err = json.Unmarshal([]byte(`{"log_level":"LogWarning"}`), &decoded)
+	if err == nil || !strings.Contains(err.Error(), `unknown LogLevel "LogWarning"`) {
+		t.Fatalf("json.Unmarshal of a constant identifier: err = %v", err)
+	}
+}
+
+func TestTaskKeepsIntegerValues(t *testing.T) {
+	task := Task{ID: "1", Name: "ship", Priority: PriorityUrgent, LogLevel: LogError}
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"id":"1","name":"ship","priority":400,"log_level":3}`
+	if string(data) != want {
+		t.Fatalf("json.Marshal = %s, want %s", data, want)
+	}
+}
+
+func TestEnumHelpers(t *testing.T) {
+	level, err := ParseLogLevel("warning")
+	if err != nil || level != LogWarning {
+		t.Fatalf("ParseLogLevel(warning) = %v, %v", level, err)
+	}
+	if _, err = ParseLogLevel("LogWarning"); err == nil {
+		t.Fatal("ParseLogLevel accepted a constant identifier")
+	}
+	if got := LogLevelValues(); len(got) != 5 || got[0] != LogDebug || got[4] != LogFatal {
+		t.Fatalf("LogLevelValues() = %v", got)
+	}
+	for _, p := range PriorityValues() {
+		if !p.IsValid() {
+			t.Fatalf("%v.IsValid() = false", p)
+		}
+	}
+	if Priority(150).IsValid() {
+		t.Fatal("Priority(150).IsValid() = true")
+	}
+}
@@ -10,15 +10,15 @@ type LogLevel int
 
 const (
 	// LogDebug is for detailed diagnostic information
-	LogDebug LogLevel = iota
+	LogDebug LogLevel = iota // jsonschema:"debug"
 	// LogInfo is for general informational messages
-	LogInfo
+	LogInfo // jsonschema:"info"
 	// LogWarning is for warning messages
-	LogWarning
+	LogWarning // jsonschema:"warning"
 	// LogError is for error messages
-	LogError
+	LogError // jsonschema:"error"
 	// LogFatal is for fatal errors that cause termination
-	LogFatal
+	LogFatal // jsonschema:"fatal"
 )
 
 // String implements the Stringer interface for LogLevel
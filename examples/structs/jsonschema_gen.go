@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	jsonschema "github.com/santhosh-tekuri/jsonschema/v6"
+	genjsonschema "github.com/tylergannon/go-gen-jsonschema"
 )
 
 //go:embed jsonschema
@@ -33,52 +34,55 @@ var (
 )
 
 func init() {
-	compile := func(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
-		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
-		}
-		c := jsonschema.NewCompiler()
-		url := typeName + ".json"
-		if err := c.AddResource(url, doc); err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
-		}
-		sch, err := c.Compile(url)
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
-		}
-		return sch
-	}
-
 	{
 		var __zero Address
-		__gen_jsonschema_compiled_Address = compile("Address", __zero.Schema())
+		__gen_jsonschema_compiled_Address = __gen_jsonschema_compile("Address", __zero.Schema())
 	}
 
 	{
 		var __zero ContactInfo
-		__gen_jsonschema_compiled_ContactInfo = compile("ContactInfo", __zero.Schema())
+		__gen_jsonschema_compiled_ContactInfo = __gen_jsonschema_compile("ContactInfo", __zero.Schema())
 	}
 
 	{
 		var __zero RetryPolicy
-		__gen_jsonschema_compiled_RetryPolicy = compile("RetryPolicy", __zero.Schema())
+		__gen_jsonschema_compiled_RetryPolicy = __gen_jsonschema_compile("RetryPolicy", __zero.Schema())
 	}
 
 	{
 		var __zero Person
-		__gen_jsonschema_compiled_Person = compile("Person", __zero.Schema())
+		__gen_jsonschema_compiled_Person = __gen_jsonschema_compile("Person", __zero.Schema())
 	}
 
 	{
 		var __zero Organization
-		__gen_jsonschema_compiled_Organization = compile("Organization", __zero.Schema())
+		__gen_jsonschema_compiled_Organization = __gen_jsonschema_compile("Organization", __zero.Schema())
 	}
 
 	{
 		var __zero Department
-		__gen_jsonschema_compiled_Department = compile("Department", __zero.Schema())
+		__gen_jsonschema_compiled_Department = __gen_jsonschema_compile("Department", __zero.Schema())
+	}
+}
+
+// __gen_jsonschema_compile compiles a generated schema. The schemas are
+// produced by the generator, so a failure here is a generator bug.
+func __gen_jsonschema_compile(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
 	}
+	c := jsonschema.NewCompiler()
+	c.AssertFormat()
+	url := typeName + ".json"
+	if err := c.AddResource(url, doc); err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
+	}
+	sch, err := c.Compile(url)
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
+	}
+	return sch
 }
 
 func (Address) Schema() json.RawMessage {
@@ -136,55 +140,175 @@ func (Department) Schema() json.RawMessage {
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Address.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Address) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Address.Validate(inst)
+	if err = __gen_jsonschema_compiled_Address.Validate(inst); err != nil {
+		var __zero Address
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseAddress validates data against the schema for Address and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseAddress(data []byte) (Address, error) {
+	var value Address
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Address", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Address
+		return zero, genjsonschema.NewParseError("Address", err)
+	}
+	return value, nil
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for ContactInfo.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (ContactInfo) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_ContactInfo.Validate(inst)
+	if err = __gen_jsonschema_compiled_ContactInfo.Validate(inst); err != nil {
+		var __zero ContactInfo
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseContactInfo validates data against the schema for ContactInfo and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseContactInfo(data []byte) (ContactInfo, error) {
+	var value ContactInfo
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("ContactInfo", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero ContactInfo
+		return zero, genjsonschema.NewParseError("ContactInfo", err)
+	}
+	return value, nil
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for RetryPolicy.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (RetryPolicy) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_RetryPolicy.Validate(inst)
+	if err = __gen_jsonschema_compiled_RetryPolicy.Validate(inst); err != nil {
+		var __zero RetryPolicy
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseRetryPolicy validates data against the schema for RetryPolicy and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseRetryPolicy(data []byte) (RetryPolicy, error) {
+	var value RetryPolicy
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("RetryPolicy", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero RetryPolicy
+		return zero, genjsonschema.NewParseError("RetryPolicy", err)
+	}
+	return value, nil
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Person.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Person) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Person.Validate(inst)
+	if err = __gen_jsonschema_compiled_Person.Validate(inst); err != nil {
+		var __zero Person
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParsePerson validates data against the schema for Person and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParsePerson(data []byte) (Person, error) {
+	var value Person
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Person", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Person
+		return zero, genjsonschema.NewParseError("Person", err)
+	}
+	return value, nil
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Organization.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Organization) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Organization.Validate(inst)
+	if err = __gen_jsonschema_compiled_Organization.Validate(inst); err != nil {
+		var __zero Organization
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseOrganization validates data against the schema for Organization and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseOrganization(data []byte) (Organization, error) {
+	var value Organization
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Organization", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Organization
+		return zero, genjsonschema.NewParseError("Organization", err)
+	}
+	return value, nil
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Department.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Department) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Department.Validate(inst)
+	if err = __gen_jsonschema_compiled_Department.Validate(inst); err != nil {
+		var __zero Department
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseDepartment validates data against the schema for Department and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseDepartment(data []byte) (Department, error) {
+	var value Department
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Department", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Department
+		return zero, genjsonschema.NewParseError("Department", err)
+	}
+	return value, nil
 }
@@ -40,8 +40,18 @@ func (Department) Schema() json.RawMessage {
 
 // These marker variables register the types with the jsonschema generator.
 var (
-	// Register Address for schema generation
-	_ = jsonschema.NewJSONSchemaMethod(Address.Schema)
+	// Register Address for schema generation, with examples checked against
+	// its schema during generation
+	_ = jsonschema.NewJSONSchemaMethod(Address.Schema,
+		jsonschema.WithExamples(Address{}.PostalCode, "94103", "SW1A 1AA"),
+		jsonschema.WithExample(Address{
+			Street:     "1 Market St",
+			City:       "San Francisco",
+			State:      "CA",
+			PostalCode: "94105",
+			Country:    "USA",
+		}),
+	)
 
 	// Register ContactInfo for schema generation
 	_ = jsonschema.NewJSONSchemaMethod(ContactInfo.Schema)
@@ -2,10 +2,13 @@ package structs
 
 import (
 	"encoding/json"
+	"errors"
 	"slices"
 	"strings"
 	"testing"
 	"time"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
 )
 
 func TestStructSchemaPropertyNamesMatchJSON(t *testing.T) {
@@ -76,18 +79,37 @@ func TestPersonSchemaWithTime(t *testing.T) {
 		t.Errorf("birthDate type = %v, want string", birthDate["type"])
 	}
 
-	// Verify the description includes RFC3339 guidance
-	desc, ok := birthDate["description"].(string)
-	if !ok {
-		t.Fatal("birthDate missing description")
+	// Verify it carries the date-time format
+	if birthDate["format"] != "date-time" {
+		t.Errorf("birthDate format = %v, want date-time", birthDate["format"])
+	}
+}
+
+func TestAddressSchemaDefaultsAndExamples(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			Default  any   `json:"default"`
+			Examples []any `json:"examples"`
+		} `json:"properties"`
+		Examples []json.RawMessage `json:"examples"`
+	}
+	if err := json.Unmarshal(Address{}.Schema(), &schema); err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
 	}
 
-	if !strings.Contains(desc, "RFC3339") {
-		t.Errorf("birthDate description doesn't mention RFC3339: %s", desc)
+	if got := schema.Properties["country"].Default; got != "USA" {
+		t.Errorf("country default = %v, want USA", got)
+	}
+	if got := schema.Properties["postalCode"].Examples; len(got) != 2 || got[0] != "94103" {
+		t.Errorf("postalCode examples = %v, want [94103 SW1A 1AA]", got)
+	}
+	if len(schema.Examples) != 1 {
+		t.Fatalf("Address examples = %d, want 1", len(schema.Examples))
 	}
 
-	if !strings.Contains(desc, "2006-01-02T15:04:05Z07:00") {
-		t.Errorf("birthDate description doesn't include example format: %s", desc)
+	// The whole-type example is a valid Address.
+	if err := (Address{}).ValidateJSON(schema.Examples[0]); err != nil {
+		t.Errorf("Address example does not validate: %v", err)
 	}
 }
 
@@ -155,6 +177,10 @@ func TestValidate_ValidJSON(t *testing.T) {
 		"email": "jane@example.com",
 		"phone": "555-1234",
 		"alternateEmails": ["jane.doe@example.com"],
+		"alternatePhones": {"work": "555-9876"},
+		"addresses": {
+			"home": {"street": "1 Elm St", "city": "Springfield", "state": "IL", "postalCode": "62701", "country": "US"}
+		},
 		"tags": ["developer"]
 	}`
 
@@ -220,3 +246,110 @@ func TestValidate_MalformedJSON(t *testing.T) {
 		t.Fatal("Validate accepted malformed JSON")
 	}
 }
+
+func TestValidate_TagKeywords(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+		check func() error
+	}{
+		{"maximum accepted", true, func() error {
+			return (RetryPolicy{}).ValidateJSON([]byte(`{"MaxRetries":10,"TimeoutSeconds":0,"backoff_strategy":0,"Untagged":0}`))
+		}},
+		{"maximum exceeded", false, func() error {
+			return (RetryPolicy{}).ValidateJSON([]byte(`{"MaxRetries":11,"TimeoutSeconds":0,"backoff_strategy":0,"Untagged":0}`))
+		}},
+		{"minimum violated", false, func() error {
+			return (RetryPolicy{}).ValidateJSON([]byte(`{"MaxRetries":-1,"TimeoutSeconds":0,"backoff_strategy":0,"Untagged":0}`))
+		}},
+		{"pattern violated", false, func() error {
+			return (Address{}).ValidateJSON([]byte(`{"street":"1 Main","city":"X","state":"IL","postalCode":"ab","country":"US"}`))
+		}},
+		{"maxItems exceeded", false, func() error {
+			return (ContactInfo{}).ValidateJSON([]byte(`{"email":"a@b.c","alternateEmails":["1","2","3","4","5","6"]}`))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check()
+			if tt.valid && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatal("validation unexpectedly succeeded")
+			}
+		})
+	}
+}
+
+func TestValidate_MapValues(t *testing.T) {
+	invalid := `{"email":"a@b.c","phone":"","alternateEmails":[],"alternatePhones":{"work":5}}`
+	if err := (ContactInfo{}).ValidateJSON([]byte(invalid)); err == nil {
+		t.Fatal("Validate accepted a non-string alternatePhones value")
+	}
+	valid := `{"email":"a@b.c","phone":"","alternateEmails":[],"alternatePhones":{"work":"555","home":"556"}}`
+	if err := (ContactInfo{}).ValidateJSON([]byte(valid)); err != nil {
+		t.Fatalf("Validate rejected arbitrary alternatePhones keys: %v", err)
+	}
+}
+
+func TestValidate_RecursiveDepartments(t *testing.T) {
+	var schema struct {
+		Defs       map[string]json.RawMessage `json:"$defs"`
+		Properties map[string]struct {
+			Items struct {
+				Ref string `json:"$ref"`
+			} `json:"items"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(Department{}.Schema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	if got := schema.Properties["subDepartments"].Items.Ref; got != "#" {
+		t.Fatalf("subDepartments items $ref = %q, want #", got)
+	}
+	if _, ok := schema.Defs["Department"]; ok {
+		t.Fatal("Department is the root and should not be repeated under $defs")
+	}
+	if _, ok := schema.Defs["Person"]; ok {
+		t.Fatal("Person is not recursive and should stay inlined")
+	}
+
+	manager := `{"id":"1","name":"Jane","birthDate":"1990-05-15T14:30:00Z","email":"jane@example.com",
+		"phone":"","alternateEmails":[],"alternatePhones":{},"addresses":{},"tags":[]}`
+	department := func(name, subDepartments string) string {
+		return `{"name":"` + name + `","manager":` + manager + `,"parentDepartment":"","subDepartments":[` + subDepartments + `]}`
+	}
+	valid := department("Eng", department("Platform", department("Storage", "")))
+	if err := (Department{}).ValidateJSON([]byte(valid)); err != nil {
+		t.Fatalf("Validate rejected nested departments: %v", err)
+	}
+	invalid := department("Eng", department("Platform", `{"name":7}`))
+	if err := (Department{}).ValidateJSON([]byte(invalid)); err == nil {
+		t.Fatal("Validate accepted an invalid department two levels down")
+	}
+}
+
+func TestValidate_RetryPromptErrors(t *testing.T) {
+	invalid := `{"street":123,"state":"IL","postalCode":"ab","country":"US","planet":"Earth"}`
+	err := (Address{}).ValidateJSON([]byte(invalid))
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateJSON error = %T %v, want *jsonschema.ValidationError", err, err)
+	}
+	want := strings.Join([]string{
+		`/city: missing required property (expected string) -- City is the city name.`,
+		`/planet: property is not allowed (expected only "city", "country", "postalCode", "state", "street")`,
+		`/postalCode: "ab" does not match the pattern (expected a match for ^[0-9A-Z -]{3,10}$) -- PostalCode is the postal or zip code.`,
+		`/street: got number (expected string) -- Street is the street address.`,
+	}, "\n")
+	if got := jsonschema.FormatValidationError(err); got != want {
+		t.Fatalf("FormatValidationError =\n%s\nwant\n%s", got, want)
+	}
+	if got := validationErr.InstanceLocations(); !slices.Equal(got, []string{"/city", "/planet", "/postalCode", "/street"}) {
+		t.Fatalf("InstanceLocations = %v", got)
+	}
+	if got := validationErr.Problems[3].KeywordLocation; got != "/properties/street/type" {
+		t.Fatalf("street KeywordLocation = %q", got)
+	}
+}
@@ -16,10 +16,10 @@ type Address struct {
 	State string `json:"state"`
 
 	// PostalCode is the postal or zip code.
-	PostalCode string `json:"postalCode"`
+	PostalCode string `json:"postalCode" jsonschema:"pattern=^[0-9A-Z -]{3,10}$"`
 
 	// Country is the country name.
-	Country string `json:"country"`
+	Country string `json:"country" jsonschema:"default=USA"`
 }
 
 // ContactInfo contains various ways to contact a person.
@@ -31,17 +31,16 @@ type ContactInfo struct {
 	Phone string `json:"phone,omitempty"`
 
 	// AlternateEmails contains additional email addresses.
-	AlternateEmails []string `json:"alternateEmails,omitempty"`
+	AlternateEmails []string `json:"alternateEmails,omitempty" jsonschema:"maxItems=5"`
 
-	// COMMENTED OUT: Map fields are not yet supported
-	// // AlternatePhones contains additional phone numbers with labels.
-	// AlternatePhones map[string]string `json:"alternatePhones,omitempty"`
+	// AlternatePhones contains additional phone numbers with labels.
+	AlternatePhones map[string]string `json:"alternatePhones,omitempty"`
 }
 
 // RetryPolicy demonstrates JSON options that retain Go's default field names.
 type RetryPolicy struct {
 	// MaxRetries uses its Go field name while omitting the zero value.
-	MaxRetries int `json:",omitzero"`
+	MaxRetries int `json:",omitzero" jsonschema:"minimum=0,maximum=10"`
 
 	// TimeoutSeconds uses its Go field name with omitempty.
 	TimeoutSeconds int `json:",omitempty"`
@@ -68,9 +67,8 @@ type Person struct {
 	// This demonstrates using the time.Time type which will be properly handled.
 	BirthDate time.Time `json:"birthDate"`
 
-	// COMMENTED OUT: Map fields are not yet supported
-	// // Addresses is a map of labeled addresses (e.g., "home", "work").
-	// Addresses map[string]Address `json:"addresses,omitempty"`
+	// Addresses is a map of labeled addresses (e.g., "home", "work").
+	Addresses map[string]Address `json:"addresses,omitempty"`
 
 	// Embed the ContactInfo type.
 	// All fields from ContactInfo will be flattened into Person.
@@ -79,7 +77,7 @@ type Person struct {
 	// Tags are arbitrary labels associated with the person.
 	Tags []string `json:"tags,omitempty"`
 
-	// COMMENTED OUT: Map fields are not yet supported
+	// COMMENTED OUT: Interface-valued maps (map[string]any) are not supported
 	// // Metadata contains any additional information.
 	// // Using map[string]interface{} allows for arbitrary JSON.
 	// Metadata map[string]any `json:"metadata,omitempty"`
@@ -121,8 +119,7 @@ type Department struct {
 	// ParentDepartment is the name of the parent department, if any.
 	ParentDepartment string `json:"parentDepartment,omitempty"`
 
-	// COMMENTED OUT: Recursive/circular references are not yet supported
-	// // SubDepartments demonstrates recursive structures.
-	// // This creates a tree structure of departments.
-	// SubDepartments []Department `json:"subDepartments,omitempty"`
+	// SubDepartments demonstrates recursive structures.
+	// This creates a tree structure of departments.
+	SubDepartments []Department `json:"subDepartments,omitempty"`
 }
@@ -0,0 +1,26 @@
+//go:build jsonschema
+
+package tagged_unions
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Payment) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(
+	Payment.Schema,
+	jsonschema.WithInterface(Payment{}.Method,
+		jsonschema.ExternallyTagged(),
+		jsonschema.Impl("credit_card", CreditCard{}),
+		jsonschema.Impl("bank_transfer", (*BankTransfer)(nil)),
+	),
+	jsonschema.WithInterface(Payment{}.Refunds,
+		jsonschema.Discriminator("kind"),
+		jsonschema.AdjacentlyTagged("data"),
+		jsonschema.Impl("credit_card", CreditCard{}),
+		jsonschema.Impl("bank_transfer", (*BankTransfer)(nil)),
+	),
+)
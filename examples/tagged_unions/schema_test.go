@@ -0,0 +1,60 @@
+package tagged_unions
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPaymentRoundTripsTaggedMethods(t *testing.T) {
+	const input = `{"amount":42,` +
+		`"method":{"credit_card":{"last4":"4242","expiry":"12/30"}},` +
+		`"refunds":[{"kind":"bank_transfer","data":{"iban":"DE89370400440532013000"}},{"kind":"credit_card","data":{"last4":"4242","expiry":"12/30"}}]}`
+	want := Payment{
+		Amount: 42,
+		Method: CreditCard{Last4: "4242", Expiry: "12/30"},
+		Refunds: []PaymentMethod{
+			&BankTransfer{IBAN: "DE89370400440532013000"},
+			CreditCard{Last4: "4242", Expiry: "12/30"},
+		},
+	}
+
+	var payment Payment
+	if err := json.Unmarshal([]byte(input), &payment); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(payment, want) {
+		t.Fatalf("json.Unmarshal = %#v, want %#v", payment, want)
+	}
+	if err := payment.ValidateJSON([]byte(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(payment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Fatalf("json.Marshal = %s, want %s", data, input)
+	}
+}
+
+func TestPaymentRejectsMalformedTags(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`{"amount":1,"method":{},"refunds":[]}`, "expected one property naming the implementation of PaymentMethod, found 0"},
+		{`{"amount":1,"method":{"cash":{}},"refunds":[]}`, "unknown discriminator: cash"},
+		{`{"amount":1,"method":{"credit_card":{}},"refunds":[{"kind":"credit_card"}]}`, "no content property 'data' found"},
+		{`{"amount":1,"method":{"credit_card":{}},"refunds":[{"data":{}}]}`, "no discriminator property 'kind' found"},
+	}
+	for _, tt := range tests {
+		var payment Payment
+		err := json.Unmarshal([]byte(tt.input), &payment)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Fatalf("json.Unmarshal(%s): err = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
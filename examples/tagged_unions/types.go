@@ -0,0 +1,34 @@
+// Package tagged_unions shows interface unions whose wire value sits outside
+// the implementation's object: externally tagged, as {"credit_card": {...}},
+// and adjacently tagged, as {"kind": "credit_card", "data": {...}}.
+package tagged_unions
+
+//go:generate go run ../../gen-jsonschema/ --pretty --validate
+
+// PaymentMethod is how a payment or refund is settled.
+type PaymentMethod interface {
+	isPaymentMethod()
+}
+
+// CreditCard is a card payment.
+type CreditCard struct {
+	Last4  string `json:"last4"`
+	Expiry string `json:"expiry"`
+}
+
+func (CreditCard) isPaymentMethod() {}
+
+// BankTransfer is a payment by bank transfer.
+type BankTransfer struct {
+	IBAN string `json:"iban"`
+}
+
+func (*BankTransfer) isPaymentMethod() {}
+
+// Payment is a charge. Its method is externally tagged, and its refunds are
+// adjacently tagged, as the services that send them encode them.
+type Payment struct {
+	Amount  int             `json:"amount"`
+	Method  PaymentMethod   `json:"method"`
+	Refunds []PaymentMethod `json:"refunds"`
+}
@@ -27,3 +27,31 @@ func (WorkItem) Schema() json.RawMessage {
 	}
 	return data
 }
+
+// IsValid reports whether v is one of the Status constants.
+func (v Status) IsValid() bool {
+	switch v {
+	case StatusPending, StatusActive, StatusComplete:
+		return true
+	}
+	return false
+}
+
+// StatusValues returns the Status constants in declaration order.
+func StatusValues() []Status {
+	return []Status{StatusPending, StatusActive, StatusComplete}
+}
+
+// ParseStatus returns the Status constant whose value is s.
+func ParseStatus(s string) (Status, error) {
+	switch s {
+	case "pending":
+		return StatusPending, nil
+	case "active":
+		return StatusActive, nil
+	case "complete":
+		return StatusComplete, nil
+	}
+	var zero Status
+	return zero, fmt.Errorf("unknown Status %q", s)
+}
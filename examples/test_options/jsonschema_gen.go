@@ -36,3 +36,63 @@ func (Team) Schema() json.RawMessage {
 	}
 	return data
 }
+
+// IsValid reports whether v is one of the Priority constants.
+func (v Priority) IsValid() bool {
+	switch v {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// PriorityValues returns the Priority constants in declaration order.
+func PriorityValues() []Priority {
+	return []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+}
+
+// ParsePriority returns the Priority constant whose value is s.
+func ParsePriority(s string) (Priority, error) {
+	switch s {
+	case "low":
+		return PriorityLow, nil
+	case "medium":
+		return PriorityMedium, nil
+	case "high":
+		return PriorityHigh, nil
+	case "urgent":
+		return PriorityUrgent, nil
+	}
+	var zero Priority
+	return zero, fmt.Errorf("unknown Priority %q", s)
+}
+
+// IsValid reports whether v is one of the Status constants.
+func (v Status) IsValid() bool {
+	switch v {
+	case StatusPending, StatusActive, StatusComplete, StatusCanceled:
+		return true
+	}
+	return false
+}
+
+// StatusValues returns the Status constants in declaration order.
+func StatusValues() []Status {
+	return []Status{StatusPending, StatusActive, StatusComplete, StatusCanceled}
+}
+
+// ParseStatus returns the Status constant whose value is s.
+func ParseStatus(s string) (Status, error) {
+	switch s {
+	case "pending":
+		return StatusPending, nil
+	case "active":
+		return StatusActive, nil
+	case "complete":
+		return StatusComplete, nil
+	case "canceled":
+		return StatusCanceled, nil
+	}
+	var zero Status
+	return zero, fmt.Errorf("unknown Status %q", s)
+}
@@ -0,0 +1,14 @@
+//go:build jsonschema
+
+package tools
+
+import jsonschema "github.com/tylergannon/go-gen-jsonschema"
+
+var (
+	_ = jsonschema.NewTool(GetStockPrice)
+	_ = jsonschema.NewTool(FindUser,
+		jsonschema.ToolName("lookup_user"),
+		jsonschema.ToolDependency("db"),
+		jsonschema.ToolDependency("logger"),
+	)
+)
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func TestInvokeCallsFunction(t *testing.T) {
+	tool := NewGetStockPriceTool()
+	if tool.Name() != "get_stock_price" {
+		t.Errorf("Name() = %q", tool.Name())
+	}
+	got, err := tool.Invoke(context.Background(), `{"symbol":"GOOG","date":"2024-03-01T00:00:00Z"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"symbol":"GOOG","date":"2024-03-01T00:00:00Z","cents":17150}`
+	if got != want {
+		t.Errorf("Invoke() = %s, want %s", got, want)
+	}
+}
+
+func TestInvokeRejectsInvalidParams(t *testing.T) {
+	for name, params := range map[string]string{
+		"missing date":   `{"symbol":"GOOG"}`,
+		"malformed date": `{"symbol":"GOOG","date":"March 1st"}`,
+	} {
+		_, err := NewGetStockPriceTool().Invoke(context.Background(), params)
+		var parseErr *jsonschema.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Kind != jsonschema.ParseSchemaViolation {
+			t.Errorf("%s: Invoke() error = %v, want a schema violation", name, err)
+		}
+	}
+}
+
+func TestInvokeInjectsDependencies(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	params := `{"name":"^Ada"}`
+
+	tool := NewFindUserTool(new(sql.DB), logger)
+	if tool.Name() != "lookup_user" {
+		t.Errorf("Name() = %q", tool.Name())
+	}
+	got, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != `user matching "^Ada"` {
+		t.Errorf("Invoke() = %s", got)
+	}
+
+	if _, err = NewFindUserTool(nil, logger).Invoke(context.Background(), params); err == nil {
+		t.Error("Invoke() with a nil db succeeded")
+	}
+}
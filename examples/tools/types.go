@@ -0,0 +1,48 @@
+package tools
+
+//go:generate go run ../../gen-jsonschema/ --pretty
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+// Quote is the price of a stock at the close of one trading day.
+type Quote struct {
+	Symbol string    `json:"symbol"`
+	Date   time.Time `json:"date"`
+	Cents  int       `json:"cents"`
+}
+
+// GetStockPrice returns the closing price of a stock on a given date.
+func GetStockPrice(
+	ctx context.Context,
+	symbol string, // The ticker symbol to look up, such as "GOOG".
+	date time.Time, // The trading day to look up.
+) (Quote, error) {
+	if err := ctx.Err(); err != nil {
+		return Quote{}, err
+	}
+	return Quote{Symbol: symbol, Date: date, Cents: 17150}, nil
+}
+
+// FindUser locates a user by matching on name or email.
+func FindUser(
+	ctx context.Context,
+	name string, // Regular expression matched against first and last name.
+	email jsonschema.Optional[string], // Matched exactly when given.
+	db *sql.DB,
+	logger *slog.Logger,
+) (string, error) {
+	logger.InfoContext(ctx, "finding user", "name", name, "email", email.Value)
+	if db == nil {
+		return "", errors.New("no database configured")
+	}
+	return fmt.Sprintf("user matching %q", name), nil
+}
@@ -128,6 +128,34 @@ func (d *Drawing) UnmarshalJSON(data []byte) (err error) {
 	return nil
 }
 
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// Drawing. Interface values are written with their discriminator.
+func (d Drawing) MarshalJSON() ([]byte, error) {
+	type Alias Drawing
+	type Wrapper struct {
+		Alias
+		Shapes json.RawMessage `json:"shapes"`
+	}
+	var (
+		wrapper = Wrapper{Alias: Alias(d)}
+		err     error
+	)
+
+	if d.Shapes != nil {
+		__raw0 := make([]json.RawMessage, len(d.Shapes))
+		for __index, __value := range d.Shapes {
+			if __raw0[__index], err = __jsonMarshal__uniontypes__Shape(__value); err != nil {
+				return nil, fmt.Errorf("field shapes[%d]: %w", __index, err)
+			}
+		}
+		if wrapper.Shapes, err = json.Marshal(__raw0); err != nil {
+			return nil, fmt.Errorf("field shapes: %w", err)
+		}
+	}
+
+	return json.Marshal(wrapper)
+}
+
 // UnmarshalJSON is a generated custom json.Unmarshaler implementation for
 // Payment.
 func (p *Payment) UnmarshalJSON(data []byte) (err error) {
@@ -149,6 +177,27 @@ func (p *Payment) UnmarshalJSON(data []byte) (err error) {
 	*p = __next
 	return nil
 }
+
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// Payment. Interface values are written with their discriminator.
+func (p Payment) MarshalJSON() ([]byte, error) {
+	type Alias Payment
+	type Wrapper struct {
+		Alias
+		Method json.RawMessage `json:"method"`
+	}
+	var (
+		wrapper = Wrapper{Alias: Alias(p)}
+		err     error
+	)
+
+	if wrapper.Method, err = __jsonMarshal__uniontypes__PaymentMethod(p.Method); err != nil {
+		return nil, fmt.Errorf("field method: %w", err)
+	}
+
+	return json.Marshal(wrapper)
+}
+
 func __jsonUnmarshal__uniontypes__Shape(data []byte) (Shape, error) {
 	var (
 		temp          map[string]json.RawMessage
@@ -186,6 +235,24 @@ func __jsonUnmarshal__uniontypes__Shape(data []byte) (Shape, error) {
 		return nil, fmt.Errorf("unknown discriminator: %s", discriminator)
 	}
 }
+
+func __jsonMarshal__uniontypes__Shape(value Shape) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case Circle, *Circle:
+		discriminator = "Circle"
+	case Rectangle, *Rectangle:
+		discriminator = "Rectangle"
+	case Triangle, *Triangle:
+		discriminator = "Triangle"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of Shape: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("type", discriminator, value)
+}
+
 func __jsonUnmarshal__uniontypes__PaymentMethod(data []byte) (PaymentMethod, error) {
 	var (
 		temp          map[string]json.RawMessage
@@ -224,6 +291,64 @@ func __jsonUnmarshal__uniontypes__PaymentMethod(data []byte) (PaymentMethod, err
 	}
 }
 
+func __jsonMarshal__uniontypes__PaymentMethod(value PaymentMethod) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case CreditCard, *CreditCard:
+		discriminator = "CreditCard"
+	case BankTransfer, *BankTransfer:
+		discriminator = "BankTransfer"
+	case *DigitalWallet:
+		discriminator = "DigitalWallet"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of PaymentMethod: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("type", discriminator, value)
+}
+
 func __jsonschema__unmarshalDiscriminatorError(discriminator json.RawMessage, err error) error {
 	return fmt.Errorf("unable to unmarshal discriminator value %v: %w", discriminator, err)
 }
+
+// __jsonschema__marshalWithDiscriminator encodes value, which must be a JSON
+// object, with the discriminator property written first. A value whose own
+// MarshalJSON already writes the discriminator is kept as it is.
+func __jsonschema__marshalWithDiscriminator(propName, discriminator string, value any) (json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("%T must marshal to a JSON object to carry discriminator %q", value, discriminator)
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if existing, ok := fields[propName]; ok {
+		var written string
+		if err = json.Unmarshal(existing, &written); err != nil || written != discriminator {
+			return nil, fmt.Errorf("%T writes %s %s, want %q", value, propName, existing, discriminator)
+		}
+		return data, nil
+	}
+	key, err := json.Marshal(propName)
+	if err != nil {
+		return nil, err
+	}
+	val, err := json.Marshal(discriminator)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)+len(key)+len(val)+2)
+	out = append(out, '{')
+	out = append(out, key...)
+	out = append(out, ':')
+	out = append(out, val...)
+	if data[1] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, data[1:]...), nil
+}
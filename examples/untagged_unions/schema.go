@@ -0,0 +1,22 @@
+//go:build jsonschema
+
+package untagged_unions
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Delivery) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(
+	Delivery.Schema,
+	jsonschema.WithInterface(Delivery{}.Event,
+		jsonschema.Untagged(),
+		jsonschema.Impl("push", PushEvent{}),
+		jsonschema.Impl("issue", (*IssueEvent)(nil)),
+		jsonschema.Impl("ping", PingEvent{}),
+		jsonschema.Impl("heartbeat", HeartbeatEvent{}),
+	),
+)
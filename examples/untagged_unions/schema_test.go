@@ -0,0 +1,79 @@
+package untagged_unions
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func TestDeliveryDecodesEventsByShape(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Event
+	}{
+		{`{"ref":"refs/heads/main","commits":2}`, PushEvent{Ref: "refs/heads/main", Commits: 2}},
+		{`{"action":"opened","number":7}`, &IssueEvent{Action: "opened", Number: 7}},
+		{`{"zen":"Keep it logically awesome."}`, PingEvent{Zen: jsonschema.Optional[string]{Present: true, Value: "Keep it logically awesome."}}},
+		{`{"at":"2026-01-02T03:04:05Z"}`, HeartbeatEvent{At: jsonschema.Optional[string]{Present: true, Value: "2026-01-02T03:04:05Z"}}},
+	}
+	for _, tt := range tests {
+		var delivery Delivery
+		if err := json.Unmarshal([]byte(`{"id":"1","event":`+tt.input+`}`), &delivery); err != nil {
+			t.Fatalf("json.Unmarshal(%s): %v", tt.input, err)
+		}
+		if !reflect.DeepEqual(delivery.Event, tt.want) {
+			t.Fatalf("json.Unmarshal(%s) = %#v, want %#v", tt.input, delivery.Event, tt.want)
+		}
+
+		data, err := json.Marshal(delivery)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"id":"1","event":` + tt.input + `}`; string(data) != want {
+			t.Fatalf("json.Marshal = %s, want %s", data, want)
+		}
+	}
+}
+
+func TestDeliveryReportsAmbiguousAndUnmatchedEvents(t *testing.T) {
+	var delivery Delivery
+	err := json.Unmarshal([]byte(`{"id":"1","event":{}}`), &delivery)
+	if err == nil || !strings.Contains(err.Error(), "matches more than one implementation of Event: ping, heartbeat") {
+		t.Fatalf("json.Unmarshal of an empty event: err = %v", err)
+	}
+
+	err = json.Unmarshal([]byte(`{"id":"1","event":{"ref":"main"}}`), &delivery)
+	if err == nil || !strings.Contains(err.Error(), "matches no implementation of Event") {
+		t.Fatalf("json.Unmarshal of an incomplete push: err = %v", err)
+	}
+	for _, name := range []string{"push:", "issue:", "ping:", "heartbeat:"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Fatalf("error %q does not report alternative %s", err, name)
+		}
+	}
+}
+
+func TestDeliverySchemaHasNoDiscriminator(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			AnyOf []struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"anyOf"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(Delivery{}.Schema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	options := schema.Properties["event"].AnyOf
+	if len(options) != 4 {
+		t.Fatalf("event options = %d, want 4", len(options))
+	}
+	for _, option := range options {
+		if _, ok := option.Properties["type"]; ok {
+			t.Fatalf("option %v has a discriminator property", option.Properties)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package untagged_unions shows an interface union decoded by shape, for
+// payloads that carry no discriminator property.
+package untagged_unions
+
+//go:generate go run ../../gen-jsonschema/ --pretty
+
+import jsonschema "github.com/tylergannon/go-gen-jsonschema"
+
+// Event is the payload of a webhook delivery.
+type Event interface {
+	isEvent()
+}
+
+// PushEvent reports commits pushed to a branch.
+type PushEvent struct {
+	Ref     string `json:"ref"`
+	Commits int    `json:"commits"`
+}
+
+func (PushEvent) isEvent() {}
+
+// IssueEvent reports a change to an issue.
+type IssueEvent struct {
+	Action string `json:"action"`
+	Number int    `json:"number"`
+}
+
+func (*IssueEvent) isEvent() {}
+
+// PingEvent is sent when a webhook is created.
+type PingEvent struct {
+	Zen jsonschema.Optional[string] `json:"zen,omitzero"`
+}
+
+func (PingEvent) isEvent() {}
+
+// HeartbeatEvent is sent periodically. Like PingEvent it accepts {}, so an
+// empty payload is ambiguous.
+type HeartbeatEvent struct {
+	At jsonschema.Optional[string] `json:"at,omitzero"`
+}
+
+func (HeartbeatEvent) isEvent() {}
+
+// Delivery is one webhook delivery.
+type Delivery struct {
+	ID    string `json:"id"`
+	Event Event  `json:"event"`
+}
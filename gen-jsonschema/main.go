@@ -40,6 +40,10 @@ func main() {
 		handleGen(2)
 	case "new":
 		handleNew()
+	case "check":
+		handleCheck()
+	case "diff":
+		handleDiff()
 	default:
 		handleGen(1)
 	}
@@ -52,21 +56,67 @@ func printGlobalHelp() {
 	fmt.Println("\nSubcommands:")
 	fmt.Println("  gen      Generate output (default)")
 	fmt.Println("  new      Create a new project")
+	fmt.Println("  check    Report generated files that are out of date, writing nothing")
+	fmt.Println("  diff     Classify schema changes against a base revision as breaking or not")
 	fmt.Println("\nRun '[subcommand] --help' for more details.")
 }
 
+// genFlags holds the generation options shared by gen and by the
+// go:generate directives that check replays.
+type genFlags struct {
+	pretty         *bool
+	target         *string
+	numTestSamples *int
+	noChanges      *bool
+	force          *bool
+	validate       *bool
+	formats        *string
+	strict         *bool
+	dialect        *string
+}
+
+func addGenFlags(fs *flag.FlagSet) genFlags {
+	return genFlags{
+		pretty:         fs.Bool("pretty", false, "Enable pretty output"),
+		target:         fs.String("target", "", "Path to target package (default to local wd)"),
+		numTestSamples: fs.Int("num-test-samples", 5, "Number of test samples to generate"),
+		noChanges:      fs.Bool("no-changes", false, "Fail if any schema changes are detected"),
+		force:          fs.Bool("force", false, "Force regeneration of schemas even if no changes are detected"),
+		validate:       fs.Bool("validate", false, "Generate schema validation methods for the selected formats"),
+		formats:        fs.String("formats", "json", "Generated decoding and validation formats: json or both"),
+		strict:         fs.Bool("strict", false, "Fail if a schema contains constructs the openai-strict dialect rejects, such as open objects (maps)"),
+		dialect:        fs.String("dialect", "", "Comma-separated provider dialects to emit beside each schema as <Type>.<dialect>.json: openai-strict, anthropic, gemini, draft2020"),
+	}
+}
+
+func (f genFlags) builderArgs() (builder.BuilderArgs, error) {
+	unmarshalFormats, err := parseUnmarshalFormats(*f.formats)
+	if err != nil {
+		return builder.BuilderArgs{}, err
+	}
+	dialects, err := builder.ParseDialects(*f.dialect)
+	if err != nil {
+		return builder.BuilderArgs{}, fmt.Errorf("invalid --dialect value: %w", err)
+	}
+	return builder.BuilderArgs{
+		TargetDir:        *f.target,
+		Pretty:           *f.pretty,
+		NumTestSamples:   *f.numTestSamples,
+		NoChanges:        *f.noChanges,
+		Force:            *f.force,
+		Validate:         *f.validate,
+		UnmarshalFormats: unmarshalFormats,
+		Strict:           *f.strict,
+		Dialects:         dialects,
+	}, nil
+}
+
 func handleGen(firstArg int) {
-	// Define the --pretty flag
 	var (
-		genCmd         = flag.NewFlagSet("gen", flag.ExitOnError)
-		pretty         = genCmd.Bool("pretty", false, "Enable pretty output")
-		target         = genCmd.String("target", "", "Path to target package (default to local wd)")
-		numTestSamples = genCmd.Int("num-test-samples", 5, "Number of test samples to generate")
-		noChanges      = genCmd.Bool("no-changes", false, "Fail if any schema changes are detected")
-		force          = genCmd.Bool("force", false, "Force regeneration of schemas even if no changes are detected")
-		validate       = genCmd.Bool("validate", false, "Generate schema validation methods for the selected formats")
-		formats        = genCmd.String("formats", "json", "Generated decoding and validation formats: json or both")
-		err            error
+		genCmd = flag.NewFlagSet("gen", flag.ExitOnError)
+		flags  = addGenFlags(genCmd)
+		target = flags.target
+		err    error
 	)
 
 	if *target == "" {
@@ -81,35 +131,127 @@ func handleGen(firstArg int) {
 
 	// Check if --help was requested
 	if len(os.Args) > 2 && os.Args[2] == "--help" {
-		fmt.Println("Usage: gen [options]")
+		fmt.Println("Usage: gen [options] [packages]")
 		fmt.Println("\nOptions:")
 		genCmd.PrintDefaults()
 		return
 	}
 	_ = genCmd.Parse(os.Args[firstArg:])
-	unmarshalFormats, err := parseUnmarshalFormats(*formats)
+	args, err := flags.builderArgs()
 	if err != nil {
 		log.Fatal(err)
 	}
+	args.Patterns = genCmd.Args()
 
 	// Check environment variable
-	*noChanges = *noChanges || os.Getenv("JSONSCHEMA_NO_CHANGES") != ""
+	args.NoChanges = args.NoChanges || os.Getenv("JSONSCHEMA_NO_CHANGES") != ""
 
-	if *force && *noChanges {
+	if args.Force && args.NoChanges {
 		log.Fatal("Cannot use --force and --no-changes together")
 	}
 
-	if err = builder.Run(builder.BuilderArgs{
-		TargetDir:        *target,
-		Pretty:           *pretty,
-		NumTestSamples:   *numTestSamples,
-		NoChanges:        *noChanges,
-		Force:            *force,
-		Validate:         *validate,
-		UnmarshalFormats: unmarshalFormats,
-	}); err != nil {
+	if err = builder.Run(args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func handleCheck() {
+	var (
+		checkCmd = flag.NewFlagSet("check", flag.ExitOnError)
+		format   = checkCmd.String("format", "text", "Report format: text or json")
+	)
+	checkCmd.Usage = func() {
+		fmt.Println("Usage: check [options] [packages]")
+		fmt.Println("\nReports schemas, jsonschema_gen.go files and orphaned schema files that")
+		fmt.Println("are out of date in the matched packages (default ./...). Writes nothing.")
+		fmt.Println("\nOptions:")
+		checkCmd.PrintDefaults()
+	}
+	_ = checkCmd.Parse(os.Args[2:])
+	if *format != "text" && *format != "json" {
+		log.Fatalf("invalid --format value %q: expected text or json", *format)
+	}
+
+	report, err := builder.Check(builder.CheckArgs{
+		Patterns: checkCmd.Args(),
+		GenArgs:  parseGenDirective,
+	})
+	if *format == "json" {
+		if writeErr := report.WriteJSON(os.Stdout); writeErr != nil {
+			log.Fatal(writeErr)
+		}
+	} else if writeErr := report.WriteText(os.Stdout); writeErr != nil {
+		log.Fatal(writeErr)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(report.Drift) > 0 {
+		os.Exit(1)
+	}
+}
+
+// parseGenDirective parses the arguments of a package's gen-jsonschema
+// go:generate directive into the options gen would run with.
+func parseGenDirective(args []string) (builder.BuilderArgs, error) {
+	directiveCmd := flag.NewFlagSet("gen", flag.ContinueOnError)
+	directiveCmd.SetOutput(io.Discard)
+	flags := addGenFlags(directiveCmd)
+	if err := directiveCmd.Parse(args); err != nil {
+		return builder.BuilderArgs{}, err
+	}
+	genArgs, err := flags.builderArgs()
+	genArgs.Patterns = directiveCmd.Args()
+	return genArgs, err
+}
+
+func handleDiff() {
+	var (
+		diffCmd = flag.NewFlagSet("diff", flag.ExitOnError)
+		base    = diffCmd.String("base", "", "Git revision or directory holding the previous schemas (required)")
+		ack     = diffCmd.String("ack", "gen-jsonschema.ack", "File listing acknowledged breaking changes, one key per line")
+		format  = diffCmd.String("format", "text", "Report format: text or json")
+	)
+	diffCmd.Usage = func() {
+		fmt.Println("Usage: diff --base <git-ref|dir> [options] [packages]")
+		fmt.Println("\nCompares the schemas of the matched packages (default ./...) with their")
+		fmt.Println("versions in the base and classifies each change as breaking or not. Exits 1")
+		fmt.Println("when a breaking change is not listed in the acknowledgement file.")
+		fmt.Println("\nOptions:")
+		diffCmd.PrintDefaults()
+	}
+	_ = diffCmd.Parse(os.Args[2:])
+	if *base == "" {
+		diffCmd.Usage()
+		os.Exit(2)
+	}
+	if *format != "text" && *format != "json" {
+		log.Fatalf("invalid --format value %q: expected text or json", *format)
+	}
+	acknowledged, err := builder.ReadAcknowledgements(*ack)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := builder.Diff(builder.DiffArgs{
+		Patterns: diffCmd.Args(),
+		Base:     *base,
+		GenArgs:  parseGenDirective,
+	})
+	report.Acknowledge(acknowledged)
+	if *format == "json" {
+		if writeErr := report.WriteJSON(os.Stdout); writeErr != nil {
+			log.Fatal(writeErr)
+		}
+	} else if writeErr := report.WriteText(os.Stdout); writeErr != nil {
+		log.Fatal(writeErr)
+	}
+	if err != nil {
 		log.Fatal(err)
 	}
+	if len(report.Unacknowledged()) > 0 {
+		os.Exit(1)
+	}
 }
 
 func parseUnmarshalFormats(value string) (builder.UnmarshalFormats, error) {
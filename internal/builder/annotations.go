@@ -0,0 +1,80 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// annotatedNode adds keywords, such as "default" and "examples", to the end
+// of any schema that marshals to a JSON object.
+type annotatedNode struct {
+	Schema   json.Marshaler
+	Keywords []Keyword
+}
+
+func (a annotatedNode) MarshalJSON() ([]byte, error) {
+	data, err := a.Schema.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	if err = a.splice(&sb, data); err != nil {
+		return nil, err
+	}
+	return []byte(sb.String()), nil
+}
+
+// splice writes data, a JSON object, with the keywords appended.
+func (a annotatedNode) splice(sb *strings.Builder, data []byte) error {
+	data = bytes.TrimRight(data, " \n")
+	if len(data) < 2 || data[0] != '{' || data[len(data)-1] != '}' {
+		return fmt.Errorf("annotated schema must marshal to a JSON object")
+	}
+	sb.Write(data[:len(data)-1])
+	for i, keyword := range a.Keywords {
+		if i > 0 || len(bytes.TrimSpace(data[1:len(data)-1])) > 0 {
+			sb.WriteByte(',')
+		}
+		encodeString(sb, keyword.Name)
+		sb.WriteByte(':')
+		value, err := keyword.Value.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("keyword %q: %w", keyword.Name, err)
+		}
+		sb.Write(value)
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+// annotationKeywords returns the "default" and "examples" keywords for
+// dialect. Gemini takes a single "example".
+func annotationKeywords(dialect Dialect, def json.RawMessage, examples []json.RawMessage) []Keyword {
+	var keywords []Keyword
+	if def != nil {
+		keywords = append(keywords, Keyword{"default", def})
+	}
+	switch {
+	case len(examples) == 0:
+	case dialect == DialectGemini:
+		keywords = append(keywords, Keyword{"example", examples[0]})
+	default:
+		keywords = append(keywords, Keyword{"examples", literal(examples)})
+	}
+	return keywords
+}
+
+// annotatedSchema is the property's schema with its default and examples.
+func (p ObjectProp) annotatedSchema() json.Marshaler {
+	return p.annotate(p.Schema, "")
+}
+
+func (p ObjectProp) annotate(schema json.Marshaler, dialect Dialect) json.Marshaler {
+	keywords := annotationKeywords(dialect, p.Default, p.Examples)
+	if len(keywords) == 0 {
+		return schema
+	}
+	return annotatedNode{Schema: schema, Keywords: keywords}
+}
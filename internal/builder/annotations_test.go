@@ -0,0 +1,300 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestDefaultsAndExamples(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Address struct {
+	Street string `+"`json:\"street\"`"+`
+	City   string `+"`json:\"city\"`"+`
+}
+
+type Owner struct {
+	Name    string                      `+"`json:\"name\" jsonschema:\"default=anonymous\"`"+`
+	Retries int                         `+"`json:\"retries\" jsonschema:\"default=3,minimum=0\"`"+`
+	Verbose bool                        `+"`json:\"verbose\" jsonschema:\"default=false\"`"+`
+	Home    Address                     `+"`json:\"home\"`"+`
+	Tags    []string                    `+"`json:\"tags\"`"+`
+	Nick    jsonschema.Optional[string] `+"`json:\"nick,omitzero\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithExamples(Owner{}.Name, "Ada", "Grace"),
+	jsonschema.WithExamples(Owner{}.Home, Address{Street: "1 Main St", City: "Springfield"}, Address{Street: "2 Elm St", City: "Shelbyville"}),
+	jsonschema.WithExamples(Owner{}.Tags, []string{"admin"}, []string{}),
+	jsonschema.WithExamples(Owner{}.Nick, jsonschema.Optional[string]{Present: true, Value: "ada"}, jsonschema.Optional[string]{Present: true}),
+	jsonschema.WithExample(Owner{Name: "Ada", Retries: 2, Home: Address{Street: "1 Main St"}, Tags: []string{"admin"}, Nick: jsonschema.Optional[string]{Present: true, Value: "ada"}}),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	typeID := syntax.TypeID{PkgPath: pkgs[0].PkgPath, TypeName: "Owner"}
+
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Examples   []json.RawMessage          `json:"examples"`
+		Example    json.RawMessage            `json:"example"`
+	}
+	targetDir = t.TempDir()
+	_, err = builder.writeSchema(typeID, "", targetDir, false)
+	require.NoError(t, err)
+	generated, err := os.ReadFile(filepath.Join(targetDir, "Owner.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(generated, &got))
+	require.JSONEq(t, `{"type":"string","default":"anonymous","examples":["Ada","Grace"]}`, string(got.Properties["name"]))
+	require.JSONEq(t, `{"type":"integer","minimum":0,"default":3}`, string(got.Properties["retries"]))
+	require.JSONEq(t, `{"type":"boolean","default":false}`, string(got.Properties["verbose"]))
+	require.JSONEq(t, `[{"street":"1 Main St","city":"Springfield"},{"street":"2 Elm St","city":"Shelbyville"}]`, extractExamples(t, got.Properties["home"]))
+	require.JSONEq(t, `[["admin"],[]]`, extractExamples(t, got.Properties["tags"]))
+	require.JSONEq(t, `["ada",""]`, extractExamples(t, got.Properties["nick"]))
+	require.Len(t, got.Examples, 1)
+	require.JSONEq(t, `{"name":"Ada","retries":2,"verbose":false,"home":{"street":"1 Main St","city":""},"tags":["admin"],"nick":"ada"}`, string(got.Examples[0]))
+
+	targetDir = t.TempDir()
+	_, err = builder.writeSchema(typeID, DialectGemini, targetDir, false)
+	require.NoError(t, err)
+	generated, err = os.ReadFile(filepath.Join(targetDir, "Owner.gemini.json"))
+	require.NoError(t, err)
+	got.Examples = nil
+	require.NoError(t, json.Unmarshal(generated, &got))
+	require.JSONEq(t, `{"type":"string","default":"anonymous","example":"Ada"}`, string(got.Properties["name"]))
+	require.Nil(t, got.Examples)
+	require.JSONEq(t, `{"name":"Ada","retries":2,"verbose":false,"home":{"street":"1 Main St","city":""},"tags":["admin"],"nick":"ada"}`, string(got.Example))
+}
+
+func TestExamplesEvaluateConstantsAndLiterals(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Level int
+
+const (
+	Debug Level = iota
+	Warn
+)
+
+type Limits struct {
+	Max int `+"`json:\"max\"`"+`
+	Min int
+}
+
+type Owner struct {
+	Level   Level                        `+"`json:\"level\"`"+`
+	Limits  Limits                       `+"`json:\"limits\"`"+`
+	Corners [2]int                       `+"`json:\"corners\"`"+`
+	Counts  map[string]int               `+"`json:\"counts\"`"+`
+	Note    jsonschema.Nullable[string]  `+"`json:\"note\"`"+`
+	Nick    jsonschema.Optional[string]  `+"`json:\"nick,omitzero\"`"+`
+	Skipped string                       `+"`json:\"-\"`"+`
+	hidden  string
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithExamples(Owner{}.Level, Warn, Debug),
+	jsonschema.WithExample(Owner{Level: Warn, Corners: [2]int{3}, Counts: map[string]int{"b": 2, "a": 1}, Skipped: "x", hidden: "y"}),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	typeID := syntax.TypeID{PkgPath: pkgs[0].PkgPath, TypeName: "Owner"}
+	schema, ok := builder.GetSchema(typeID)
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.JSONEq(t, `[1,0]`, extractExamples(t, got.Properties["level"]))
+
+	// Unset fields are written as their zero values, the absent Optional is
+	// left out and map keys are sorted.
+	require.Len(t, builder.TypeExamples["Owner"], 1)
+	require.JSONEq(t, `{"level":1,"limits":{"max":0,"Min":0},"corners":[3,0],"counts":{"a":1,"b":2},"note":null}`, string(builder.TypeExamples["Owner"][0]))
+}
+
+func TestDefaultsAndExamplesDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		field     string
+		options   string
+		wantError string
+	}{
+		{
+			name:      "default of the wrong type",
+			field:     `Value int ` + "`json:\"value\" jsonschema:\"default=many\"`",
+			wantError: `jsonschema tag default "many" is not a valid integer`,
+		},
+		{
+			name:      "default outside the bounds",
+			field:     `Value int ` + "`json:\"value\" jsonschema:\"default=10,maximum=5\"`",
+			wantError: "10 does not match the schema",
+		},
+		{
+			name:      "default on an object",
+			field:     `Value Inner ` + "`json:\"value\" jsonschema:\"default=x\"`",
+			wantError: "jsonschema tag default does not apply to object schemas",
+		},
+		{
+			name:      "example that fails a keyword",
+			field:     `Value string ` + "`json:\"value\" jsonschema:\"maxLength=3\"`",
+			options:   `jsonschema.WithExamples(Owner{}.Value, "abc", "abcd")`,
+			wantError: `"abcd" does not match the schema`,
+		},
+		{
+			name:      "example that is not a literal",
+			field:     `Value string ` + "`json:\"value\"`",
+			options:   `jsonschema.WithExamples(Owner{}.Value, strings.ToUpper("a"))`,
+			wantError: "is not a constant or composite literal",
+		},
+		{
+			name:      "example of a type with a marshaler",
+			field:     `Value Stamp ` + "`json:\"value\"`",
+			options:   `jsonschema.WithExample(Owner{Value: Stamp{}})`,
+			wantError: "Stamp implements MarshalJSON",
+		},
+		{
+			name:      "example that is a call",
+			field:     `Value time.Time ` + "`json:\"value\"`",
+			options:   `jsonschema.WithExamples(Owner{}.Value, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))`,
+			wantError: "time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC) is not a constant or composite literal",
+		},
+		{
+			name:      "unset field of a type with a marshaler",
+			field:     `Value time.Time ` + "`json:\"value\"`",
+			options:   `jsonschema.WithExample(Owner{})`,
+			wantError: ".Owner: time.Time implements MarshalJSON",
+		},
+		{
+			name:      "interface value",
+			field:     `Value Shape ` + "`json:\"value\"`",
+			options:   `jsonschema.WithExample(Owner{Value: Circle{}}), jsonschema.WithInterface(Owner{}.Value, jsonschema.Impl("circle", Circle{}))`,
+			wantError: ".Shape; values of interfaces cannot be evaluated during generation",
+		},
+		{
+			name:      "field with a json tag option",
+			field:     `Value string ` + "`json:\"value,omitempty\"`",
+			options:   `jsonschema.WithExample(Owner{Value: "x"})`,
+			wantError: `.Owner: json tag option "omitempty" cannot be evaluated during generation`,
+		},
+		{
+			name:      "embedded field",
+			field:     `Inner`,
+			options:   `jsonschema.WithExample(Owner{Inner: Inner{Name: "x"}})`,
+			wantError: ".Owner: embedded fields cannot be evaluated during generation",
+		},
+		{
+			name:      "WithStringerEnum field",
+			field:     `Value Level ` + "`json:\"value\"`",
+			options:   `jsonschema.WithStringerEnum(Owner{}.Value), jsonschema.WithExamples(Owner{}.Value, High)`,
+			wantError: "WithStringerEnum fields cannot have examples",
+		},
+		{
+			name:      "absent Optional example",
+			field:     `Value jsonschema.Optional[int] ` + "`json:\"value,omitzero\"`",
+			options:   `jsonschema.WithExamples(Owner{}.Value, jsonschema.Optional[int]{})`,
+			wantError: "an absent Optional value is not an example",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			targetDir := writeAnnotationsFixture(t, `
+type Inner struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+type Stamp struct{}
+
+func (Stamp) MarshalJSON() ([]byte, error) { return []byte("0"), nil }
+
+type Shape interface{ shape() }
+
+type Circle struct{}
+
+func (Circle) shape() {}
+
+type Square struct{}
+
+func (Square) shape() {}
+
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+
+type Owner struct {
+	`+tc.field+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema, `+tc.options+`)
+`)
+			pkgs, err := syntax.Load(targetDir)
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			require.Empty(t, pkgs[0].Errors)
+
+			_, err = New(pkgs[0])
+			require.ErrorContains(t, err, tc.wantError)
+		})
+	}
+}
+
+func extractExamples(t *testing.T, schema json.RawMessage) string {
+	t.Helper()
+
+	var got struct {
+		Examples json.RawMessage `json:"examples"`
+	}
+	require.NoError(t, json.Unmarshal(schema, &got))
+	return string(got.Examples)
+}
+
+func writeAnnotationsFixture(t *testing.T, types, registration string) string {
+	t.Helper()
+
+	source := `//go:build jsonschema
+
+package fixture
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+var (
+	_ = strings.ToUpper
+	_ time.Time
+)
+` + types + `
+func (Owner) Schema() json.RawMessage { panic("not implemented") }
+` + registration
+	return writeFixture(t, "annotations_", map[string]string{"schema.go": source})
+}
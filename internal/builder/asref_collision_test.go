@@ -30,8 +30,57 @@ func TestAsRefDefinitionNameCollisionFailsDuringGeneration(t *testing.T) {
 	require.NotEmpty(t, scan.SchemaMethods)
 
 	_, err = New(pkgs[0])
-	require.ErrorContains(t, err, "AsRef definition name collision")
-	require.ErrorContains(t, err, `"Shared"`)
+	require.ErrorContains(t, err, `$defs name collision: "Shared" is used by both`)
+	require.ErrorContains(t, err, "Shared (registered with AsRef()) and")
+}
+
+// TestRecursiveDefinitionNameCollisionNamesCause covers two recursive types
+// with the same bare name, which reach "$defs" without AsRef().
+func TestRecursiveDefinitionNameCollisionNamesCause(t *testing.T) {
+	t.Parallel()
+
+	root := writeFixture(t, "recursive_collision_", map[string]string{
+		"tree/tree.go": `package tree
+
+type Node struct {
+	Children []Node ` + "`json:\"children\"`" + `
+}
+`,
+		"schema.go": `//go:build jsonschema
+
+package fixture
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+	"` + fixturePath + `/tree"
+)
+
+type Node struct {
+	Next []Node ` + "`json:\"next\"`" + `
+}
+
+type Container struct {
+	Local  Node      ` + "`json:\"local\"`" + `
+	Remote tree.Node ` + "`json:\"remote\"`" + `
+}
+
+func (Container) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Container.Schema)
+`,
+	})
+
+	pkgs, err := syntax.Load(root)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	_, err = New(pkgs[0])
+	require.ErrorContains(t, err, `$defs name collision: "Node" is used by both`)
+	require.ErrorContains(t, err, "Node (recursive) and")
+	require.NotContains(t, err.Error(), "AsRef")
 }
 
 // writeAsRefCollisionDepFixture writes a small dependency package, with no
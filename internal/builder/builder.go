@@ -1,7 +1,11 @@
 package builder
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/dave/dst/decorator"
@@ -9,7 +13,10 @@ import (
 )
 
 type BuilderArgs struct {
-	TargetDir      string
+	TargetDir string
+	// Patterns, such as "./...", select several packages to generate in one
+	// run. When empty, only TargetDir is generated.
+	Patterns       []string
 	Pretty         bool
 	NumTestSamples int
 	NoChanges      bool // If true, fail if any schema changes are detected
@@ -18,6 +25,17 @@ type BuilderArgs struct {
 	// UnmarshalFormats selects whether generated JSON decoding also accepts YAML.
 	// The zero value preserves the CLI default and generates JSON support only.
 	UnmarshalFormats UnmarshalFormats
+	// Strict fails generation when a schema contains constructs that
+	// strict-mode providers reject, such as open objects rendered from maps,
+	// using the same checks as DialectOpenAIStrict.
+	Strict bool
+	// Dialects lists provider dialects to emit beside each native schema as
+	// <Type>.<dialect>.json. A construct a dialect cannot express fails
+	// generation with its field path.
+	Dialects []Dialect
+	// Warnings receives problems that do not stop generation, one per line,
+	// such as field types that could not be resolved. Defaults to os.Stderr.
+	Warnings io.Writer
 }
 
 type UnmarshalFormats string
@@ -39,53 +57,138 @@ func (f UnmarshalFormats) valid() bool {
 	return f == "" || f == UnmarshalFormatsJSON || f == UnmarshalFormatsBoth
 }
 
-func Run(args BuilderArgs) (err error) {
+func (args BuilderArgs) validate() error {
 	if !args.UnmarshalFormats.valid() {
 		return fmt.Errorf("invalid unmarshal formats %q", args.UnmarshalFormats)
 	}
+	for _, dialect := range args.Dialects {
+		if !dialect.valid() {
+			return fmt.Errorf("invalid dialect %q: expected one of %s", dialect, dialectNames())
+		}
+	}
+	return nil
+}
+
+// Run generates schemas and jsonschema_gen.go. With Patterns set, every
+// matched package that registers schemas is generated from a single package
+// load that shares one dependency cache; otherwise only TargetDir is.
+func Run(args BuilderArgs) (err error) {
+	if err = args.validate(); err != nil {
+		return err
+	}
 	var (
-		pkgs    []*decorator.Package
-		builder SchemaBuilder
+		pkgs     []*decorator.Package
+		patterns = args.Patterns
+		single   = len(patterns) == 0
 	)
-	if pkgs, err = syntax.Load(args.TargetDir); err != nil {
+	if single {
+		patterns = []string{args.TargetDir}
+	}
+	if pkgs, err = syntax.Load(patterns...); err != nil {
 		return err
 	}
 	if len(pkgs) == 0 {
-		return fmt.Errorf("no packages found in %s", args.TargetDir)
+		return fmt.Errorf("no packages found in %s", strings.Join(patterns, " "))
 	}
-	if builder, err = New(pkgs[0]); err != nil {
-		return err
+	if single {
+		pkgs = pkgs[:1]
 	}
-	builder.Pretty = args.Pretty
-	builder.NumTestSamples = args.NumTestSamples
-	builder.Validate = args.Validate
-	builder.UnmarshalFormats = args.UnmarshalFormats
 
-	// Allow registered transforms to mutate the model before render (no-ops by default)
-	if err = (&builder).applyTransforms(); err != nil {
+	var (
+		set          = syntax.NewPackageSet(pkgs)
+		changedTypes []string
+		errs         []error
+	)
+	for _, pkg := range pkgs {
+		scan, err := set.Scan(pkg)
+		if err == nil && !single && !scan.HasSchemas() {
+			continue
+		}
+		var changed []string
+		if err == nil {
+			changed, err = generatePackage(scan, args)
+		}
+		if single {
+			if err != nil {
+				return err
+			}
+			changedTypes = changed
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", pkg.PkgPath, err))
+		}
+		for _, typeName := range changed {
+			changedTypes = append(changedTypes, pkg.PkgPath+"."+typeName)
+		}
+	}
+	if err = errors.Join(errs...); err != nil {
 		return err
 	}
+	if len(changedTypes) > 0 {
+		slices.Sort(changedTypes)
+		return fmt.Errorf("schema changes detected for types: %s (and --no-changes or JSONSCHEMA_NO_CHANGES was set)", strings.Join(changedTypes, ", "))
+	}
+	return nil
+}
+
+// generatePackage writes the schemas and Go code for one scanned package.
+// In NoChanges mode it writes nothing and returns the types whose schemas
+// would change.
+func generatePackage(scan syntax.ScanResult, args BuilderArgs) (changedTypes []string, err error) {
+	builder, err := newConfiguredBuilder(scan, args)
+	if err != nil {
+		return nil, err
+	}
+	warnings := args.Warnings
+	if warnings == nil {
+		warnings = os.Stderr
+	}
+	for _, warning := range *builder.Warnings {
+		fmt.Fprintf(warnings, "warning: %s\n", warning)
+	}
 
 	var changedSchemas map[string]bool
 	if changedSchemas, err = builder.RenderSchemas(args.NoChanges, args.Force); err != nil {
-		return err
+		return nil, err
 	}
 
 	// If NoChanges is set, fail if any schemas changed
 	if args.NoChanges {
-		var changedTypes []string
 		for typeName, changed := range changedSchemas {
 			if changed {
 				changedTypes = append(changedTypes, typeName)
 			}
 		}
 		if len(changedTypes) > 0 {
-			return fmt.Errorf("schema changes detected for types: %s (and --no-changes or JSONSCHEMA_NO_CHANGES was set)", strings.Join(changedTypes, ", "))
+			slices.Sort(changedTypes)
+			return changedTypes, nil
 		}
 	}
 
-	if err = builder.RenderGoCode(); err != nil {
-		return err
+	return nil, builder.RenderGoCode()
+}
+
+// newConfiguredBuilder maps a scanned package and applies the generation
+// options in args, so that gen and check render identical output.
+func newConfiguredBuilder(scan syntax.ScanResult, args BuilderArgs) (builder SchemaBuilder, err error) {
+	if builder, err = newFromScan(scan); err != nil {
+		return builder, err
 	}
-	return nil
+	builder.Pretty = args.Pretty
+	builder.NumTestSamples = args.NumTestSamples
+	builder.Validate = args.Validate
+	builder.UnmarshalFormats = args.UnmarshalFormats
+	builder.Dialects = args.Dialects
+	if args.Strict {
+		if err = builder.LintStrict(); err != nil {
+			return builder, err
+		}
+	}
+
+	// Allow registered transforms to mutate the model before render (no-ops by default)
+	if err = (&builder).applyTransforms(); err != nil {
+		return builder, err
+	}
+	return builder, nil
 }
@@ -0,0 +1,336 @@
+package builder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dave/dst/decorator"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+// CheckArgs configures Check.
+type CheckArgs struct {
+	// Patterns select the packages to check, such as "./...". Only packages
+	// with a go:generate directive that runs gen-jsonschema are checked.
+	Patterns []string
+	// GenArgs parses the arguments of a package's gen-jsonschema directive
+	// into the options generation runs with.
+	GenArgs func(args []string) (BuilderArgs, error)
+}
+
+// DriftKind classifies one difference between generated and committed output.
+type DriftKind string
+
+const (
+	// DriftChanged is a committed schema or checksum file whose contents
+	// differ from what generation would write.
+	DriftChanged DriftKind = "changed"
+	// DriftMissing is a file generation would write that does not exist.
+	DriftMissing DriftKind = "missing"
+	// DriftStale is a jsonschema_gen.go that differs from what generation
+	// would write.
+	DriftStale DriftKind = "stale"
+	// DriftOrphaned is a schema file that no registered type generates.
+	DriftOrphaned DriftKind = "orphaned"
+)
+
+// Drift is one file that generation would create, change, or leave behind.
+type Drift struct {
+	Kind    DriftKind `json:"kind"`
+	Package string    `json:"package"`
+	File    string    `json:"file"`
+	Type    string    `json:"type,omitempty"`
+	// Diff is a unified diff from the committed file to the generated one.
+	Diff string `json:"diff,omitempty"`
+}
+
+// CheckReport lists the packages Check examined, every drifted file, and
+// the warnings generation would print, such as unresolved field types.
+type CheckReport struct {
+	Packages []string `json:"packages"`
+	Drift    []Drift  `json:"drift"`
+	Warnings []string `json:"warnings"`
+}
+
+// WriteText writes the report for people: one line per warning, then one
+// line per drifted file, followed by its diff.
+func (r CheckReport) WriteText(w io.Writer) error {
+	var buf bytes.Buffer
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(&buf, "warning: %s\n", warning)
+	}
+	for _, d := range r.Drift {
+		fmt.Fprintf(&buf, "%s: %s", d.File, d.Kind)
+		if d.Type != "" {
+			fmt.Fprintf(&buf, " (%s)", d.Type)
+		}
+		buf.WriteByte('\n')
+		buf.WriteString(d.Diff)
+	}
+	fmt.Fprintf(&buf, "checked %d package(s), %d file(s) out of date\n", len(r.Packages), len(r.Drift))
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteJSON writes the report as a single JSON document for CI tooling.
+func (r CheckReport) WriteJSON(w io.Writer) error {
+	if r.Packages == nil {
+		r.Packages = []string{}
+	}
+	if r.Drift == nil {
+		r.Drift = []Drift{}
+	}
+	if r.Warnings == nil {
+		r.Warnings = []string{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// Check renders every package matched by args.Patterns in memory, with the
+// options from its go:generate directive, and reports how the result differs
+// from the files on disk. It writes nothing.
+func Check(args CheckArgs) (report CheckReport, err error) {
+	patterns := args.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	pkgs, err := syntax.Load(patterns...)
+	if err != nil {
+		return report, err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return report, err
+	}
+	var (
+		set                    = syntax.NewPackageSet(pkgs)
+		options, covered, errs = directiveOptions("check", pkgs, args.GenArgs)
+	)
+	for _, pkg := range pkgs {
+		genArgs, ok := options[pkg]
+		if !ok {
+			continue
+		}
+		scan, err := set.Scan(pkg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("check %s: %w", pkg.PkgPath, err))
+			continue
+		} else if covered[pkg] && !scan.HasSchemas() {
+			continue
+		}
+		drift, warnings, err := checkPackage(scan, genArgs, wd)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("check %s: %w", pkg.PkgPath, err))
+			continue
+		}
+		report.Packages = append(report.Packages, pkg.PkgPath)
+		report.Drift = append(report.Drift, drift...)
+		report.Warnings = append(report.Warnings, warnings...)
+	}
+	return report, errors.Join(errs...)
+}
+
+// directiveOptions returns the options each of pkgs is generated with, parsed
+// by parse from the go:generate directive that generates it. A directive that
+// names packages, such as "gen ./..." at the module root, generates those
+// packages rather than its own, so each matched package takes that
+// directive's options unless it has a directive of its own; such packages are
+// marked covered. Packages no directive generates are left out.
+func directiveOptions(verb string, pkgs []*decorator.Package, parse func(args []string) (BuilderArgs, error)) (
+	options map[*decorator.Package]BuilderArgs, covered map[*decorator.Package]bool, errs []error,
+) {
+	options = make(map[*decorator.Package]BuilderArgs)
+	covered = make(map[*decorator.Package]bool)
+	for _, pkg := range pkgs {
+		directive, ok, err := genDirective(pkg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", verb, pkg.PkgPath, err))
+			continue
+		} else if !ok {
+			continue
+		}
+		genArgs, err := parse(directive)
+		if err == nil {
+			err = genArgs.validate()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: go:generate arguments: %w", verb, pkg.PkgPath, err))
+			continue
+		}
+		if len(genArgs.Patterns) == 0 {
+			options[pkg] = genArgs
+			continue
+		}
+		for _, other := range pkgs {
+			if _, own := options[other]; !own && !covered[other] && matchPackage(pkg.Dir, genArgs.Patterns, other) {
+				options[other] = genArgs
+				covered[other] = true
+			}
+		}
+	}
+	return options, covered, errs
+}
+
+// matchPackage reports whether pkg is selected by patterns given to a
+// directive in dir. Relative patterns are resolved against dir; others are
+// import paths. Either may end in "/..." to match a whole tree.
+func matchPackage(dir string, patterns []string, pkg *decorator.Package) bool {
+	for _, pattern := range patterns {
+		pattern, tree := strings.CutSuffix(pattern, "/...")
+		if pattern == "." || pattern == "./" || strings.HasPrefix(pattern, "./") || strings.HasPrefix(pattern, "../") {
+			root := filepath.Join(dir, filepath.FromSlash(pattern))
+			if pkg.Dir == root || tree && strings.HasPrefix(pkg.Dir, root+string(filepath.Separator)) {
+				return true
+			}
+		} else if pkg.PkgPath == pattern || tree && strings.HasPrefix(pkg.PkgPath, pattern+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// genDirective returns the arguments of the first go:generate directive in
+// pkg that runs gen-jsonschema to generate schemas.
+func genDirective(pkg *decorator.Package) (args []string, ok bool, err error) {
+	for _, fileName := range pkg.GoFiles {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return nil, false, err
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line, found := strings.CutPrefix(scanner.Text(), "//go:generate ")
+			if !found {
+				continue
+			}
+			fields := strings.Fields(line)
+			for i, field := range fields {
+				tool, _, _ := strings.Cut(field, "@")
+				if path.Base(strings.TrimSuffix(tool, "/")) != "gen-jsonschema" {
+					continue
+				}
+				args = fields[i+1:]
+				if len(args) > 0 && args[0] == "gen" {
+					args = args[1:]
+				} else if len(args) > 0 && args[0] == "new" {
+					break
+				}
+				_ = file.Close()
+				return args, true, nil
+			}
+		}
+		err = errors.Join(scanner.Err(), file.Close())
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return nil, false, nil
+}
+
+func checkPackage(scan syntax.ScanResult, args BuilderArgs, wd string) (drift []Drift, warnings []string, err error) {
+	pkg := scan.Pkg
+	builder, err := newConfiguredBuilder(scan, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings = *builder.Warnings
+	var (
+		schemaDir = filepath.Join(pkg.Dir, builder.Subdir)
+		expected  = map[string]bool{}
+		dialects  = append([]Dialect{""}, builder.Dialects...)
+		relPath   = func(file string) string {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				return rel
+			}
+			return file
+		}
+		compare = func(typeName, file string, generated []byte, kind DriftKind) error {
+			committed, err := os.ReadFile(file)
+			if errors.Is(err, os.ErrNotExist) {
+				drift = append(drift, Drift{Kind: DriftMissing, Package: pkg.PkgPath, File: relPath(file), Type: typeName})
+				return nil
+			} else if err != nil {
+				return err
+			} else if bytes.Equal(committed, generated) {
+				return nil
+			}
+			diff, err := unifiedDiff(relPath(file), committed, generated)
+			if err != nil {
+				return err
+			}
+			drift = append(drift, Drift{Kind: kind, Package: pkg.PkgPath, File: relPath(file), Type: typeName, Diff: diff})
+			return nil
+		}
+	)
+	for _, receiver := range builder.schemaReceivers() {
+		for _, dialect := range dialects {
+			data, err := builder.schemaFile(receiver, dialect)
+			if err != nil {
+				return nil, nil, err
+			}
+			file := filepath.Join(schemaDir, builder.schemaFileName(receiver, dialect))
+			expected[filepath.Base(file)] = true
+			expected[filepath.Base(file)+".sum"] = true
+			if err = compare(receiver.TypeName, file, data, DriftChanged); err != nil {
+				return nil, nil, err
+			}
+			if err = compare(receiver.TypeName, file+".sum", []byte(schemaChecksum(data)), DriftChanged); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(schemaDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, err
+	}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".sum")
+		if entry.IsDir() || expected[entry.Name()] || !(strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json.tmpl")) {
+			continue
+		}
+		drift = append(drift, Drift{Kind: DriftOrphaned, Package: pkg.PkgPath, File: relPath(filepath.Join(schemaDir, entry.Name()))})
+	}
+
+	code, err := builder.renderGoCode()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = compare("", filepath.Join(pkg.Dir, goCodeFile), code, DriftStale); err != nil {
+		return nil, nil, err
+	}
+	slices.SortStableFunc(drift, func(a, b Drift) int { return strings.Compare(a.File, b.File) })
+	return drift, warnings, nil
+}
+
+// unifiedDiff diffs committed against generated. JSON files are indented
+// first so the diff shows individual keywords rather than whole lines of
+// compact output; if indenting hides the difference, the raw text is diffed.
+func unifiedDiff(file string, committed, generated []byte) (string, error) {
+	from, to := committed, generated
+	if strings.HasSuffix(file, ".json") {
+		var a, b bytes.Buffer
+		if json.Indent(&a, committed, "", "  ") == nil && json.Indent(&b, generated, "", "  ") == nil && !bytes.Equal(a.Bytes(), b.Bytes()) {
+			from, to = a.Bytes(), b.Bytes()
+		}
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(from)),
+		B:        difflib.SplitLines(string(to)),
+		FromFile: "a/" + filepath.ToSlash(file),
+		ToFile:   "b/" + filepath.ToSlash(file),
+		Context:  3,
+	})
+}
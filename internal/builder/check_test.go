@@ -0,0 +1,100 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The directive is split so that go generate does not run it in this package.
+const checkFixtureTypes = `package fixture
+
+//go:` + `generate go tool gen-jsonschema gen --pretty
+
+type Owner struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+
+func TestCheckReportsDriftWithoutWriting(t *testing.T) {
+	targetDir := writeCheckFixture(t)
+	require.NoError(t, Run(BuilderArgs{TargetDir: targetDir, Pretty: true}))
+
+	var directives [][]string
+	args := CheckArgs{
+		Patterns: []string{targetDir},
+		GenArgs: func(args []string) (BuilderArgs, error) {
+			directives = append(directives, args)
+			return BuilderArgs{Pretty: slices.Contains(args, "--pretty")}, nil
+		},
+	}
+	report, err := Check(args)
+	require.NoError(t, err)
+	require.Len(t, report.Packages, 1)
+	require.Empty(t, report.Drift)
+	require.Equal(t, [][]string{{"--pretty"}}, directives)
+
+	types := filepath.Join(targetDir, "types.go")
+	require.NoError(t, os.WriteFile(types, []byte(strings.Replace(checkFixtureTypes, `"name"`, `"title"`, 1)), 0o644))
+	orphan := filepath.Join(targetDir, "jsonschema", "Removed.json")
+	require.NoError(t, os.WriteFile(orphan, []byte("{}\n"), 0o644))
+	goCode := filepath.Join(targetDir, goCodeFile)
+	code, err := os.ReadFile(goCode)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(goCode, append(code, "// edited\n"...), 0o644))
+	committed, err := os.ReadFile(filepath.Join(targetDir, "jsonschema", "Owner.json"))
+	require.NoError(t, err)
+
+	report, err = Check(args)
+	require.NoError(t, err)
+	kinds := map[string]DriftKind{}
+	for _, drift := range report.Drift {
+		kinds[filepath.Base(drift.File)] = drift.Kind
+	}
+	require.Equal(t, map[string]DriftKind{
+		"Owner.json":     DriftChanged,
+		"Owner.json.sum": DriftChanged,
+		"Removed.json":   DriftOrphaned,
+		goCodeFile:       DriftStale,
+	}, kinds)
+	require.Equal(t, "Owner", report.Drift[0].Type)
+	require.Contains(t, report.Drift[0].Diff, `-    "name": {`)
+	require.Contains(t, report.Drift[0].Diff, `+    "title": {`)
+
+	after, err := os.ReadFile(filepath.Join(targetDir, "jsonschema", "Owner.json"))
+	require.NoError(t, err)
+	require.Equal(t, string(committed), string(after), "check must not write schema files")
+
+	require.NoError(t, os.Remove(filepath.Join(targetDir, "jsonschema", "Owner.json")))
+	report, err = Check(args)
+	require.NoError(t, err)
+	require.Equal(t, DriftMissing, report.Drift[0].Kind)
+	require.Equal(t, "Owner", report.Drift[0].Type)
+}
+
+func writeCheckFixture(t *testing.T) string {
+	t.Helper()
+
+	schema := `//go:build jsonschema
+
+package fixture
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Owner) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
+`
+	return writeFixture(t, "check_", map[string]string{
+		"types.go":  checkFixtureTypes,
+		"schema.go": schema,
+	})
+}
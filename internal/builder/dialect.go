@@ -0,0 +1,154 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Dialect names a provider's JSON Schema subset. The built model is always
+// draft 2020-12; a dialect rewrites it into the shape the provider accepts
+// and rejects constructs the provider cannot express.
+type Dialect string
+
+const (
+	// DialectDraft2020 is the generator's native output.
+	DialectDraft2020 Dialect = "draft2020"
+	// DialectOpenAIStrict targets OpenAI Structured Outputs in strict mode,
+	// where every property is required and every object is closed.
+	DialectOpenAIStrict Dialect = "openai-strict"
+	// DialectAnthropic targets Anthropic tool input schemas, which accept the
+	// native output unchanged.
+	DialectAnthropic Dialect = "anthropic"
+	// DialectGemini targets Gemini response schemas, an OpenAPI 3.0 subset
+	// that marks nullability with "nullable" and has no $ref or const.
+	DialectGemini Dialect = "gemini"
+)
+
+// Dialects lists every supported dialect in CLI order.
+var Dialects = []Dialect{DialectOpenAIStrict, DialectAnthropic, DialectGemini, DialectDraft2020}
+
+func (d Dialect) valid() bool {
+	for _, known := range Dialects {
+		if d == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDialects parses a comma-separated dialect list.
+func ParseDialects(value string) ([]Dialect, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var (
+		dialects []Dialect
+		seen     = map[Dialect]bool{}
+	)
+	for _, name := range strings.Split(value, ",") {
+		d := Dialect(strings.TrimSpace(name))
+		if !d.valid() {
+			return nil, fmt.Errorf("unknown dialect %q: expected one of %s", name, dialectNames())
+		}
+		if !seen[d] {
+			seen[d] = true
+			dialects = append(dialects, d)
+		}
+	}
+	return dialects, nil
+}
+
+func dialectNames() string {
+	names := make([]string, len(Dialects))
+	for i, d := range Dialects {
+		names[i] = string(d)
+	}
+	return strings.Join(names, ", ")
+}
+
+// rewrite returns schema in the dialect's shape. path names the schema's
+// location in error messages, e.g. "Owner.items[].name".
+func (d Dialect) rewrite(s SchemaBuilder, schema JSONSchema, path string) (JSONSchema, error) {
+	switch d {
+	case DialectOpenAIStrict:
+		return schema, checkOpenAIStrict(s, schema, path)
+	case DialectGemini:
+		r := geminiRewriter{builder: s, inlining: map[string]bool{}}
+		rewritten := r.rewrite(schema, path)
+		return rewritten, errors.Join(r.errs...)
+	default:
+		return schema, nil
+	}
+}
+
+// checkOpenAIStrict reports every construct in schema outside OpenAI's strict
+// subset.
+func checkOpenAIStrict(s SchemaBuilder, schema JSONSchema, path string) error {
+	c := openAIStrictChecker{builder: s, visitedDefs: map[string]bool{}}
+	if len(s.TypeExamples[schema.TypeID().TypeName]) > 0 {
+		c.fail(path, "examples are not supported in strict mode")
+	}
+	c.check(schema, path)
+	return errors.Join(c.errs...)
+}
+
+// openAIStrictChecker rejects constructs outside OpenAI's strict subset. The
+// native model is otherwise already strict-compatible, so nothing is
+// rewritten.
+type openAIStrictChecker struct {
+	builder     SchemaBuilder
+	visitedDefs map[string]bool
+	errs        []error
+}
+
+func (c *openAIStrictChecker) fail(path, format string, args ...any) {
+	c.errs = append(c.errs, fmt.Errorf("dialect %s: %s: %s", DialectOpenAIStrict, path, fmt.Sprintf(format, args...)))
+}
+
+func (c *openAIStrictChecker) check(schema JSONSchema, path string) {
+	switch node := schema.(type) {
+	case ObjectNode:
+		for _, prop := range node.Properties {
+			propPath := path + "." + prop.Name
+			if prop.Optional {
+				c.fail(propPath, "optional properties cannot be expressed; strict mode requires every property, use Nullable[T] instead of Optional[T]")
+			}
+			if prop.Default != nil {
+				c.fail(propPath, "default is not supported in strict mode")
+			}
+			if len(prop.Examples) > 0 {
+				c.fail(propPath, "examples are not supported in strict mode")
+			}
+			c.check(prop.Schema, propPath)
+		}
+	case PropertyNode[string]:
+		if node.MinLength != nil || node.MaxLength != nil {
+			c.fail(path, "minLength and maxLength are not supported in strict mode")
+		}
+	case MapNode:
+		c.fail(path, "open objects (maps) cannot be expressed; strict mode requires additionalProperties:false")
+	case AnyNode:
+		c.fail(path, "type %s could not be resolved; strict mode requires every schema to have a type", node.TypeID_)
+	case ArrayNode:
+		if node.Items != nil {
+			c.check(node.Items, path+"[]")
+		}
+	case UnionTypeNode:
+		// Check each option as written, so that the properties of external
+		// and adjacent tagging wrappers are checked and named in paths.
+		for _, option := range node.Options {
+			c.check(node.option(option), path)
+		}
+	case NullableObjectNode:
+		c.check(node.Object, path)
+	case NullableUnionNode:
+		c.check(node.Schema, path)
+	case RefNode:
+		name := strings.TrimPrefix(node.Ref, "#/$defs/")
+		if def, ok := c.builder.RefDefs[name]; ok && !c.visitedDefs[name] {
+			c.visitedDefs[name] = true
+			c.check(def.Schema, "$defs."+name)
+		}
+	}
+}
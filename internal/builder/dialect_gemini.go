@@ -0,0 +1,219 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// geminiRewriter converts the native model into Gemini's OpenAPI 3.0 style
+// response schema: nullability becomes "nullable":true, const becomes a
+// one-value enum, $ref targets are inlined, and objects drop
+// additionalProperties in favor of propertyOrdering. String formats other
+// than date-time are ignored by Gemini, so they move into the description.
+type geminiRewriter struct {
+	builder  SchemaBuilder
+	inlining map[string]bool
+	errs     []error
+}
+
+func (g *geminiRewriter) fail(path, format string, args ...any) JSONSchema {
+	g.errs = append(g.errs, fmt.Errorf("dialect %s: %s: %s", DialectGemini, path, fmt.Sprintf(format, args...)))
+	return OrderedNode{}
+}
+
+func (g *geminiRewriter) rewrite(schema JSONSchema, path string) JSONSchema {
+	switch node := schema.(type) {
+	case ObjectNode:
+		return g.object(node, path)
+	case PropertyNode[string]:
+		return geminiProperty(g, node, path)
+	case PropertyNode[int]:
+		return geminiProperty(g, node, path)
+	case PropertyNode[float64]:
+		return geminiProperty(g, node, path)
+	case PropertyNode[bool]:
+		return geminiProperty(g, node, path)
+	case ArrayNode:
+		keywords := []Keyword{{"type", literal("array")}}
+		if node.Desc != "" {
+			keywords = append(keywords, Keyword{"description", literal(node.Desc)})
+		}
+		if node.Items != nil {
+			keywords = append(keywords, Keyword{"items", g.rewrite(node.Items, path+"[]")})
+		}
+		if node.MinItems != nil {
+			keywords = append(keywords, Keyword{"minItems", literal(*node.MinItems)})
+		}
+		if node.MaxItems != nil {
+			keywords = append(keywords, Keyword{"maxItems", literal(*node.MaxItems)})
+		}
+		return OrderedNode{Keywords: keywords, TypeID_: node.TypeID_}
+	case UnionTypeNode:
+		options := make(orderedList, len(node.Options))
+		for i, option := range node.Options {
+			options[i] = g.object(node.option(option), path)
+		}
+		if node.Nullable {
+			return g.fail(path, "nullable unions cannot be expressed; Gemini allows nullable only on typed schemas, not anyOf")
+		}
+		return OrderedNode{Keywords: []Keyword{{"anyOf", options}}, TypeID_: node.TypeID_}
+	case NullableObjectNode:
+		return g.nullable(g.object(node.Object, path), path)
+	case NullableUnionNode:
+		return g.nullable(g.rewrite(node.Schema, path), path)
+	case MapNode:
+		return g.fail(path, "open objects (maps) cannot be expressed; Gemini response schemas have no additionalProperties")
+	case AnyNode:
+		return g.fail(path, "type %s could not be resolved; Gemini response schemas require a type", node.TypeID_)
+	case RefNode:
+		name := strings.TrimPrefix(node.Ref, "#/$defs/")
+		def, ok := g.builder.RefDefs[name]
+		if !ok {
+			return g.fail(path, "explicit $ref %q cannot be resolved; Gemini response schemas have no $ref", node.Ref)
+		}
+		if g.inlining[name] {
+			return g.fail(path, "recursive $ref %q cannot be inlined", node.Ref)
+		}
+		g.inlining[name] = true
+		defer delete(g.inlining, name)
+		return g.rewrite(def.Schema, path)
+	default:
+		return schema
+	}
+}
+
+func (g *geminiRewriter) object(node ObjectNode, path string) OrderedNode {
+	keywords := []Keyword{{"type", literal("object")}}
+	if node.Desc != "" {
+		keywords = append(keywords, Keyword{"description", literal(node.Desc)})
+	}
+	if len(node.Properties) > 0 {
+		var (
+			properties = make(orderedMap, len(node.Properties))
+			ordering   = make([]string, len(node.Properties))
+		)
+		for i, prop := range node.Properties {
+			properties[i] = Keyword{prop.Name, prop.annotate(g.rewrite(prop.Schema, path+"."+prop.Name), DialectGemini)}
+			ordering[i] = prop.Name
+		}
+		keywords = append(keywords, Keyword{"properties", properties})
+		if required := requiredPropertyNames(node.Properties); len(required) > 0 {
+			keywords = append(keywords, Keyword{"required", literal(required)})
+		}
+		keywords = append(keywords, Keyword{"propertyOrdering", literal(ordering)})
+	}
+	return OrderedNode{Keywords: keywords, TypeID_: node.TypeID_}
+}
+
+func (g *geminiRewriter) nullable(schema JSONSchema, path string) JSONSchema {
+	node, ok := schema.(OrderedNode)
+	if !ok || len(node.Keywords) == 0 || node.Keywords[0].Name != "type" {
+		return g.fail(path, "nullable %T cannot be expressed", schema)
+	}
+	return node.insertAfter("type", Keyword{"nullable", literal(true)})
+}
+
+func geminiProperty[T ~int | ~string | ~bool | float32 | float64](g *geminiRewriter, p PropertyNode[T], path string) JSONSchema {
+	keywords := []Keyword{{"type", literal(p.Typ)}}
+	if p.Nullable {
+		keywords = append(keywords, Keyword{"nullable", literal(true)})
+	}
+	desc, format := p.Desc, ""
+	if len(p.Formats) == 1 && p.Formats[0] == "date-time" {
+		format = p.Formats[0]
+	} else if len(p.Formats) > 0 {
+		desc = formatDescription(desc, p.Formats)
+	}
+	if p.EnumDescriptions != nil {
+		// Gemini has no const, so the value descriptions become a legend.
+		desc = enumLegend(desc, p.Enum, p.EnumDescriptions)
+	}
+	if desc != "" {
+		keywords = append(keywords, Keyword{"description", literal(desc)})
+	}
+	if format != "" {
+		keywords = append(keywords, Keyword{"format", literal(format)})
+	}
+	switch {
+	case p.Const != nil && p.Typ == "string":
+		keywords = append(keywords, Keyword{"enum", literal([]T{*p.Const})})
+	case p.Const != nil:
+		return g.fail(path, "%s const cannot be expressed; Gemini supports only string enums", p.Typ)
+	case len(p.Enum) > 0 && p.Typ == "string":
+		keywords = append(keywords, Keyword{"enum", literal(p.Enum)})
+	case len(p.Enum) > 0:
+		return g.fail(path, "%s enum cannot be expressed; Gemini supports only string enums, use WithStringerEnum", p.Typ)
+	}
+	if p.Minimum != nil {
+		keywords = append(keywords, Keyword{"minimum", literal(*p.Minimum)})
+	}
+	if p.Maximum != nil {
+		keywords = append(keywords, Keyword{"maximum", literal(*p.Maximum)})
+	}
+	if p.MinLength != nil {
+		keywords = append(keywords, Keyword{"minLength", literal(*p.MinLength)})
+	}
+	if p.MaxLength != nil {
+		keywords = append(keywords, Keyword{"maxLength", literal(*p.MaxLength)})
+	}
+	if p.Pattern != "" {
+		keywords = append(keywords, Keyword{"pattern", literal(p.Pattern)})
+	}
+	return OrderedNode{Keywords: keywords, TypeID_: p.TypeID_}
+}
+
+// enumLegend appends a line per described value to description.
+func enumLegend[T any](description string, values []T, descriptions []string) string {
+	var sb strings.Builder
+	for i, value := range values {
+		if descriptions[i] == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n- %v: %s", value, descriptions[i])
+	}
+	if sb.Len() == 0 {
+		return description
+	}
+	legend := "Values:" + sb.String()
+	if description == "" {
+		return legend
+	}
+	return description + "\n\n" + legend
+}
+
+// literal encodes a plain Go value as a keyword value.
+func literal(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("literal %#v: %s", v, err))
+	}
+	return data
+}
+
+// orderedMap is an ordered JSON object of schemas, such as "properties".
+type orderedMap []Keyword
+
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	return OrderedNode{Keywords: m}.MarshalJSON()
+}
+
+// orderedList is a JSON array of schemas, such as "anyOf".
+type orderedList []JSONSchema
+
+func (l orderedList) MarshalJSON() ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, item := range l {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		data, err := item.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		sb.Write(data)
+	}
+	sb.WriteByte(']')
+	return []byte(sb.String()), nil
+}
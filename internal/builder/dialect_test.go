@@ -0,0 +1,117 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func dialectTestBuilder(root ObjectNode) (SchemaBuilder, syntax.TypeID) {
+	typeID := syntax.TypeID{PkgPath: "example.com/test", TypeName: "Example"}
+	builder := SchemaBuilder{schemas: make(schemaMap), RefDefs: map[string]refDef{}}
+	root.TypeID_ = typeID
+	builder.AddSchema(typeID, root)
+	return builder, typeID
+}
+
+func TestParseDialects(t *testing.T) {
+	dialects, err := ParseDialects("gemini, openai-strict,gemini")
+	require.NoError(t, err)
+	require.Equal(t, []Dialect{DialectGemini, DialectOpenAIStrict}, dialects)
+
+	dialects, err = ParseDialects("")
+	require.NoError(t, err)
+	require.Empty(t, dialects)
+
+	_, err = ParseDialects("openai")
+	require.ErrorContains(t, err, `unknown dialect "openai"`)
+}
+
+func TestGeminiDialectRewritesNullabilityConstAndRefs(t *testing.T) {
+	kind := "circle"
+	builder, typeID := dialectTestBuilder(ObjectNode{
+		Desc: "Example.",
+		Properties: ObjectPropSet{
+			{Name: "name", Schema: PropertyNode[string]{Typ: "string", Nullable: true, MaxLength: new(8)}},
+			{Name: "color", Schema: NullableUnionNode{Schema: PropertyNode[string]{Typ: "string", Enum: []string{"red", "blue"}}}},
+			{Name: "address", Schema: RefNode{Ref: "#/$defs/Address"}},
+			{Name: "shape", Schema: UnionTypeNode{Options: []ObjectNode{{Discriminator: kind, Properties: ObjectPropSet{
+				{Name: "radius", Schema: PropertyNode[float64]{Typ: "number", Minimum: new(0.0)}},
+			}}}}},
+			{Name: "tags", Schema: ArrayNode{Items: PropertyNode[string]{Typ: "string"}, MinItems: new(1)}, Optional: true},
+		},
+	})
+	builder.RefDefs["Address"] = refDef{Schema: ObjectNode{Properties: ObjectPropSet{
+		{Name: "city", Schema: PropertyNode[string]{Typ: "string"}},
+	}}}
+
+	targetDir := t.TempDir()
+	changed, err := builder.writeSchema(typeID, DialectGemini, targetDir, false)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	generated, err := os.ReadFile(filepath.Join(targetDir, "Example.gemini.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type":"object","description":"Example.",
+		"properties":{
+			"name":{"type":"string","nullable":true,"maxLength":8},
+			"color":{"type":"string","nullable":true,"enum":["red","blue"]},
+			"address":{"type":"object","properties":{"city":{"type":"string"}},"required":["city"],"propertyOrdering":["city"]},
+			"shape":{"anyOf":[{"type":"object","properties":{
+				"type":{"type":"string","enum":["circle"]},
+				"radius":{"type":"number","minimum":0}
+			},"required":["type","radius"],"propertyOrdering":["type","radius"]}]},
+			"tags":{"type":"array","items":{"type":"string"},"minItems":1}
+		},
+		"required":["name","color","address","shape"],
+		"propertyOrdering":["name","color","address","shape","tags"]
+	}`, string(generated))
+	require.NotContains(t, string(generated), "$defs")
+}
+
+func TestDialectErrorsNameFieldPaths(t *testing.T) {
+	builder, _ := dialectTestBuilder(ObjectNode{
+		Properties: ObjectPropSet{
+			{Name: "label", Schema: PropertyNode[string]{Typ: "string", MinLength: new(1)}, Optional: true},
+			{Name: "items", Schema: ArrayNode{Items: ObjectNode{Properties: ObjectPropSet{
+				{Name: "attrs", Schema: MapNode{Values: PropertyNode[string]{Typ: "string"}}},
+				{Name: "level", Schema: PropertyNode[int]{Typ: "integer", Enum: []int{1, 2}}},
+			}}}},
+			{Name: "tracking", Schema: AnyNode{TypeID_: syntax.TypeID{PkgPath: "example.com/missing", TypeName: "Number"}}},
+			{Name: "size", Schema: PropertyNode[int]{Typ: "integer"}, Default: json.RawMessage("1"), Examples: []json.RawMessage{json.RawMessage("2")}},
+			{Name: "shape", Schema: UnionTypeNode{ExternallyTagged: true, Options: []ObjectNode{{
+				Discriminator: "circle",
+				Properties:    ObjectPropSet{{Name: "attrs", Schema: MapNode{Values: PropertyNode[string]{Typ: "string"}}}},
+			}}}},
+		},
+	})
+	builder.TypeExamples = map[string][]json.RawMessage{"Example": {json.RawMessage("{}")}}
+	root, ok := builder.GetSchema(syntax.TypeID{PkgPath: "example.com/test", TypeName: "Example"})
+	require.True(t, ok)
+
+	_, err := DialectOpenAIStrict.rewrite(builder, root, "Example")
+	require.ErrorContains(t, err, "dialect openai-strict: Example: examples are not supported in strict mode")
+	require.ErrorContains(t, err, "dialect openai-strict: Example.label: optional properties cannot be expressed")
+	require.ErrorContains(t, err, "dialect openai-strict: Example.label: minLength and maxLength are not supported")
+	require.ErrorContains(t, err, "dialect openai-strict: Example.items[].attrs: open objects (maps) cannot be expressed")
+	require.ErrorContains(t, err, "dialect openai-strict: Example.size: default is not supported in strict mode")
+	require.ErrorContains(t, err, "dialect openai-strict: Example.size: examples are not supported in strict mode")
+	require.ErrorContains(t, err, "dialect openai-strict: Example.shape.circle.attrs: open objects (maps) cannot be expressed")
+	require.ErrorContains(t, err, "dialect openai-strict: Example.tracking: type example.com/missing.Number could not be resolved")
+
+	_, err = DialectGemini.rewrite(builder, root, "Example")
+	require.ErrorContains(t, err, "dialect gemini: Example.items[].attrs: open objects (maps) cannot be expressed")
+	require.ErrorContains(t, err, "dialect gemini: Example.items[].level: integer enum cannot be expressed")
+	require.ErrorContains(t, err, "dialect gemini: Example.tracking: type example.com/missing.Number could not be resolved")
+
+	for _, dialect := range []Dialect{DialectAnthropic, DialectDraft2020} {
+		rewritten, err := dialect.rewrite(builder, root, "Example")
+		require.NoError(t, err)
+		require.Equal(t, root, rewritten)
+	}
+}
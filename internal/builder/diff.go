@@ -0,0 +1,325 @@
+package builder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dave/dst/decorator"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+// DiffArgs configures Diff.
+type DiffArgs struct {
+	// Patterns select the packages to compare, such as "./...". Packages that
+	// register no schemas are skipped.
+	Patterns []string
+	// Base is a git revision, or a directory laid out like the working
+	// directory (such as a worktree of the base revision). When a single
+	// package is compared, Base may also be that package's previous
+	// jsonschema directory.
+	Base string
+	// GenArgs parses the arguments of a package's gen-jsonschema directive
+	// into the options generation runs with, so that each package is
+	// rendered, and its base read, as go generate would. Packages without a
+	// directive, or every package when GenArgs is nil, use the defaults.
+	GenArgs func(args []string) (BuilderArgs, error)
+}
+
+// DiffReport lists the packages Diff compared and every schema change found.
+type DiffReport struct {
+	Packages []string       `json:"packages"`
+	Changes  []SchemaChange `json:"changes"`
+}
+
+// Acknowledge marks the breaking changes whose keys are in acknowledged.
+func (r *DiffReport) Acknowledge(acknowledged map[string]bool) {
+	for i, change := range r.Changes {
+		r.Changes[i].Acknowledged = change.Breaking && acknowledged[change.Key()]
+	}
+}
+
+// Unacknowledged returns the breaking changes that were not acknowledged.
+func (r DiffReport) Unacknowledged() (changes []SchemaChange) {
+	for _, change := range r.Changes {
+		if change.Breaking && !change.Acknowledged {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// WriteText writes one line per change, prefixed by its classification and
+// followed by its key, then a summary line.
+func (r DiffReport) WriteText(w io.Writer) error {
+	var (
+		buf          bytes.Buffer
+		breaking     int
+		acknowledged int
+	)
+	for _, change := range r.Changes {
+		label := "non-breaking"
+		if change.Acknowledged {
+			label, acknowledged = "breaking (acknowledged)", acknowledged+1
+		} else if change.Breaking {
+			label, breaking = "breaking", breaking+1
+		}
+		fmt.Fprintf(&buf, "%s: %s: %s\n", label, change.Key(), change.Detail)
+	}
+	fmt.Fprintf(&buf, "compared %d package(s): %d breaking, %d acknowledged, %d non-breaking\n",
+		len(r.Packages), breaking, acknowledged, len(r.Changes)-breaking-acknowledged)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteJSON writes the report as a single JSON document for CI tooling.
+func (r DiffReport) WriteJSON(w io.Writer) error {
+	if r.Packages == nil {
+		r.Packages = []string{}
+	}
+	if r.Changes == nil {
+		r.Changes = []SchemaChange{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// ReadAcknowledgements reads change keys, one per line, from file. Blank
+// lines and lines starting with # are ignored. A missing file acknowledges
+// nothing.
+func ReadAcknowledgements(file string) (map[string]bool, error) {
+	acknowledged := map[string]bool{}
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return acknowledged, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.Join(strings.Fields(scanner.Text()), " ")
+		if line != "" && !strings.HasPrefix(line, "#") {
+			acknowledged[line] = true
+		}
+	}
+	return acknowledged, scanner.Err()
+}
+
+// Diff compares the schemas the builder renders for each package matched by
+// args.Patterns against the schema files in args.Base, and classifies every
+// change as breaking or not. It writes nothing.
+func Diff(args DiffArgs) (report DiffReport, err error) {
+	if args.Base == "" {
+		return report, errors.New("a base git revision or directory is required")
+	}
+	patterns := args.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	pkgs, err := syntax.Load(patterns...)
+	if err != nil {
+		return report, err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return report, err
+	}
+
+	var (
+		set         = syntax.NewPackageSet(pkgs)
+		scans       []syntax.ScanResult
+		registering int
+		options     map[*decorator.Package]BuilderArgs
+		errs        []error
+		readBase    func(single bool) baseReader
+	)
+	if args.GenArgs != nil {
+		options, _, errs = directiveOptions("diff", pkgs, args.GenArgs)
+	}
+	// Packages that register nothing are still compared, so that a package
+	// whose last registration was removed reports its schemas as removed.
+	for _, pkg := range pkgs {
+		scan, err := set.Scan(pkg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("diff %s: %w", pkg.PkgPath, err))
+			continue
+		}
+		scans = append(scans, scan)
+		if scan.HasSchemas() {
+			registering++
+		}
+	}
+	if st, statErr := os.Stat(args.Base); statErr == nil && st.IsDir() {
+		readBase = func(single bool) baseReader { return dirBase(args.Base, wd, single) }
+	} else if readGit, err := gitBase(args.Base); err != nil {
+		return report, err
+	} else {
+		readBase = func(bool) baseReader { return readGit }
+	}
+
+	for _, scan := range scans {
+		// A base directory may hold a lone package's schema files directly.
+		single := len(scans) == 1 || (registering == 1 && scan.HasSchemas())
+		changes, err := diffPackage(scan, options[scan.Pkg], readBase(single))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("diff %s: %w", scan.Pkg.PkgPath, err))
+			continue
+		}
+		if !scan.HasSchemas() && len(changes) == 0 {
+			continue
+		}
+		report.Packages = append(report.Packages, scan.Pkg.PkgPath)
+		report.Changes = append(report.Changes, changes...)
+	}
+	return report, errors.Join(errs...)
+}
+
+// baseReader returns the base version of the schema files in a package's
+// schema directory, keyed by file name. A directory that did not exist in
+// the base yields no files.
+type baseReader func(schemaDir string) (map[string][]byte, error)
+
+func dirBase(base, wd string, single bool) baseReader {
+	return func(schemaDir string) (map[string][]byte, error) {
+		rel, err := filepath.Rel(wd, schemaDir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("%s is outside the working directory", schemaDir)
+		}
+		dir := filepath.Join(base, rel)
+		if _, err = os.Stat(dir); errors.Is(err, os.ErrNotExist) && single {
+			dir = base
+		}
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		files := map[string][]byte{}
+		for _, entry := range entries {
+			if entry.IsDir() || !isBaseSchemaFile(entry.Name()) {
+				continue
+			}
+			if files[entry.Name()], err = os.ReadFile(filepath.Join(dir, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+		return files, nil
+	}
+}
+
+func gitBase(rev string) (baseReader, error) {
+	if out, err := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}").CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("--base %q is neither a directory nor a git revision: %s", rev, commandOutput(out, err))
+	}
+	return func(schemaDir string) (map[string][]byte, error) {
+		// Run from the package directory, which exists even when the schema
+		// directory does not, and name files relative to it.
+		pkgDir, subdir := filepath.Dir(schemaDir), filepath.Base(schemaDir)
+		git := func(args ...string) ([]byte, error) {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = pkgDir
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			out, err := cmd.Output()
+			if err != nil {
+				return nil, fmt.Errorf("git %s: %s", strings.Join(args, " "), commandOutput(stderr.Bytes(), err))
+			}
+			return out, nil
+		}
+		names, err := git("ls-tree", "--name-only", rev, "--", "./"+subdir+"/")
+		if err != nil {
+			return nil, err
+		}
+		files := map[string][]byte{}
+		for _, name := range strings.Fields(string(names)) {
+			name = path.Base(name)
+			if !isBaseSchemaFile(name) {
+				continue
+			}
+			if files[name], err = git("show", rev+":./"+subdir+"/"+name); err != nil {
+				return nil, err
+			}
+		}
+		return files, nil
+	}, nil
+}
+
+func commandOutput(out []byte, err error) string {
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return msg
+	}
+	return err.Error()
+}
+
+// isBaseSchemaFile reports whether name is a default-dialect schema file.
+// Dialect variants (<Type>.<dialect>.json) and templates are not compared.
+func isBaseSchemaFile(name string) bool {
+	return strings.HasSuffix(name, ".json") && strings.Count(name, ".") == 1
+}
+
+func diffPackage(scan syntax.ScanResult, args BuilderArgs, readBase baseReader) (changes []SchemaChange, err error) {
+	builder, err := newConfiguredBuilder(scan, args)
+	if err != nil {
+		return nil, err
+	}
+	base, err := readBase(filepath.Join(scan.Pkg.Dir, builder.Subdir))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pkgPath = scan.Pkg.PkgPath
+		current = map[string]bool{}
+	)
+	for _, receiver := range builder.schemaReceivers() {
+		fileName := builder.schemaFileName(receiver, "")
+		if !isBaseSchemaFile(fileName) {
+			continue
+		}
+		current[fileName] = true
+		data, err := builder.schemaFile(receiver, "")
+		if err != nil {
+			return nil, err
+		}
+		baseData, ok := base[fileName]
+		if !ok {
+			changes = append(changes, SchemaChange{
+				Package: pkgPath, Type: receiver.TypeName, Kind: ChangeTypeAdded,
+				Detail: "schema was added",
+			})
+			continue
+		}
+		typeChanges, err := diffSchemas(baseData, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", receiver.TypeName, err)
+		}
+		for _, change := range typeChanges {
+			change.Package, change.Type = pkgPath, receiver.TypeName
+			changes = append(changes, change)
+		}
+	}
+	for name := range base {
+		if !current[name] {
+			changes = append(changes, SchemaChange{
+				Package: pkgPath, Type: strings.TrimSuffix(name, ".json"), Kind: ChangeTypeRemoved,
+				Detail: "schema was removed", Breaking: true,
+			})
+		}
+	}
+	slices.SortStableFunc(changes, func(a, b SchemaChange) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+	return changes, nil
+}
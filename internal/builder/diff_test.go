@@ -0,0 +1,217 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSchemasClassifiesChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		base, cur string
+		want      []string
+	}{
+		{
+			name: "removed property",
+			base: `{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"string"}},"required":["a","b"]}`,
+			cur:  `{"type":"object","properties":{"a":{"type":"string"}},"required":["a"]}`,
+			want: []string{"breaking /properties/b property-removed"},
+		},
+		{
+			name: "optional becomes required",
+			base: `{"type":"object","properties":{"a":{"type":"string"}}}`,
+			cur:  `{"type":"object","properties":{"a":{"type":"string"}},"required":["a"]}`,
+			want: []string{"breaking /properties/a property-required"},
+		},
+		{
+			name: "added properties",
+			base: `{"type":"object","properties":{}}`,
+			cur:  `{"type":"object","properties":{"opt":{"type":"string"},"req":{"type":"string"}},"required":["req"]}`,
+			want: []string{"non-breaking /properties/opt property-added", "breaking /properties/req required-property-added"},
+		},
+		{
+			name: "enum narrowed and widened",
+			base: `{"type":"string","enum":["a","b"]}`,
+			cur:  `{"type":"string","enum":["a","c"]}`,
+			want: []string{`breaking  enum-value-removed "b"`, `non-breaking  enum-value-added "c"`},
+		},
+		{
+			name: "described enum values",
+			base: `{"type":"string","enum":["a","b"]}`,
+			cur:  `{"type":"string","anyOf":[{"const":"a","description":"First."},{"const":"c"}]}`,
+			want: []string{`breaking  enum-value-removed "b"`, `non-breaking  enum-value-added "c"`},
+		},
+		{
+			name: "discriminator value changed",
+			base: `{"anyOf":[{"type":"object","properties":{"type":{"const":"circle"},"r":{"type":"number"}}},{"type":"object","properties":{"type":{"const":"square"}}}]}`,
+			cur:  `{"anyOf":[{"type":"object","properties":{"type":{"const":"round"},"r":{"type":"number"}}},{"type":"object","properties":{"type":{"const":"square"}}}]}`,
+			want: []string{`breaking /anyOf discriminator-removed type="circle"`, `non-breaking /anyOf discriminator-added type="round"`},
+		},
+		{
+			name: "external tags matched by name",
+			base: `{"anyOf":[{"type":"object","properties":{"Circle":{"type":"object","properties":{"r":{"type":"number"}}}},"required":["Circle"]},{"type":"object","properties":{"Square":{"type":"object"}},"required":["Square"]}]}`,
+			cur:  `{"anyOf":[{"type":"object","properties":{"Box":{"type":"object"}},"required":["Box"]},{"type":"object","properties":{"Circle":{"type":"object","properties":{"r":{"type":"integer"}}}},"required":["Circle"]}]}`,
+			want: []string{"breaking /anyOf/1/properties/Circle/properties/r type-narrowed number", "breaking /anyOf discriminator-removed {Square}", "non-breaking /anyOf discriminator-added {Box}"},
+		},
+		{
+			name: "adjacent tags matched by discriminator",
+			base: `{"anyOf":[{"type":"object","properties":{"type":{"const":"circle"},"content":{"type":"object","properties":{"r":{"type":"number"}}}}},{"type":"object","properties":{"type":{"const":"square"},"content":{"type":"object"}}}]}`,
+			cur:  `{"anyOf":[{"type":"object","properties":{"type":{"const":"square"},"content":{"type":"object"}}},{"type":"object","properties":{"type":{"const":"circle"},"content":{"type":"object","properties":{"r":{"type":"number"}},"required":["r"]}}}]}`,
+			want: []string{"breaking /anyOf/1/properties/content/properties/r property-required"},
+		},
+		{
+			name: "nullable widening",
+			base: `{"type":"object","properties":{"a":{"type":"string","minLength":1}}}`,
+			cur:  `{"type":"object","properties":{"a":{"anyOf":[{"type":"string","minLength":2},{"type":"null"}]}}}`,
+			want: []string{"breaking /properties/a/anyOf/0 constraint-tightened minLength", "non-breaking /properties/a/anyOf variant-added null"},
+		},
+		{
+			name: "bounds and types",
+			base: `{"type":"integer","maximum":10,"format":"int32"}`,
+			cur:  `{"type":"number","maximum":5}`,
+			want: []string{"non-breaking  type-widened number", "breaking  constraint-tightened maximum", "non-breaking  constraint-relaxed format"},
+		},
+		{
+			name: "items removed",
+			base: `{"type":"array","items":{"type":"string"}}`,
+			cur:  `{"type":"array"}`,
+			want: []string{"non-breaking /items constraint-relaxed"},
+		},
+		{
+			name: "property names narrowed",
+			base: `{"type":"object","propertyNames":{"enum":["a","b"]},"additionalProperties":{"type":"string"}}`,
+			cur:  `{"type":"object","propertyNames":{"enum":["a"]},"additionalProperties":{"type":"string"}}`,
+			want: []string{`breaking /propertyNames enum-value-removed "b"`},
+		},
+		{
+			name: "property names added",
+			base: `{"type":"object","additionalProperties":{"type":"string"}}`,
+			cur:  `{"type":"object","propertyNames":{"enum":["a"]},"additionalProperties":{"type":"string"}}`,
+			want: []string{"breaking /propertyNames constraint-tightened"},
+		},
+		{
+			name: "keywords beside a union",
+			base: `{"type":["string","integer"],"anyOf":[{"minLength":1},{"minimum":0}]}`,
+			cur:  `{"type":"string","anyOf":[{"minLength":1},{"minimum":0}]}`,
+			want: []string{"breaking  type-narrowed integer"},
+		},
+		{
+			name: "annotations and recursive refs",
+			base: `{"$ref":"#/$defs/Node","$defs":{"Node":{"type":"object","description":"old","properties":{"children":{"type":"array","items":{"$ref":"#/$defs/Node"}}}}}}`,
+			cur:  `{"$ref":"#/$defs/Node","$defs":{"Node":{"type":"object","description":"new","properties":{"children":{"type":"array","items":{"$ref":"#/$defs/Node"}}},"additionalProperties":false}}}`,
+			want: []string{"breaking  additional-properties-closed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := diffSchemas([]byte(tt.base), []byte(tt.cur))
+			require.NoError(t, err)
+			var got []string
+			for _, change := range changes {
+				label := "non-breaking"
+				if change.Breaking {
+					label = "breaking"
+				}
+				got = append(got, strings.TrimSpace(strings.Join([]string{label, change.Path, string(change.Kind), change.Value}, " ")))
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiffComparesAgainstBaseDirectory(t *testing.T) {
+	targetDir := writeCheckFixture(t)
+	require.NoError(t, Run(BuilderArgs{TargetDir: targetDir}))
+	base := t.TempDir()
+	committed, err := os.ReadFile(filepath.Join(targetDir, "jsonschema", "Owner.json"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(base, "Owner.json"), committed, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "Removed.json"), []byte(`{"type":"string"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "Owner.gemini.json"), []byte(`{}`), 0o644))
+
+	types := filepath.Join(targetDir, "types.go")
+	require.NoError(t, os.WriteFile(types, []byte(strings.Replace(checkFixtureTypes, `"name"`, `"title"`, 1)), 0o644))
+
+	report, err := Diff(DiffArgs{Patterns: []string{targetDir}, Base: base})
+	require.NoError(t, err)
+	require.Len(t, report.Packages, 1)
+	var keys []string
+	for _, change := range report.Changes {
+		keys = append(keys, change.Key())
+	}
+	pkgPath := report.Packages[0]
+	require.Equal(t, []string{
+		pkgPath + ".Owner#/properties/name property-removed",
+		pkgPath + ".Owner#/properties/title required-property-added",
+		pkgPath + ".Removed# type-removed",
+	}, keys)
+	require.Len(t, report.Unacknowledged(), 3)
+
+	ackFile := filepath.Join(t.TempDir(), "gen-jsonschema.ack")
+	ack := "# renamed before release\n" + keys[0] + "\n  " + strings.ReplaceAll(keys[1], " ", "   ") + "\n"
+	require.NoError(t, os.WriteFile(ackFile, []byte(ack), 0o644))
+	acknowledged, err := ReadAcknowledgements(ackFile)
+	require.NoError(t, err)
+	report.Acknowledge(acknowledged)
+	require.Equal(t, []SchemaChange{report.Changes[2]}, report.Unacknowledged())
+
+	var text strings.Builder
+	require.NoError(t, report.WriteText(&text))
+	require.Contains(t, text.String(), "breaking (acknowledged): "+keys[0]+`: property "name" was removed`)
+	require.Contains(t, text.String(), "compared 1 package(s): 1 breaking, 2 acknowledged, 0 non-breaking")
+}
+
+func TestDiffReportsSchemasOfPackageThatNoLongerRegisters(t *testing.T) {
+	targetDir := writeCheckFixture(t)
+	require.NoError(t, Run(BuilderArgs{TargetDir: targetDir}))
+	base := t.TempDir()
+	committed, err := os.ReadFile(filepath.Join(targetDir, "jsonschema", "Owner.json"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(base, "Owner.json"), committed, 0o644))
+	require.NoError(t, os.Remove(filepath.Join(targetDir, "schema.go")))
+
+	report, err := Diff(DiffArgs{Patterns: []string{targetDir}, Base: base})
+	require.NoError(t, err)
+	require.Len(t, report.Packages, 1)
+	require.Len(t, report.Changes, 1)
+	require.Equal(t, ChangeTypeRemoved, report.Changes[0].Kind)
+	require.Equal(t, "Owner", report.Changes[0].Type)
+	require.True(t, report.Changes[0].Breaking)
+}
+
+func TestDiffUsesDirectiveOptions(t *testing.T) {
+	targetDir := writeCheckFixture(t)
+	require.NoError(t, Run(BuilderArgs{TargetDir: targetDir}))
+	base := t.TempDir()
+	committed, err := os.ReadFile(filepath.Join(targetDir, "jsonschema", "Owner.json"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(base, "Owner.json"), committed, 0o644))
+
+	types := strings.Replace(checkFixtureTypes, "gen --pretty", "gen --strict", 1)
+	types = strings.Replace(types, "Name string", "Tags map[string]string `json:\"tags\"`\n\tName string", 1)
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "types.go"), []byte(types), 0o644))
+
+	var directives [][]string
+	args := DiffArgs{
+		Patterns: []string{targetDir},
+		Base:     base,
+		GenArgs: func(args []string) (BuilderArgs, error) {
+			directives = append(directives, args)
+			return BuilderArgs{Strict: slices.Contains(args, "--strict")}, nil
+		},
+	}
+	_, err = Diff(args)
+	require.ErrorContains(t, err, "Owner.tags: open objects (maps) cannot be expressed")
+	require.Equal(t, [][]string{{"--strict"}}, directives)
+
+	args.GenArgs = nil
+	report, err := Diff(args)
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 1)
+	require.Equal(t, ChangeRequiredPropertyAdded, report.Changes[0].Kind)
+}
@@ -0,0 +1,117 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestEnumDescriptions(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Status string
+
+const (
+	// Pending work has not started.
+	Pending Status = "pending"
+	Done    Status = "done" // Done work is finished.
+	Skipped Status = "skipped"
+)
+
+type Level int
+
+const (
+	// Low can wait.
+	Low Level = iota
+	// High needs attention now.
+	High
+)
+
+type Owner struct {
+	// Status is the state of the work.
+	Status Status                      `+"`json:\"status\"`"+`
+	Plain  Status                      `+"`json:\"plain\"`"+`
+	Level  Level                       `+"`json:\"level\"`"+`
+	Maybe  jsonschema.Nullable[Status] `+"`json:\"maybe\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithEnum(Owner{}.Status, jsonschema.EnumDescriptions()),
+	jsonschema.WithEnum(Owner{}.Plain),
+	jsonschema.WithStringerEnum(Owner{}.Level, jsonschema.EnumDescriptions()),
+	jsonschema.WithEnum(Owner{}.Maybe, jsonschema.EnumDescriptions()),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Owner")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.JSONEq(t, `{"type":"string","description":"Status is the state of the work.","anyOf":[
+		{"const":"pending","description":"Pending work has not started."},
+		{"const":"done","description":"Done work is finished."},
+		{"const":"skipped"}
+	]}`, string(got.Properties["status"]))
+	require.JSONEq(t, `{"type":"string","enum":["pending","done","skipped"]}`, string(got.Properties["plain"]))
+	require.JSONEq(t, `{"type":"string","anyOf":[
+		{"const":"Low","description":"Low can wait."},
+		{"const":"High","description":"High needs attention now."}
+	]}`, string(got.Properties["level"]))
+	require.JSONEq(t, `{"anyOf":[{"type":"string","anyOf":[
+		{"const":"pending","description":"Pending work has not started."},
+		{"const":"done","description":"Done work is finished."},
+		{"const":"skipped"}
+	]},{"type":"null"}]}`, string(got.Properties["maybe"]))
+	require.NoError(t, builder.checkValue(schema, json.RawMessage(`{"status":"done","plain":"done","level":"High","maybe":null}`)))
+	require.ErrorContains(t, builder.checkValue(schema, json.RawMessage(`{"status":"later","plain":"done","level":"High","maybe":null}`)), "does not match the schema")
+
+	targetDir = t.TempDir()
+	_, err = builder.writeSchema(syntax.TypeID{PkgPath: pkgs[0].PkgPath, TypeName: "Owner"}, DialectGemini, targetDir, false)
+	require.NoError(t, err)
+	generated, err := os.ReadFile(filepath.Join(targetDir, "Owner.gemini.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(generated, &got))
+	require.JSONEq(t, `{"type":"string",
+		"description":"Status is the state of the work.\n\nValues:\n- pending: Pending work has not started.\n- done: Done work is finished.",
+		"enum":["pending","done","skipped"]}`, string(got.Properties["status"]))
+}
+
+func TestEnumOptionDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Status string
+
+const Pending Status = "pending"
+
+type Owner struct {
+	Status Status `+"`json:\"status\"`"+`
+}
+
+func describe() jsonschema.EnumOption { return jsonschema.EnumDescriptions() }
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema, jsonschema.WithEnum(Owner{}.Status, describe()))
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	_, err = New(pkgs[0])
+	require.ErrorContains(t, err, "unknown enum option describe")
+}
@@ -0,0 +1,238 @@
+package builder
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dave/dst/decorator"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+// EnumHelper holds the IsValid, <Enum>Values and Parse<Enum> helpers
+// generated for an enum declared in this package.
+type EnumHelper struct {
+	TypeName string
+	// Consts holds one constant per distinct value, in declaration order.
+	Consts      []string
+	ParseCases  []EnumParseCase
+	ParseFunc   bool
+	ParseSource string
+}
+
+// ConstList joins the constants for a case clause or slice literal.
+func (h EnumHelper) ConstList() string {
+	return strings.Join(h.Consts, ", ")
+}
+
+// EnumParseCase maps a string accepted by Parse<Enum>, as a quoted Go
+// literal, to its constant.
+type EnumParseCase struct {
+	Text  string
+	Const string
+}
+
+// enumHelperSource records an enum rendered by WithEnum or WithStringerEnum,
+// with the WithStringerEnum wire names of its constants if any field uses
+// them.
+type enumHelperSource struct {
+	Values []syntax.ValueSpec
+	Names  map[string]string
+}
+
+// addEnumHelperSource notes that a field rendered enum, so that its helpers
+// are generated. names holds WithStringerEnum wire names by constant.
+func (s SchemaBuilder) addEnumHelperSource(enum syntax.TypeID, values []syntax.ValueSpec, names map[string]string) {
+	if enum.PkgPath != s.Scan.Pkg.PkgPath {
+		return
+	}
+	source, ok := s.enumHelperSources[enum.TypeName]
+	if !ok {
+		source = &enumHelperSource{Values: values}
+		s.enumHelperSources[enum.TypeName] = source
+	}
+	if names != nil {
+		source.Names = names
+	}
+}
+
+// collectEnumHelpers decides the helpers of every enum registered with
+// NewEnumType or rendered through a field option.
+func (s *SchemaBuilder) collectEnumHelpers() error {
+	for typeName, enum := range s.Scan.Constants {
+		if _, ok := s.enumHelperSources[typeName]; !ok && enum != nil && len(enum.Values) > 0 {
+			s.enumHelperSources[typeName] = &enumHelperSource{Values: enum.Values}
+		}
+	}
+	typeNames := make([]string, 0, len(s.enumHelperSources))
+	for typeName := range s.enumHelperSources {
+		typeNames = append(typeNames, typeName)
+	}
+	slices.Sort(typeNames)
+	if len(typeNames) == 0 {
+		return nil
+	}
+	declared, err := packageDecls(s.Scan.Pkg)
+	if err != nil {
+		return err
+	}
+	scope := s.Scan.Pkg.Types.Scope()
+	for _, typeName := range typeNames {
+		obj, ok := scope.Lookup(typeName).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		helper, err := s.enumHelper(obj, s.enumHelperSources[typeName], declared)
+		if err != nil {
+			return err
+		}
+		s.EnumHelpers = append(s.EnumHelpers, helper)
+	}
+	return nil
+}
+
+func (s SchemaBuilder) enumHelper(obj *types.TypeName, source *enumHelperSource, declared map[string]token.Position) (EnumHelper, error) {
+	var (
+		typeName = obj.Name()
+		helper   = EnumHelper{TypeName: typeName}
+		scope    = s.Scan.Pkg.Types.Scope()
+		values   = map[string]bool{}
+		texts    = map[string]bool{}
+	)
+	for _, v := range source.Values {
+		ident := v.Value().Names[0].Name
+		c, ok := scope.Lookup(ident).(*types.Const)
+		if !ok {
+			return helper, fmt.Errorf("enum %s: constant %s not found", typeName, ident)
+		}
+		value := c.Val().ExactString()
+		if !values[value] {
+			values[value] = true
+			helper.Consts = append(helper.Consts, ident)
+		}
+		var text string
+		switch {
+		case c.Val().Kind() == constant.String:
+			text = constant.StringVal(c.Val())
+			helper.ParseSource = "value"
+		case source.Names != nil:
+			text = source.Names[ident]
+		default:
+			text = value
+		}
+		if !texts[text] {
+			texts[text] = true
+			helper.ParseCases = append(helper.ParseCases, EnumParseCase{Text: strconv.Quote(text), Const: ident})
+		}
+	}
+	switch {
+	case helper.ParseSource != "":
+	case source.Names != nil:
+		helper.ParseSource = "WithStringerEnum name"
+	default:
+		helper.ParseSource = "decimal value"
+	}
+
+	if pos, ok := declared[typeName+".IsValid"]; ok {
+		return helper, fmt.Errorf("enum %s needs the method %s.IsValid for its generated helper, but it is already declared at %s", typeName, typeName, pos)
+	}
+	valuesFunc := typeName + "Values"
+	if pos, ok := declared[valuesFunc]; ok {
+		return helper, fmt.Errorf("enum %s needs the name %s for its generated helper, but it is already declared at %s", typeName, valuesFunc, pos)
+	}
+	// An enum with a schema method of its own leaves Parse<Enum> to the
+	// Parse<Type>([]byte) generated for schema types.
+	if !s.isSchemaReceiver(typeName) {
+		parseFunc := "Parse" + typeName
+		if pos, ok := declared[parseFunc]; ok {
+			return helper, fmt.Errorf("enum %s needs the name %s for its generated helper, but it is already declared at %s", typeName, parseFunc, pos)
+		}
+		helper.ParseFunc = true
+	}
+	return helper, nil
+}
+
+// packageDecls returns the position of every top-level declaration in the
+// Go files of pkg's directory, keyed by name, or by "Type.Method" for
+// methods. Test files and files excluded by build constraints are parsed
+// too, since generated helpers must not collide with them either; the
+// generated jsonschema_gen.go is not.
+func packageDecls(pkg *decorator.Package) (map[string]token.Position, error) {
+	declared := map[string]token.Position{}
+	if len(pkg.GoFiles) == 0 {
+		return declared, nil
+	}
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(pkg.GoFiles[0]), "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if filepath.Base(file) == goCodeFile {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		if f.Name.Name != pkg.Name {
+			// An external test package shares the directory, not the scope.
+			continue
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				name := decl.Name.Name
+				if decl.Recv != nil && len(decl.Recv.List) > 0 {
+					name = receiverName(decl.Recv.List[0].Type) + "." + name
+				}
+				declared[name] = fset.Position(decl.Name.Pos())
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						declared[spec.Name.Name] = fset.Position(spec.Name.Pos())
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							declared[name.Name] = fset.Position(name.Pos())
+						}
+					}
+				}
+			}
+		}
+	}
+	return declared, nil
+}
+
+// receiverName returns the name of the type of a method receiver.
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	case *ast.ParenExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+func (s SchemaBuilder) isSchemaReceiver(typeName string) bool {
+	for _, m := range s.SchemaMethods() {
+		if m.Receiver.TypeName == typeName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package builder
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestEnumHelpers(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Status string
+
+const (
+	Open   Status = "open"
+	Closed Status = "closed"
+)
+
+type Level int
+
+const (
+	Low Level = iota // jsonschema:"low"
+	High
+	Max = High // jsonschema:"max"
+)
+
+type Count int
+
+const (
+	One Count = 1
+	Two Count = 2
+)
+
+type Kind string
+
+const Plain Kind = "plain"
+
+func (Kind) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Kind.Schema)
+
+type Owner struct {
+	Status Status `+"`json:\"status\"`"+`
+	Level  Level  `+"`json:\"level\"`"+`
+	Count  Count  `+"`json:\"count\"`"+`
+	Kind   Kind   `+"`json:\"kind\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithEnum(Owner{}.Status),
+	jsonschema.WithStringerEnum(Owner{}.Level),
+	jsonschema.WithEnum(Owner{}.Count),
+	jsonschema.WithEnum(Owner{}.Kind),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	data, err := builder.renderGoCode()
+	require.NoError(t, err)
+	code := string(data)
+
+	require.Contains(t, code, "func StatusValues() []Status {\n\treturn []Status{Open, Closed}\n}")
+	require.Contains(t, code, "// ParseStatus returns the Status constant whose value is s.")
+	require.Contains(t, code, "case \"closed\":\n\t\treturn Closed, nil")
+
+	// Max shares High's value, so it is listed once but parsed by name.
+	require.Contains(t, code, "func (v Level) IsValid() bool {\n\tswitch v {\n\tcase Low, High:")
+	require.Contains(t, code, "// ParseLevel returns the Level constant whose WithStringerEnum name is s.")
+	require.Contains(t, code, "case \"High\":\n\t\treturn High, nil\n\tcase \"max\":\n\t\treturn Max, nil")
+
+	require.Contains(t, code, "func (v Count) IsValid() bool")
+	require.Contains(t, code, "case \"2\":\n\t\treturn Two, nil")
+
+	// Kind has a schema method of its own, which owns the ParseKind name.
+	require.Contains(t, code, "func KindValues() []Kind")
+	require.NotContains(t, code, "func ParseKind(s string)")
+}
+
+func TestEnumHelperNameCollisionsFailGeneration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		file      string
+		source    string
+		wantError string
+	}{
+		{
+			name:      "IsValid method",
+			file:      "status.go",
+			source:    "func (s Status) IsValid() bool { return s != \"\" }\n",
+			wantError: "enum Status needs the method Status.IsValid for its generated helper, but it is already declared at ",
+		},
+		{
+			name:      "Values function",
+			file:      "status.go",
+			source:    "func StatusValues() []Status { return nil }\n",
+			wantError: "enum Status needs the name StatusValues for its generated helper, but it is already declared at ",
+		},
+		{
+			name:      "Parse function",
+			file:      "status.go",
+			source:    "func ParseStatus(s string) (Status, error) { return Status(s), nil }\n",
+			wantError: "enum Status needs the name ParseStatus for its generated helper, but it is already declared at ",
+		},
+		{
+			name:      "IsValid method in a test file",
+			file:      "status_test.go",
+			source:    "func (s *Status) IsValid() bool { return *s != \"\" }\n",
+			wantError: "enum Status needs the method Status.IsValid for its generated helper, but it is already declared at ",
+		},
+		{
+			name:      "Values function behind another build tag",
+			file:      "status_windows.go",
+			source:    "func StatusValues() []Status { return nil }\n",
+			wantError: "enum Status needs the name StatusValues for its generated helper, but it is already declared at ",
+		},
+		{
+			name:      "Parse function behind a build constraint",
+			file:      "status_extra.go",
+			source:    "//go:build extra\n\npackage fixture\n\nfunc ParseStatus(s string) (Status, error) { return Status(s), nil }\n",
+			wantError: "enum Status needs the name ParseStatus for its generated helper, but it is already declared at ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			targetDir := writeAnnotationsFixture(t, `
+type Status string
+
+const (
+	Open   Status = "open"
+	Closed Status = "closed"
+)
+
+type Owner struct {
+	Status Status `+"`json:\"status\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema, jsonschema.WithEnum(Owner{}.Status))
+`)
+			source := tt.source
+			if !strings.HasPrefix(source, "//go:build") {
+				source = "package fixture\n\n" + source
+			}
+			writeFixtureFiles(t, targetDir, map[string]string{tt.file: source})
+			pkgs, err := syntax.Load(targetDir)
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+
+			_, err = New(pkgs[0])
+			require.ErrorContains(t, err, tt.wantError+filepath.Join(targetDir, tt.file))
+		})
+	}
+}
@@ -0,0 +1,497 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	santhosh "github.com/santhosh-tekuri/jsonschema/v6"
+	genjsonschema "github.com/tylergannon/go-gen-jsonschema"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+// errAbsent marks an absent Optional value, which its struct omits.
+var errAbsent = errors.New("absent Optional value")
+
+// encodeValue encodes a value expression of WithExamples or WithExample as
+// JSON. Only constants and composite literals built from them are
+// evaluated, and only where their JSON form follows from the value alone:
+// anything whose encoding depends on code the generator would have to
+// reproduce, such as a MarshalJSON method, an interface's discriminator or a
+// json tag option, is rejected. owner and field name the field of a
+// WithExamples value; both are empty for a type-level example.
+func (s SchemaBuilder) encodeValue(expr syntax.Expr, owner, field string) (json.RawMessage, error) {
+	pkg := expr.Pkg()
+	astExpr, ok := pkg.Decorator.Map.Ast.Nodes[expr.Expr()].(ast.Expr)
+	if !ok {
+		return nil, fmt.Errorf("example value at %s cannot be evaluated", expr.Position())
+	}
+	var (
+		buf  bytes.Buffer
+		enc  = valueEncoder{info: pkg.TypesInfo, buf: &buf, builder: s}
+		slot = pkg.TypesInfo.TypeOf(astExpr)
+	)
+	if owner != "" {
+		if _, ok := s.stringerFields[owner][field]; ok {
+			return nil, fmt.Errorf("example value at %s: WithStringerEnum fields cannot have examples; their JSON names come from the generated MarshalText", expr.Position())
+		}
+		if st, ok := derefStruct(s.Scan.Pkg.Types.Scope().Lookup(owner).Type()); ok {
+			if i := fieldIndex(st, field); i >= 0 {
+				slot = st.Field(i).Type()
+			}
+		}
+	}
+	if err := enc.encodeIn(slot, astExpr); err != nil {
+		if errors.Is(err, errAbsent) {
+			err = errors.New("an absent Optional value is not an example")
+		}
+		return nil, fmt.Errorf("example value at %s: %w", expr.Position(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+type valueEncoder struct {
+	info    *types.Info
+	buf     *bytes.Buffer
+	builder SchemaBuilder
+}
+
+// encodeIn encodes expr held in a slot of type slot: a field, an element, a
+// map value or the value of an Optional or Nullable. A value held in an
+// interface is written by the generated marshaler of the interface, so it
+// cannot be evaluated.
+func (e valueEncoder) encodeIn(slot types.Type, expr ast.Expr) error {
+	if types.IsInterface(slot) {
+		return fmt.Errorf("%s is held in interface %s; values of interfaces cannot be evaluated during generation", types.ExprString(expr), slot)
+	}
+	return e.encode(expr)
+}
+
+func (e valueEncoder) encode(expr ast.Expr) error {
+	expr = ast.Unparen(expr)
+	tv, ok := e.info.Types[expr]
+	if !ok {
+		return fmt.Errorf("%s has no type information", types.ExprString(expr))
+	}
+	switch {
+	case tv.Value != nil:
+		if err := checkNoMarshaler(tv.Type); err != nil {
+			return err
+		}
+		return e.constant(tv.Value)
+	case tv.IsNil():
+		e.buf.WriteString("null")
+		return nil
+	}
+	switch node := expr.(type) {
+	case *ast.UnaryExpr:
+		if lit, ok := node.X.(*ast.CompositeLit); ok && node.Op.String() == "&" {
+			return e.composite(lit)
+		}
+	case *ast.CompositeLit:
+		return e.composite(node)
+	}
+	return fmt.Errorf("%s is not a constant or composite literal", types.ExprString(expr))
+}
+
+func (e valueEncoder) constant(value constant.Value) error {
+	switch value.Kind() {
+	case constant.String:
+		data, _ := json.Marshal(constant.StringVal(value))
+		e.buf.Write(data)
+	case constant.Bool:
+		e.buf.WriteString(strconv.FormatBool(constant.BoolVal(value)))
+	case constant.Int:
+		e.buf.WriteString(value.ExactString())
+	case constant.Float:
+		f, _ := constant.Float64Val(value)
+		data, err := json.Marshal(f)
+		if err != nil {
+			return err
+		}
+		e.buf.Write(data)
+	default:
+		return fmt.Errorf("constant %s cannot be encoded as JSON", value)
+	}
+	return nil
+}
+
+func (e valueEncoder) composite(lit *ast.CompositeLit) error {
+	typ := e.info.TypeOf(lit)
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	if kind, ok := wrapperKind(typ); ok {
+		return e.wrapper(lit, kind)
+	}
+	if err := checkNoMarshaler(typ); err != nil {
+		return err
+	}
+	switch under := typ.Underlying().(type) {
+	case *types.Struct:
+		return e.structValue(typ, under, literalValues(lit, under))
+	case *types.Slice, *types.Array:
+		elem := under.(interface{ Elem() types.Type }).Elem()
+		e.buf.WriteByte('[')
+		for i, elt := range lit.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				return errors.New("indexed array elements are not supported")
+			}
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			if err := e.encodeIn(elem, elt); err != nil {
+				return err
+			}
+		}
+		if arr, ok := under.(*types.Array); ok {
+			for i := int64(len(lit.Elts)); i < arr.Len(); i++ {
+				if i > 0 {
+					e.buf.WriteByte(',')
+				}
+				if err := e.zero(arr.Elem()); err != nil {
+					return err
+				}
+			}
+		}
+		e.buf.WriteByte(']')
+		return nil
+	case *types.Map:
+		return e.mapLiteral(lit, under)
+	default:
+		return fmt.Errorf("composite literal of %s cannot be encoded", typ)
+	}
+}
+
+// literalValues maps the fields of st that a struct literal sets, by index,
+// to their values.
+func literalValues(lit *ast.CompositeLit, st *types.Struct) map[int]ast.Expr {
+	values := map[int]ast.Expr{}
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			values[fieldIndex(st, kv.Key.(*ast.Ident).Name)] = kv.Value
+		} else {
+			values[i] = elt
+		}
+	}
+	return values
+}
+
+// structValue writes a struct of type typ, whose literal sets values, as an
+// object of its exported fields under their json names, in declaration
+// order. Fields the literal leaves out are written as their zero values.
+func (e valueEncoder) structValue(typ types.Type, st *types.Struct, values map[int]ast.Expr) error {
+	e.buf.WriteByte('{')
+	var wrote bool
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		name, err := e.memberName(typ, field, st.Tag(i))
+		if err != nil {
+			return fmt.Errorf("field %s of %s: %w", field.Name(), typ, err)
+		} else if name == "" {
+			continue
+		}
+		var member bytes.Buffer
+		sub := e
+		sub.buf = &member
+		if value, ok := values[i]; ok {
+			err = sub.encodeIn(field.Type(), value)
+		} else if types.IsInterface(field.Type()) {
+			err = errors.New("values of interfaces cannot be evaluated during generation")
+		} else {
+			err = sub.zero(field.Type())
+		}
+		if errors.Is(err, errAbsent) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("field %s of %s: %w", field.Name(), typ, err)
+		}
+		if wrote {
+			e.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		e.buf.Write(key)
+		e.buf.WriteByte(':')
+		e.buf.Write(member.Bytes())
+		wrote = true
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+// memberName returns the name field of struct type typ is written under, or
+// "" if it is not written. Fields whose presence or form depends on more
+// than their value are rejected: embedded fields, WithStringerEnum fields
+// and fields with json tag options, other than the omitzero that Optional
+// fields require.
+func (e valueEncoder) memberName(typ types.Type, field *types.Var, tag string) (string, error) {
+	if field.Embedded() {
+		return "", errors.New("embedded fields cannot be evaluated during generation")
+	}
+	if !field.Exported() {
+		return "", nil
+	}
+	name, opts, hasOpts := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	_, wrapper := wrapperKind(field.Type())
+	switch {
+	case name == "-" && !hasOpts:
+		return "", nil
+	case opts != "" && !(wrapper && opts == "omitzero"):
+		return "", fmt.Errorf("json tag option %q cannot be evaluated during generation", opts)
+	}
+	if named, ok := types.Unalias(typ).(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == e.builder.Scan.Pkg.PkgPath {
+		if _, ok := e.builder.stringerFields[named.Obj().Name()][field.Name()]; ok {
+			return "", errors.New("WithStringerEnum fields cannot be evaluated during generation")
+		}
+	}
+	if name == "" {
+		name = field.Name()
+	}
+	return name, nil
+}
+
+func (e valueEncoder) mapLiteral(lit *ast.CompositeLit, typ *types.Map) error {
+	type entry struct {
+		key   string
+		value []byte
+	}
+	var entries []entry
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return errors.New("map literal elements must be key: value pairs")
+		}
+		tv := e.info.Types[ast.Unparen(kv.Key)]
+		if tv.Value == nil || tv.Value.Kind() != constant.String {
+			return fmt.Errorf("map key %s must be a string constant", types.ExprString(kv.Key))
+		}
+		if err := checkNoMarshaler(tv.Type); err != nil {
+			return err
+		}
+		var value bytes.Buffer
+		sub := e
+		sub.buf = &value
+		if err := sub.encodeIn(typ.Elem(), kv.Value); err != nil {
+			return err
+		}
+		entries = append(entries, entry{constant.StringVal(tv.Value), value.Bytes()})
+	}
+	// encoding/json sorts map keys.
+	slices.SortFunc(entries, func(a, b entry) int { return strings.Compare(a.key, b.key) })
+	e.buf.WriteByte('{')
+	for i, entry := range entries {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(entry.key)
+		e.buf.Write(key)
+		e.buf.WriteByte(':')
+		e.buf.Write(entry.value)
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+// wrapper encodes an Optional or Nullable literal from its Present and Value
+// fields.
+func (e valueEncoder) wrapper(lit *ast.CompositeLit, kind syntax.WrapperKind) error {
+	var (
+		present bool
+		value   ast.Expr
+	)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return fmt.Errorf("%s literals must use field names", kind)
+		}
+		switch kv.Key.(*ast.Ident).Name {
+		case "Present":
+			tv := e.info.Types[ast.Unparen(kv.Value)]
+			if tv.Value == nil || tv.Value.Kind() != constant.Bool {
+				return errors.New("Present must be a boolean constant")
+			}
+			present = constant.BoolVal(tv.Value)
+		case "Value":
+			value = kv.Value
+		}
+	}
+	elem := e.info.TypeOf(lit).(*types.Named).TypeArgs().At(0)
+	switch {
+	case !present && kind == syntax.WrapperOptional:
+		return errAbsent
+	case !present:
+		e.buf.WriteString("null")
+		return nil
+	case value == nil:
+		return e.zero(elem)
+	default:
+		return e.encodeIn(elem, value)
+	}
+}
+
+// zero writes the encoding of typ's zero value.
+func (e valueEncoder) zero(typ types.Type) error {
+	if kind, ok := wrapperKind(typ); ok {
+		if kind == syntax.WrapperOptional {
+			return errAbsent
+		}
+		e.buf.WriteString("null")
+		return nil
+	}
+	if err := checkNoMarshaler(typ); err != nil {
+		return err
+	}
+	switch under := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case under.Info()&types.IsString != 0:
+			e.buf.WriteString(`""`)
+		case under.Info()&types.IsBoolean != 0:
+			e.buf.WriteString("false")
+		default:
+			e.buf.WriteString("0")
+		}
+	case *types.Struct:
+		return e.structValue(typ, under, nil)
+	case *types.Array:
+		e.buf.WriteByte('[')
+		for i := range under.Len() {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			if err := e.zero(under.Elem()); err != nil {
+				return err
+			}
+		}
+		e.buf.WriteByte(']')
+	default:
+		e.buf.WriteString("null")
+	}
+	return nil
+}
+
+// checkNoMarshaler rejects types that encode themselves, whose JSON form
+// cannot be known without running their methods.
+func checkNoMarshaler(typ types.Type) error {
+	for _, method := range []string{"MarshalJSON", "MarshalText"} {
+		obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, method)
+		if _, ok := obj.(*types.Func); ok {
+			return fmt.Errorf("%s implements %s, so its JSON form cannot be evaluated during generation", typ, method)
+		}
+	}
+	return nil
+}
+
+func wrapperKind(typ types.Type) (syntax.WrapperKind, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != syntax.SchemaPackagePath {
+		return syntax.WrapperNone, false
+	}
+	switch named.Obj().Name() {
+	case "Optional":
+		return syntax.WrapperOptional, true
+	case "Nullable":
+		return syntax.WrapperNullable, true
+	}
+	return syntax.WrapperNone, false
+}
+
+func derefStruct(typ types.Type) (*types.Struct, bool) {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	st, ok := typ.Underlying().(*types.Struct)
+	return st, ok
+}
+
+func fieldIndex(st *types.Struct, name string) int {
+	for i := range st.NumFields() {
+		if st.Field(i).Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// defaultValue reads the text of a default= tag option as a value of the
+// scalar schema.
+func defaultValue(schema JSONSchema, text string) (json.RawMessage, error) {
+	var (
+		value any
+		kind  string
+		err   error
+	)
+	switch node := schema.(type) {
+	case PropertyNode[string]:
+		value, kind = text, node.Typ
+	case PropertyNode[int]:
+		kind = node.Typ
+		value, err = strconv.ParseInt(text, 10, 64)
+	case PropertyNode[float64]:
+		kind = node.Typ
+		value, err = strconv.ParseFloat(text, 64)
+	case PropertyNode[bool]:
+		kind = node.Typ
+		value, err = strconv.ParseBool(text)
+	default:
+		return nil, fmt.Errorf("jsonschema tag default does not apply to %s schemas; use WithExamples to document other values", schemaKind(node))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jsonschema tag default %q is not a valid %s", text, kind)
+	}
+	return json.Marshal(value)
+}
+
+// checkValue validates value against schema, so that a default or example
+// that the generated validator would reject fails generation instead.
+func (s SchemaBuilder) checkValue(schema JSONSchema, value json.RawMessage) error {
+	data, compiled, err := s.compileSchema(schema)
+	if err != nil {
+		return err
+	}
+	inst, err := santhosh.UnmarshalJSON(bytes.NewReader(value))
+	if err != nil {
+		return err
+	}
+	if err = compiled.Validate(inst); err != nil {
+		return fmt.Errorf("%s does not match the schema: %s", value, genjsonschema.FormatValidationError(genjsonschema.NewValidationError(err, data)))
+	}
+	return nil
+}
+
+// compileSchema encodes schema as a standalone document, with the $defs its
+// refs need, and compiles it the way generated code does.
+func (s SchemaBuilder) compileSchema(schema JSONSchema) (json.RawMessage, *santhosh.Schema, error) {
+	var doc json.Marshaler = schema
+	defs := map[string]JSONSchema{}
+	s.collectRefDefs(schema, defs)
+	if len(defs) > 0 {
+		doc = RootSchema{Root: schema, Defs: defs}
+	}
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	parsed, err := santhosh.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	c := santhosh.NewCompiler()
+	c.AssertFormat()
+	if err = c.AddResource("value.json", parsed); err != nil {
+		return nil, nil, err
+	}
+	compiled, err := c.Compile("value.json")
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, compiled, nil
+}
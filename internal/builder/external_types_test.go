@@ -0,0 +1,154 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	santhosh "github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRendersTypesFromImportedPackages(t *testing.T) {
+	root := writeExternalTypesFixture(t, "")
+	var warnings bytes.Buffer
+	require.NoError(t, Run(BuilderArgs{TargetDir: root, Warnings: &warnings}))
+	require.Empty(t, warnings.String())
+
+	order, err := os.ReadFile(filepath.Join(root, "jsonschema", "Order.json"))
+	require.NoError(t, err)
+	money := `{
+		"type": "object",
+		"description": "Money is an amount in minor units.",
+		"properties": {
+			"cents": {"type": "integer"},
+			"currency": {"type": "string"}
+		},
+		"required": ["cents", "currency"],
+		"additionalProperties": false
+	}`
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"totals": {"type": "object", "additionalProperties": `+money+`},
+			"byCurrency": {"type": "object", "additionalProperties": {"type": "integer"}},
+			"due": {"type": "string", "format": "date-time"}
+		},
+		"required": ["totals", "byCurrency", "due"],
+		"additionalProperties": false
+	}`, string(order))
+}
+
+func TestRunWarnsAboutUnresolvedTypes(t *testing.T) {
+	root := writeExternalTypesFixture(t, "\tTracking missing.Number `json:\"tracking\"`\n")
+	var warnings bytes.Buffer
+	require.NoError(t, Run(BuilderArgs{TargetDir: root, Warnings: &warnings}))
+
+	fieldPos := filepath.Join(root, "types.go") + ":14:11"
+	require.Equal(t,
+		"warning: "+fieldPos+": type example.com/missing.Number could not be resolved; its schema accepts any value\n",
+		warnings.String())
+
+	order, err := os.ReadFile(filepath.Join(root, "jsonschema", "Order.json"))
+	require.NoError(t, err)
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(order, &schema))
+	require.JSONEq(t, `{}`, string(schema.Properties["tracking"]))
+
+	doc, err := santhosh.UnmarshalJSON(bytes.NewReader(order))
+	require.NoError(t, err)
+	c := santhosh.NewCompiler()
+	require.NoError(t, c.AddResource("Order.json", doc))
+	compiled, err := c.Compile("Order.json")
+	require.NoError(t, err)
+	for _, tracking := range []string{`{"carrier":"ups","id":7}`, `"1Z999"`, `[1,2]`} {
+		inst, err := santhosh.UnmarshalJSON(strings.NewReader(`{"totals":{},"byCurrency":{},"due":"2024-01-02T03:04:05Z","tracking":` + tracking + `}`))
+		require.NoError(t, err)
+		require.NoError(t, compiled.Validate(inst), tracking)
+	}
+}
+
+func TestCheckReportsWarnings(t *testing.T) {
+	root := writeExternalTypesFixture(t, "\tTracking missing.Number `json:\"tracking\"`\n")
+	require.NoError(t, Run(BuilderArgs{TargetDir: root, Warnings: io.Discard}))
+	// The directive is split so that go generate does not run it in this package.
+	directive := "//go:" + "generate go tool gen-jsonschema\n\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "doc.go"), []byte(directive+"package external\n"), 0o644))
+
+	report, err := Check(CheckArgs{
+		Patterns: []string{root},
+		GenArgs:  func([]string) (BuilderArgs, error) { return BuilderArgs{}, nil },
+	})
+	require.NoError(t, err)
+	require.Empty(t, report.Drift)
+	require.Len(t, report.Warnings, 1)
+	require.Contains(t, report.Warnings[0], "type example.com/missing.Number could not be resolved")
+
+	var text strings.Builder
+	require.NoError(t, report.WriteText(&text))
+	require.Contains(t, text.String(), "warning: "+report.Warnings[0]+"\n")
+}
+
+// writeExternalTypesFixture writes a package whose Order type uses types
+// declared in its domain subpackage only through maps, so nothing else leads
+// the scanner to them. The domain package registers nothing itself. extraFields are appended to Order.
+func writeExternalTypesFixture(t *testing.T, extraFields string) string {
+	t.Helper()
+
+	files := map[string]string{
+		"types.go": `package external
+
+import (
+	"time"
+
+	"` + fixturePath + `/domain"
+	missing "example.com/missing"
+)
+
+type Order struct {
+	Totals     map[string]domain.Money  ` + "`json:\"totals\"`" + `
+	ByCurrency map[domain.Currency]int  ` + "`json:\"byCurrency\"`" + `
+	Due        time.Time                ` + "`json:\"due\"`" + `
+` + extraFields + `}
+`,
+		"schema.go": `//go:build jsonschema
+
+package external
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Order) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Order.Schema)
+`,
+		"domain/money.go": `package domain
+
+// Money is an amount in minor units.
+type Money struct {
+	Cents    int      ` + "`json:\"cents\"`" + `
+	Currency Currency ` + "`json:\"currency\"`" + `
+}
+
+type Currency string
+
+const (
+	EUR Currency = "EUR"
+	USD Currency = "USD"
+)
+`,
+	}
+	if extraFields == "" {
+		files["types.go"] = strings.Replace(files["types.go"], "\tmissing \"example.com/missing\"\n", "", 1)
+	}
+	return writeFixture(t, "external_", files)
+}
@@ -0,0 +1,48 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fixturePath in the contents of fixture files is replaced with the import
+// path of the directory they are written to.
+const fixturePath = "{{fixture}}"
+
+// writeFixture writes files, keyed by slash-separated path, to a new directory
+// under testfixtures whose name starts with prefix. The directory is removed
+// when the test ends.
+func writeFixture(t *testing.T, prefix string, files map[string]string) string {
+	t.Helper()
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	root, err := os.MkdirTemp(filepath.Join(cwd, "testfixtures"), prefix)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(root))
+	})
+	writeFixtureFiles(t, root, files)
+	return root
+}
+
+// writeFixtureFiles adds files to, or replaces them in, the fixture at root.
+func writeFixtureFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	importPath := fixtureImportPath(root)
+	for name, content := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(strings.ReplaceAll(content, fixturePath, importPath)), 0o644))
+	}
+}
+
+// fixtureImportPath returns the import path of the fixture at root.
+func fixtureImportPath(root string) string {
+	return "github.com/tylergannon/go-gen-jsonschema/internal/builder/testfixtures/" + filepath.Base(root)
+}
@@ -0,0 +1,77 @@
+package builder
+
+import (
+	"strings"
+
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+// stringFormats lists the formats accepted by the format= tag option, each
+// with the prose that replaces it for dialects whose providers ignore
+// "format". The generated validators assert every one of them.
+var stringFormats = map[string]string{
+	"date-time":     `an RFC3339 formatted date-time string (e.g., "2006-01-02T15:04:05Z07:00")`,
+	"date":          `an RFC3339 full-date string (e.g., "2006-01-02")`,
+	"time":          `an RFC3339 full-time string (e.g., "15:04:05Z07:00")`,
+	"duration":      `an ISO 8601 duration string (e.g., "PT1H30M")`,
+	"email":         `an email address (e.g., "user@example.com")`,
+	"hostname":      `a DNS hostname (e.g., "api.example.com")`,
+	"ipv4":          `an IPv4 address (e.g., "192.0.2.1")`,
+	"ipv6":          `an IPv6 address (e.g., "2001:db8::1")`,
+	"uri":           `an absolute URI (e.g., "https://example.com/path")`,
+	"uri-reference": `a URI or relative reference (e.g., "/path?q=1")`,
+	"uuid":          `a UUID string (e.g., "f47ac10b-58cc-4372-a567-0e02b2c3d479")`,
+}
+
+// wellKnownType is the fixed schema of a type listed by
+// syntax.IsWellKnownType.
+type wellKnownType struct {
+	typ string
+	// formats holds the string format; several mean any one of them.
+	formats []string
+	// description is always added, for types whose JSON form is not
+	// described by a format.
+	description string
+}
+
+var wellKnownTypes = map[syntax.TypeID]wellKnownType{
+	{PkgPath: "time", TypeName: "Time"}:                   {typ: "string", formats: []string{"date-time"}},
+	{PkgPath: "net/netip", TypeName: "Addr"}:              {typ: "string", formats: []string{"ipv4", "ipv6"}},
+	{PkgPath: "github.com/google/uuid", TypeName: "UUID"}: {typ: "string", formats: []string{"uuid"}},
+	// encoding/json writes a Duration as its integer count of nanoseconds,
+	// not as an ISO 8601 duration.
+	{PkgPath: "time", TypeName: "Duration"}: {typ: "integer", description: "A duration in nanoseconds (e.g., 1500000000 for 1.5s)."},
+}
+
+// renderWellKnownType returns the schema of a well-known type, or false when
+// pkgPath.typeName is not one.
+func renderWellKnownType(t syntax.TypeExpr, pkgPath, typeName, description string) (JSONSchema, bool) {
+	known, ok := wellKnownTypes[syntax.TypeID{PkgPath: pkgPath, TypeName: typeName}]
+	if !ok {
+		return nil, false
+	}
+	if known.description != "" {
+		description = joinDescription(description, known.description)
+	}
+	if known.typ == "integer" {
+		return PropertyNode[int]{Desc: description, Typ: known.typ, TypeID_: t.ID()}, true
+	}
+	return PropertyNode[string]{Desc: description, Typ: known.typ, Formats: known.formats, TypeID_: t.ID()}, true
+}
+
+// formatDescription folds formats into description, for dialects that drop
+// the "format" keyword.
+func formatDescription(description string, formats []string) string {
+	phrases := make([]string, len(formats))
+	for i, format := range formats {
+		phrases[i] = stringFormats[format]
+	}
+	return joinDescription(description, "Must be "+strings.Join(phrases, " or ")+".")
+}
+
+func joinDescription(description, suffix string) string {
+	if description == "" {
+		return suffix
+	}
+	return strings.TrimSuffix(description, ".") + ". " + suffix
+}
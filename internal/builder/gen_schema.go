@@ -1,6 +1,8 @@
 package builder
 
 import (
+	"bytes"
+	"cmp"
 	_ "embed"
 	"encoding/hex"
 	"encoding/json"
@@ -8,7 +10,6 @@ import (
 	"fmt"
 	"go/token"
 	"go/types"
-	"io"
 	"os"
 	"path/filepath"
 	"runtime/debug"
@@ -30,29 +31,40 @@ var schemasTemplate string
 
 const maxNestingDepth = 100 // This is not the JSON Schema nesting depth but recursion depth...
 const defaultSubdir = "jsonschema"
-const unsupportedRegisteredInterfaceContainer = "arrays/slices of registered interfaces are not yet supported"
+const unsupportedRegisteredInterfaceContainer = "containers of registered interfaces are supported only as struct field types"
 
 func New(pkg *decorator.Package) (SchemaBuilder, error) {
 	data, err := syntax.LoadPackage(pkg)
 	if err != nil {
 		return SchemaBuilder{}, err
 	}
-	var builder = SchemaBuilder{
+	return newFromScan(data)
+}
+
+// newFromScan maps the types registered in an already scanned package.
+func newFromScan(data syntax.ScanResult) (builder SchemaBuilder, err error) {
+	builder = SchemaBuilder{
 		Scan:              data,
 		schemas:           schemaMap{},
 		customTypes:       map[string][]InterfaceProp{},
+		stringerFields:    map[string]map[string]stringerEnumField{},
+		enumNames:         map[syntax.TypeID]string{},
+		enumHelperSources: map[string]*enumHelperSource{},
+		usedEnumNames:     map[syntax.TypeID]bool{},
 		Subdir:            defaultSubdir,
 		BuildTag:          syntax.BuildTag,
 		DiscriminatorProp: DefaultDiscriminatorPropName,
 		TypeProvidersMap:  map[string][]FieldProvider{},
 		IfaceV1:           map[string]map[string]interfaceFieldConfig{},
-		EnumV1: make(map[string]map[string]struct {
-			UseStringer bool
-		}),
-		RenderedTypes: []string{},
-		Rendered:      map[string]bool{},
-		RefTypes:      map[syntax.TypeID]bool{},
-		RefDefs:       map[string]refDef{},
+		EnumV1:            map[string]map[string]enumFieldConfig{},
+		RenderedTypes:     []string{},
+		Rendered:          map[string]bool{},
+		RefTypes:          map[syntax.TypeID]bool{},
+		RefDefs:           map[string]refDef{},
+		Recursive:         map[syntax.TypeID]bool{},
+		FieldExamples:     map[string]map[string][]syntax.Expr{},
+		TypeExamples:      map[string][]json.RawMessage{},
+		Warnings:          new([]string),
 	}
 	// First, collect providers so they're available during mapping
 	var foundNewInterfaceOpts bool
@@ -74,11 +86,12 @@ func New(pkg *decorator.Package) (SchemaBuilder, error) {
 				// so distinct types sharing a bare name are kept distinct here.
 				builder.RefTypes[recv.Concrete()] = true
 				continue
-			case "WithInterface", "WithInterfaceImpls", "WithDiscriminator", "Impl":
+			case "WithInterface", "WithInterfaceImpls", "WithDiscriminator", "Impl", "Untagged", "ExternallyTagged", "AdjacentlyTagged", "Implementations":
 				foundNewInterfaceOpts = true
 				continue
-			case "WithEnum", "WithStringerEnum":
-				// Enum options don't create providers, they're handled inline
+			case "WithEnum", "WithStringerEnum", "WithEnumName", "WithExamples", "WithExample":
+				// Enum and example options don't create providers, they're
+				// handled inline
 				continue
 			}
 			builder.TypeProvidersMap[recvName] = append(builder.TypeProvidersMap[recvName], FieldProvider{
@@ -100,7 +113,9 @@ func New(pkg *decorator.Package) (SchemaBuilder, error) {
 		return builder, fmt.Errorf("invalid configuration: cannot mix legacy NewInterfaceImpl with v1 interface options in package %s", data.Pkg.PkgPath)
 	}
 
-	// Collect v1 interface options per receiver/field and enum options
+	// Collect v1 interface options per receiver/field, enum options and
+	// examples
+	typeExamples := map[string][]syntax.Expr{}
 	applyInterfaceOpts := func(recv string, opts []syntax.SchemaMethodOptionInfo) error {
 		for _, opt := range opts {
 			switch string(opt.Kind) {
@@ -113,17 +128,26 @@ func New(pkg *decorator.Package) (SchemaBuilder, error) {
 				builder.IfaceV1[recv][opt.FieldName] = curr
 			case "WithEnum", "WithStringerEnum":
 				if builder.EnumV1[recv] == nil {
-					builder.EnumV1[recv] = make(map[string]struct {
-						UseStringer bool
-					})
+					builder.EnumV1[recv] = map[string]enumFieldConfig{}
 				}
 				if _, ok := builder.EnumV1[recv][opt.FieldName]; !ok {
-					useStringer := opt.Kind == "WithStringerEnum"
-					builder.EnumV1[recv][opt.FieldName] = struct {
-						UseStringer bool
-					}{UseStringer: useStringer}
+					builder.EnumV1[recv][opt.FieldName] = enumFieldConfig{
+						UseStringer:  opt.Kind == "WithStringerEnum",
+						Descriptions: opt.EnumDescriptions,
+					}
 				}
-
+			case "WithEnumName":
+				if previous, ok := builder.enumNames[opt.EnumConst]; ok && previous != opt.EnumName {
+					return fmt.Errorf("WithEnumName: constant %s is named both %q and %q", opt.EnumConst, previous, opt.EnumName)
+				}
+				builder.enumNames[opt.EnumConst] = opt.EnumName
+			case "WithExamples":
+				if builder.FieldExamples[recv] == nil {
+					builder.FieldExamples[recv] = map[string][]syntax.Expr{}
+				}
+				builder.FieldExamples[recv][opt.FieldName] = append(builder.FieldExamples[recv][opt.FieldName], opt.Values...)
+			case "WithExample":
+				typeExamples[recv] = append(typeExamples[recv], opt.Values...)
 			case "WithDiscriminator":
 				if builder.IfaceV1[recv] == nil {
 					builder.IfaceV1[recv] = map[string]interfaceFieldConfig{}
@@ -131,6 +155,27 @@ func New(pkg *decorator.Package) (SchemaBuilder, error) {
 				curr := builder.IfaceV1[recv][opt.FieldName]
 				curr.Disc = opt.Discriminator
 				builder.IfaceV1[recv][opt.FieldName] = curr
+			case "Untagged":
+				if builder.IfaceV1[recv] == nil {
+					builder.IfaceV1[recv] = map[string]interfaceFieldConfig{}
+				}
+				curr := builder.IfaceV1[recv][opt.FieldName]
+				curr.Untagged = true
+				builder.IfaceV1[recv][opt.FieldName] = curr
+			case "ExternallyTagged":
+				if builder.IfaceV1[recv] == nil {
+					builder.IfaceV1[recv] = map[string]interfaceFieldConfig{}
+				}
+				curr := builder.IfaceV1[recv][opt.FieldName]
+				curr.ExternallyTagged = true
+				builder.IfaceV1[recv][opt.FieldName] = curr
+			case "AdjacentlyTagged":
+				if builder.IfaceV1[recv] == nil {
+					builder.IfaceV1[recv] = map[string]interfaceFieldConfig{}
+				}
+				curr := builder.IfaceV1[recv][opt.FieldName]
+				curr.ContentPropName = opt.ContentPropName
+				builder.IfaceV1[recv][opt.FieldName] = curr
 			case "WithInterfaceImpls":
 				if builder.IfaceV1[recv] == nil {
 					builder.IfaceV1[recv] = map[string]interfaceFieldConfig{}
@@ -139,9 +184,23 @@ func New(pkg *decorator.Package) (SchemaBuilder, error) {
 				if curr.InlineImpls {
 					return fmt.Errorf("field %s.%s: cannot combine Impl(...) options with WithInterfaceImpls", recv, opt.FieldName)
 				}
+				if curr.Discovered != nil {
+					return fmt.Errorf("field %s.%s: cannot combine Implementations() with WithInterfaceImpls", recv, opt.FieldName)
+				}
 				curr.LegacyImpls = true
 				curr.Impls = slices.Clone(opt.ImplTypes)
 				builder.IfaceV1[recv][opt.FieldName] = curr
+			case "Implementations":
+				if builder.IfaceV1[recv] == nil {
+					builder.IfaceV1[recv] = map[string]interfaceFieldConfig{}
+				}
+				curr := builder.IfaceV1[recv][opt.FieldName]
+				if curr.LegacyImpls {
+					return fmt.Errorf("field %s.%s: cannot combine WithInterfaceImpls with Implementations()", recv, opt.FieldName)
+				}
+				curr.Discovered = slices.Clone(opt.ImplTypes)
+				curr.DiscoveredValues = slices.Clone(opt.WireValues)
+				builder.IfaceV1[recv][opt.FieldName] = curr
 			case "Impl":
 				if builder.IfaceV1[recv] == nil {
 					builder.IfaceV1[recv] = map[string]interfaceFieldConfig{}
@@ -185,9 +244,33 @@ func New(pkg *decorator.Package) (SchemaBuilder, error) {
 	}
 	for recv, fields := range builder.IfaceV1 {
 		for field, cfg := range fields {
+			if cfg.Discovered != nil {
+				if err := cfg.mergeDiscovered(); err != nil {
+					return builder, fmt.Errorf("field %s.%s: %w", recv, field, err)
+				}
+				fields[field] = cfg
+			}
 			if cfg.Registered && len(cfg.Impls) == 0 {
 				return builder, fmt.Errorf("field %s.%s: missing interface implementations; add Impl(...) options or WithInterfaceImpls", recv, field)
 			}
+			if cfg.Untagged && cfg.Disc != "" {
+				return builder, fmt.Errorf("field %s.%s: an Untagged interface has no discriminator property to set", recv, field)
+			}
+			if cfg.ExternallyTagged && cfg.Disc != "" {
+				return builder, fmt.Errorf("field %s.%s: an ExternallyTagged interface has no discriminator property to set", recv, field)
+			}
+			var styles int
+			for _, set := range []bool{cfg.Untagged, cfg.ExternallyTagged, cfg.ContentPropName != ""} {
+				if set {
+					styles++
+				}
+			}
+			if styles > 1 {
+				return builder, fmt.Errorf("field %s.%s: Untagged, ExternallyTagged and AdjacentlyTagged are mutually exclusive", recv, field)
+			}
+			if cfg.ContentPropName != "" && cfg.ContentPropName == cmp.Or(cfg.Disc, DefaultDiscriminatorPropName) {
+				return builder, fmt.Errorf("field %s.%s: AdjacentlyTagged content property %q is also the discriminator property", recv, field, cfg.ContentPropName)
+			}
 			if len(cfg.DiscriminatorValues) == 0 {
 				continue
 			}
@@ -240,17 +323,67 @@ func New(pkg *decorator.Package) (SchemaBuilder, error) {
 			return builder, err
 		}
 	}
+	for constID := range builder.enumNames {
+		if !builder.usedEnumNames[constID] {
+			return builder, fmt.Errorf("WithEnumName: constant %s is not a value of an integer enum rendered with WithStringerEnum", constID)
+		}
+	}
+	for _, m := range builder.SchemaMethods() {
+		if err = builder.addTypeExamples(m.Receiver, typeExamples[m.Receiver.TypeName]); err != nil {
+			return builder, err
+		}
+	}
+	if err = builder.collectEnumHelpers(); err != nil {
+		return builder, err
+	}
 
 	return builder, nil
 }
 
+// addTypeExamples encodes the WithExample values registered for t and checks
+// them against its schema.
+func (s SchemaBuilder) addTypeExamples(t syntax.TypeID, values []syntax.Expr) error {
+	if len(values) == 0 || len(s.TypeExamples[t.TypeName]) > 0 {
+		return nil
+	}
+	schema, ok := s.GetSchema(t)
+	if !ok {
+		return nil
+	}
+	for _, value := range values {
+		data, err := s.encodeValue(value, "", "")
+		if err != nil {
+			return err
+		}
+		if !s.Rendered[t.TypeName] {
+			if err = s.checkValue(schema, data); err != nil {
+				return fmt.Errorf("example value at %s: %w", value.Position(), err)
+			}
+		}
+		s.TypeExamples[t.TypeName] = append(s.TypeExamples[t.TypeName], data)
+	}
+	return nil
+}
+
 type CustomMarshaledType struct {
 	Name           string
 	InterfaceProps []InterfaceProp
+	EnumProps      []EnumProp
 	Star           string
 	Initial        string
 }
 
+// Discriminated reports whether any of the type's interface fields carries
+// a discriminator.
+func (c CustomMarshaledType) Discriminated() bool {
+	for _, prop := range c.InterfaceProps {
+		if !prop.Untagged {
+			return true
+		}
+	}
+	return false
+}
+
 type YAMLType struct {
 	Name    string
 	Initial string
@@ -262,6 +395,13 @@ type InterfaceOptionInfo struct {
 	TypeName           string
 	PkgPath            string
 	Pointer            bool
+	// MarshalCase is the type switch case the generated marshaler uses to
+	// recognize this implementation. Value implementations also accept a
+	// pointer to the value unless that pointer is registered separately.
+	MarshalCase string
+	// Schema is the implementation's standalone schema as a Go string
+	// literal, set for untagged interfaces.
+	Schema string
 }
 
 type interfaceFieldConfig struct {
@@ -271,6 +411,74 @@ type interfaceFieldConfig struct {
 	LegacyImpls         bool
 	InlineImpls         bool
 	Registered          bool
+	Untagged            bool
+	ExternallyTagged    bool
+	ContentPropName     string
+	// Discovered and DiscoveredValues are the implementations found by
+	// Implementations() and their wire values.
+	Discovered       []syntax.TypeID
+	DiscoveredValues []string
+}
+
+// mergeDiscovered registers the discovered implementations, in discovery
+// order and followed by any Impl types that were not discovered. An Impl
+// option sets the wire value, and the indirection, of a discovered type.
+func (c *interfaceFieldConfig) mergeDiscovered() error {
+	type typeKey struct{ pkgPath, typeName string }
+	explicit := make(map[typeKey]syntax.TypeID, len(c.Impls))
+	for _, impl := range c.Impls {
+		explicit[typeKey{impl.PkgPath, impl.TypeName}] = impl
+	}
+	var (
+		impls  []syntax.TypeID
+		values = make(map[syntax.TypeID]string, len(c.Discovered)+len(c.Impls))
+		owners = map[string]syntax.TypeID{}
+	)
+	add := func(impl syntax.TypeID, value string) error {
+		if other, ok := owners[value]; ok {
+			return fmt.Errorf("implementations %s and %s both have wire value %q; set one with Impl(...)", other, impl, value)
+		}
+		owners[value] = impl
+		impls = append(impls, impl)
+		values[impl] = value
+		return nil
+	}
+	for i, impl := range c.Discovered {
+		value := c.DiscoveredValues[i]
+		key := typeKey{impl.PkgPath, impl.TypeName}
+		if registered, ok := explicit[key]; ok {
+			impl, value = registered, c.DiscriminatorValues[registered]
+			delete(explicit, key)
+		}
+		if err := add(impl, value); err != nil {
+			return err
+		}
+	}
+	for _, impl := range c.Impls {
+		if _, ok := explicit[typeKey{impl.PkgPath, impl.TypeName}]; !ok {
+			continue
+		}
+		if err := add(impl, c.DiscriminatorValues[impl]); err != nil {
+			return err
+		}
+	}
+	c.Impls, c.DiscriminatorValues = impls, values
+	return nil
+}
+
+// undiscoveredImpls returns the implementations that discovery did not find.
+// Discovery has checked its types against the interface among the module's
+// packages, loaded together; a package the registering package does not
+// import is scanned on its own, so its types cannot be checked again here.
+func (c interfaceFieldConfig) undiscoveredImpls() []syntax.TypeID {
+	return slices.DeleteFunc(slices.Clone(c.Impls), func(impl syntax.TypeID) bool {
+		return slices.Contains(c.Discovered, impl)
+	})
+}
+
+type enumFieldConfig struct {
+	UseStringer  bool // WithStringerEnum was used
+	Descriptions bool // EnumDescriptions was given
 }
 
 func cloneDiscriminatorValues(values map[syntax.TypeID]string) map[syntax.TypeID]string {
@@ -303,8 +511,18 @@ type InterfaceInfo struct {
 	TypeName              string
 	PkgPath               string
 	UnmarshalerFunc       string
+	MarshalerFunc         string
 	DiscriminatorPropName string
 	Options               []InterfaceOptionInfo
+	// Untagged interfaces are decoded by matching their options' schemas,
+	// compiled into SchemasVar.
+	Untagged   bool
+	SchemasVar string
+	// ExternallyTagged and ContentPropName select the other encodings: a
+	// single property named by the discriminator, or the discriminator
+	// beside a content property holding the value.
+	ExternallyTagged bool
+	ContentPropName  string
 }
 
 func (c *CustomMarshaledType) UnmarshalJSON(data []byte) (err error) {
@@ -329,19 +547,33 @@ func (c *CustomMarshaledType) UnmarshalJSON(data []byte) (err error) {
 }
 
 type SchemaBuilder struct {
-	Scan              syntax.ScanResult
-	schemas           schemaMap
-	customTypes       map[string][]InterfaceProp
+	Scan        syntax.ScanResult
+	schemas     schemaMap
+	customTypes map[string][]InterfaceProp
+	// Fields rendered by WithStringerEnum from integer enums, by owner and
+	// Go field name.
+	stringerFields map[string]map[string]stringerEnumField
+	// enumNames holds the WithEnumName overrides by constant, and
+	// usedEnumNames the ones an enum field has rendered.
+	enumNames     map[syntax.TypeID]string
+	usedEnumNames map[syntax.TypeID]bool
+	// enumHelperSources holds the local enums that get helper methods, by
+	// type name.
+	enumHelperSources map[string]*enumHelperSource
+	EnumHelpers       []EnumHelper
 	Subdir            string
 	Pretty            bool
 	NumTestSamples    int
 	Validate          bool
 	BuildTag          string
 	UnmarshalFormats  UnmarshalFormats
+	Dialects          []Dialect
 	Imports           []string
 	SpecialTypes      []CustomMarshaledType
 	YAMLTypes         []YAMLType
 	Interfaces        []InterfaceInfo
+	StringerEnums     []StringerEnum
+	Tools             []ToolInfo
 	DiscriminatorProp string
 
 	// Field provider options per type (by receiver type name)
@@ -352,9 +584,7 @@ type SchemaBuilder struct {
 	IfaceV1 map[string]map[string]interfaceFieldConfig
 
 	// Enum options: receiver -> field -> config
-	EnumV1 map[string]map[string]struct {
-		UseStringer bool // WithStringerEnum was used
-	}
+	EnumV1 map[string]map[string]enumFieldConfig
 
 	// Types requesting rendered provider execution
 	RenderedTypes []string
@@ -365,6 +595,22 @@ type SchemaBuilder struct {
 	// Collected $defs entries, keyed by definition name, populated as
 	// AsRef()'d types are rendered at their reference sites.
 	RefDefs map[string]refDef
+	// Types found on a cycle in the type graph. Like AsRef() types they are
+	// rendered as "$ref" into "$defs"; everything else stays inlined.
+	Recursive map[syntax.TypeID]bool
+	// WithExamples values per receiver type name and Go field name.
+	FieldExamples map[string]map[string][]syntax.Expr
+	// Encoded WithExample values per receiver type name.
+	TypeExamples map[string][]json.RawMessage
+	// Warnings collects problems that did not stop rendering, such as field
+	// types that could not be resolved.
+	Warnings *[]string
+}
+
+func (s SchemaBuilder) warnf(format string, args ...any) {
+	if s.Warnings != nil {
+		*s.Warnings = append(*s.Warnings, fmt.Sprintf(format, args...))
+	}
 }
 
 func (s SchemaBuilder) GeneratesJSONUnmarshalers() bool {
@@ -393,7 +639,8 @@ func (s SchemaBuilder) registerRefDef(t syntax.TypeID, schema JSONSchema) (RefNo
 	if existing, ok := s.RefDefs[name]; ok {
 		if existing.TypeID != concrete {
 			pos, _ := s.find(concrete)
-			return RefNode{}, fmt.Errorf("AsRef definition name collision: %q is used by both %s and %s (registered at %s)", name, existing.TypeID, concrete, pos)
+			return RefNode{}, fmt.Errorf("$defs name collision: %q is used by both %s (%s) and %s (%s, declared at %s)",
+				name, existing.TypeID, s.refDefCause(existing.TypeID), concrete, s.refDefCause(concrete), pos)
 		}
 		return ref, nil
 	}
@@ -401,6 +648,15 @@ func (s SchemaBuilder) registerRefDef(t syntax.TypeID, schema JSONSchema) (RefNo
 	return ref, nil
 }
 
+// refDefCause says why t is rendered into "$defs": it was registered with
+// AsRef(), or it lies on a cycle in the type graph.
+func (s SchemaBuilder) refDefCause(t syntax.TypeID) string {
+	if s.RefTypes[t] {
+		return "registered with AsRef()"
+	}
+	return "recursive"
+}
+
 // collectRefDefs walks a rendered schema tree and gathers every "$defs"
 // entry reachable from it (transitively, since a $defs entry may itself
 // reference another AsRef()'d type), keyed by bare definition name.
@@ -418,6 +674,8 @@ func (s SchemaBuilder) collectRefDefs(schema JSONSchema, defs map[string]JSONSch
 		for _, opt := range node.Options {
 			s.collectRefDefs(opt, defs)
 		}
+	case MapNode:
+		s.collectRefDefs(node.Values, defs)
 	case NullableObjectNode:
 		s.collectRefDefs(node.Object, defs)
 	case NullableUnionNode:
@@ -436,10 +694,82 @@ func (s SchemaBuilder) collectRefDefs(schema JSONSchema, defs map[string]JSONSch
 	}
 }
 
+// rerootRefs returns a copy of schema in which every "$ref" to rootRef
+// refers to the document root instead.
+func rerootRefs(schema JSONSchema, rootRef string) JSONSchema {
+	switch node := schema.(type) {
+	case ObjectNode:
+		node.Properties = slices.Clone(node.Properties)
+		for i, prop := range node.Properties {
+			node.Properties[i].Schema = rerootRefs(prop.Schema, rootRef)
+		}
+		return node
+	case ArrayNode:
+		if node.Items != nil {
+			node.Items = rerootRefs(node.Items, rootRef)
+		}
+		return node
+	case UnionTypeNode:
+		node.Options = slices.Clone(node.Options)
+		for i, opt := range node.Options {
+			node.Options[i] = rerootRefs(opt, rootRef).(ObjectNode)
+		}
+		return node
+	case MapNode:
+		node.Values = rerootRefs(node.Values, rootRef)
+		return node
+	case NullableObjectNode:
+		node.Object = rerootRefs(node.Object, rootRef).(ObjectNode)
+		return node
+	case NullableUnionNode:
+		node.Schema = rerootRefs(node.Schema, rootRef)
+		return node
+	case RefNode:
+		if node.Ref == rootRef {
+			node.Ref = "#"
+		}
+		return node
+	default:
+		return schema
+	}
+}
+
 func (s SchemaBuilder) HaveInterfaces() bool {
 	return len(s.Interfaces) > 0
 }
 
+// CompilesSchemas reports whether generated code compiles JSON schemas, to
+// validate types or to decode untagged interfaces.
+func (s SchemaBuilder) CompilesSchemas() bool {
+	return s.ValidatesAny() || s.HasUntaggedUnmarshalers()
+}
+
+// HasSeparatelyTaggedInterfaces reports whether an interface is externally
+// or adjacently tagged, so that generated code marshals its values beside
+// their discriminator rather than with it inside them.
+func (s SchemaBuilder) HasSeparatelyTaggedInterfaces() bool {
+	for _, iface := range s.Interfaces {
+		if iface.ExternallyTagged || iface.ContentPropName != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasUntaggedUnmarshalers reports whether generated code decodes an untagged
+// interface.
+func (s SchemaBuilder) HasUntaggedUnmarshalers() bool {
+	if !s.GeneratesJSONUnmarshalers() {
+		return false
+	}
+	for _, iface := range s.Interfaces {
+		if iface.Untagged {
+			return true
+		}
+	}
+	return false
+}
+
 // IsSpecialType returns true if the type has a custom UnmarshalJSON for union/interface fields.
 func (s SchemaBuilder) IsSpecialType(typeName string) bool {
 	for _, st := range s.SpecialTypes {
@@ -460,6 +790,22 @@ func (s SchemaBuilder) HasNonRenderedTypes() bool {
 	return false
 }
 
+// Validates reports whether ValidateJSON and Parse<Type> are generated for
+// typeName. Tool params are always validated, since Invoke relies on it.
+func (s SchemaBuilder) Validates(typeName string) bool {
+	return !s.Rendered[typeName] && (s.Validate || s.Scan.IsToolParams(typeName))
+}
+
+// ValidatesAny reports whether any type in the package is validated.
+func (s SchemaBuilder) ValidatesAny() bool {
+	for _, m := range s.SchemaMethods() {
+		if s.Validates(m.Receiver.TypeName) {
+			return true
+		}
+	}
+	return false
+}
+
 // discoverEnum auto-discovers an enum from const declarations in the package
 func (s SchemaBuilder) discoverEnum(typeName string, scanRes syntax.ScanResult) *syntax.EnumSet {
 	// Check if the type exists
@@ -771,6 +1117,18 @@ func (s SchemaBuilder) mapType(t syntax.TypeID, seen syntax.SeenTypes) error {
 	return nil
 }
 
+// markRecursive records every type on path up to and including target, the
+// type a back-edge points at, as part of a cycle.
+func (s SchemaBuilder) markRecursive(path syntax.SeenTypes, target syntax.TypeID) {
+	target = target.Concrete()
+	for _, id := range path {
+		s.Recursive[id] = true
+		if id == target {
+			return
+		}
+	}
+}
+
 func (s SchemaBuilder) checkSeen(seen syntax.SeenTypes) error {
 	if len(seen) > maxNestingDepth {
 		pos, _ := s.find(seen[0])
@@ -789,7 +1147,10 @@ func (s SchemaBuilder) mapNamedType(t syntax.TypeID, seen syntax.SeenTypes) erro
 		return fmt.Errorf("mapNamedType: type %s not found", t.TypeName)
 	}
 	if seen.Seen(t) {
-		return fmt.Errorf("circular dependency found for type %s at %s", t.TypeName, typeSpec.Position())
+		// Back-edges through named fields become $refs in renderSchema, so
+		// reaching here means the cycle runs through an interface union
+		// option, which is always inlined beside its discriminator.
+		return fmt.Errorf("circular dependency found for type %s at %s: cycles through registered interface implementations cannot be rendered", t.TypeName, typeSpec.Position())
 	}
 	if structType, ok := typeSpec.Type().Expr().(*dst.StructType); ok {
 		if props, err := s.resolveLocalInterfaceProps(syntax.NewStructType(structType, typeSpec), nil); err != nil {
@@ -802,6 +1163,13 @@ func (s SchemaBuilder) mapNamedType(t syntax.TypeID, seen syntax.SeenTypes) erro
 		return err
 	} else {
 		s.AddSchema(t, schema)
+		if s.Recursive[t.Concrete()] {
+			// A root type on a cycle has no reference site of its own to
+			// register the definition its back-edges point at.
+			if _, err = s.registerRefDef(t, schema); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -819,19 +1187,8 @@ func (s SchemaBuilder) renderSchema(t syntax.TypeExpr, description string, seen
 		case "float32", "float64":
 			return PropertyNode[float64]{Desc: description, Typ: "number", TypeID_: t.ID()}, nil
 		default:
-			// Special handling for well-known external types
-			if syntax.IsTimeType(node.Path, node.Name) {
-				// time.Time should be represented as a string with RFC3339 format
-				// We add description to guide LLMs rather than using format field
-				timeDesc := "RFC3339 formatted date-time string (e.g., \"2006-01-02T15:04:05Z07:00\")"
-				if description != "" {
-					timeDesc = description + ". Must be an " + timeDesc
-				}
-				return PropertyNode[string]{
-					Desc:    timeDesc,
-					Typ:     "string",
-					TypeID_: t.ID(),
-				}, nil
+			if schema, ok := renderWellKnownType(t, node.Path, node.Name, description); ok {
+				return schema, nil
 			}
 
 			// Means it is another named type.
@@ -841,26 +1198,28 @@ func (s SchemaBuilder) renderSchema(t syntax.TypeExpr, description string, seen
 				newType.PkgPath = t.Pkg().PkgPath
 			}
 
-			// Check if this is an external package that we haven't scanned
-			if newType.PkgPath != "" {
-				if _, ok := s.Scan.GetPackage(newType.PkgPath); !ok {
-					// External package not scanned - return an empty schema (allows any valid JSON)
-					// We return an empty ObjectNode which will be rendered as {}
-					return ObjectNode{
-						Desc:    description,
-						TypeID_: t.ID(),
-					}, nil
-				}
+			// A package the scanner could not load has no declarations to
+			// render from. Render a schema that accepts any value, and say so.
+			if _, ok := s.Scan.GetPackage(newType.PkgPath); !ok {
+				s.warnf("%s: type %s could not be resolved; its schema accepts any value", t.Position(), newType)
+				return AnyNode{Desc: description, TypeID_: t.ID()}, nil
 			}
 
-			if err := s.mapType(newType, seen.See(t.ID())); err != nil {
+			path := seen.See(t.ID())
+			if path.Seen(newType) {
+				// A back-edge: newType is still being rendered further up the
+				// path. Every type between here and there is on the cycle.
+				s.markRecursive(path, newType)
+				return RefNode{Ref: "#/$defs/" + newType.Concrete().TypeName, BackEdge: true}, nil
+			}
+			if err := s.mapType(newType, path); err != nil {
 				return nil, err
 			}
 			schema, ok := s.GetSchema(newType)
 			if !ok {
 				panic("mapType apparently didn't map the type! " + newType.String())
 			}
-			if s.RefTypes[newType.Concrete()] {
+			if s.RefTypes[newType.Concrete()] || s.Recursive[newType.Concrete()] {
 				return s.registerRefDef(newType, schema)
 			}
 			if description == "" {
@@ -873,7 +1232,13 @@ func (s SchemaBuilder) renderSchema(t syntax.TypeExpr, description string, seen
 			}
 		}
 	case *dst.StarExpr:
-		return s.renderSchema(t.Derive(node.X), description, seen)
+		schema, err := s.renderSchema(t.Derive(node.X), description, seen)
+		if ref, ok := schema.(RefNode); ok && ref.BackEdge {
+			// Every finite value ends the cycle with a nil pointer, which
+			// encoding/json writes as null.
+			return NullableUnionNode{Schema: ref}, err
+		}
+		return schema, err
 	case *dst.ParenExpr:
 		return s.renderSchema(t.Derive(node.X), description, seen)
 	case *dst.ArrayType:
@@ -888,8 +1253,10 @@ func (s SchemaBuilder) renderSchema(t syntax.TypeExpr, description string, seen
 			return nil, fmt.Errorf("%s at %s", unsupportedRegisteredInterfaceContainer, t.Position())
 		}
 		return schema, nil
-	case *dst.MapType, *dst.ChanType:
-		return nil, fmt.Errorf("mapType/chanType not allowed %s at %s", t.Name(), t.Position())
+	case *dst.MapType:
+		return s.renderMapSchema(t, node, description, seen)
+	case *dst.ChanType:
+		return nil, fmt.Errorf("chanType not allowed %s at %s", t.Name(), t.Position())
 	case *dst.StructType:
 		return s.renderStructSchema(syntax.NewStructType(node, *t.TypeSpec), description, seen)
 	case *dst.InterfaceType:
@@ -906,95 +1273,106 @@ func (s SchemaBuilder) renderStructSchema(t syntax.StructType, description strin
 		Discriminator: t.Name(),
 		TypeID_:       t.ID(),
 	}
-	node.Properties, err = s.renderStructProps(t, nil, seen)
+	node.Properties, err = s.renderStructProps(t, seen)
 	return node, err
 }
 
-func (s SchemaBuilder) writeSchema(t syntax.TypeID, targetDir string, noChanges bool) (wroteNew bool, err error) {
-	var (
-		ok       bool
-		filePath string
-		sumPath  string
-		tmpFile  *os.File
-	)
-
-	// Decide target file extension based on whether schema is templated
-	if _, templated := s.TypeProvidersMap[t.TypeName]; templated {
-		filePath = filepath.Join(targetDir, fmt.Sprintf("%s.json.tmpl", t.TypeName))
-	} else {
-		filePath = filepath.Join(targetDir, fmt.Sprintf("%s.json", t.TypeName))
+// schemaFileName names the file the schema for t is written to. An empty
+// dialect names the native <Type>.json; any other dialect names
+// <Type>.<dialect>.json beside it. Templated types use .json.tmpl.
+func (s SchemaBuilder) schemaFileName(t syntax.TypeID, dialect Dialect) string {
+	baseName := t.TypeName
+	if dialect != "" {
+		baseName += "." + string(dialect)
 	}
-	sumPath = filePath + ".sum"
-
-	// Create temp file in same directory to ensure same filesystem
-
-	if tmpFile, err = os.CreateTemp(targetDir, fmt.Sprintf("%s.*.json.tmp", t.TypeName)); err != nil {
-		return false, fmt.Errorf("could not create temp file: %w", err)
+	if _, templated := s.TypeProvidersMap[t.TypeName]; templated {
+		return baseName + ".json.tmpl"
 	}
-	defer func() {
-		if fCloseErr := tmpFile.Close(); fCloseErr != nil && !errors.Is(fCloseErr, os.ErrClosed) {
-			err = errors.Join(err, fmt.Errorf("could not close temp file: %w", fCloseErr))
-		}
-		// Clean up temp file if we're returning with an error or if we didn't use it
-		_, statErr := os.Stat(tmpFile.Name())
-		if os.IsNotExist(statErr) {
-			return
-		} else if statErr != nil {
-			err = errors.Join(err, fmt.Errorf("could not stat temp file: %w", statErr))
-			return
-		}
-		if rmErr := os.Remove(tmpFile.Name()); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
-			err = errors.Join(err, fmt.Errorf("could not remove temp file: %w", rmErr))
-		}
-	}()
+	return baseName + ".json"
+}
 
+// schemaFile renders the file contents written for t in the given dialect.
+func (s SchemaBuilder) schemaFile(t syntax.TypeID, dialect Dialect) ([]byte, error) {
 	rootSchema, ok := s.GetSchema(t)
 	if !ok {
-		return false, fmt.Errorf("unknown type %s", t)
+		return nil, fmt.Errorf("unknown type %s", t)
+	}
+	var err error
+	if dialect != "" {
+		if rootSchema, err = dialect.rewrite(s, rootSchema, t.TypeName); err != nil {
+			return nil, err
+		}
 	}
 	var schema json.Marshaler = rootSchema
 	defs := map[string]JSONSchema{}
 	s.collectRefDefs(rootSchema, defs)
+	if def, ok := s.RefDefs[t.TypeName]; ok && def.TypeID == t.Concrete() && defs[t.TypeName] != nil {
+		// The root is its own definition: refer to it as "#" rather than
+		// writing it out a second time under "$defs".
+		delete(defs, t.TypeName)
+		rootRef := "#/$defs/" + t.TypeName
+		rootSchema = rerootRefs(rootSchema, rootRef)
+		schema = rootSchema
+		for name, def := range defs {
+			defs[name] = rerootRefs(def, rootRef)
+		}
+	}
 	if len(defs) > 0 {
 		schema = RootSchema{Root: rootSchema, Defs: defs}
 	}
+	if examples := s.TypeExamples[t.TypeName]; len(examples) > 0 {
+		schema = annotatedNode{Schema: schema, Keywords: annotationKeywords(dialect, nil, examples)}
+	}
 
-	hash := fnv.New64a()
-	writer := io.MultiWriter(tmpFile, hash)
-	_, templated := s.TypeProvidersMap[t.TypeName]
+	var (
+		buf          bytes.Buffer
+		b            []byte
+		_, templated = s.TypeProvidersMap[t.TypeName]
+	)
 	// Templates cannot use the standard pretty encoder because their holes are
 	// not valid JSON. Preserve the existing raw output when pretty is requested.
 	if templated && s.Pretty {
-		var b []byte
 		if b, err = schema.MarshalJSON(); err != nil {
-			return false, fmt.Errorf("could not marshal template schema: %w", err)
-		}
-		if _, err = writer.Write(b); err != nil {
-			return false, fmt.Errorf("could not write template schema: %w", err)
-		}
-		if _, err = writer.Write([]byte("\n")); err != nil {
-			return false, fmt.Errorf("could not write newline: %w", err)
+			return nil, fmt.Errorf("could not marshal template schema: %w", err)
 		}
+		buf.Write(b)
+		buf.WriteByte('\n')
 	} else if s.Pretty {
-		encoder := json.NewEncoder(writer)
+		encoder := json.NewEncoder(&buf)
 		encoder.SetIndent("", "  ")
 		if err = encoder.Encode(schema); err != nil {
-			return false, fmt.Errorf("could not encode schema: %w", err)
+			return nil, fmt.Errorf("could not encode schema: %w", err)
 		}
 	} else {
-		var b []byte
 		if b, err = marshalSchemaHardlines(schema); err != nil {
-			return false, fmt.Errorf("could not format schema: %w", err)
-		}
-		if _, err = writer.Write(b); err != nil {
-			return false, fmt.Errorf("could not write schema: %w", err)
-		}
-		if _, err = writer.Write([]byte("\n")); err != nil {
-			return false, fmt.Errorf("could not write newline: %w", err)
+			return nil, fmt.Errorf("could not format schema: %w", err)
 		}
+		buf.Write(b)
+		buf.WriteByte('\n')
 	}
+	return buf.Bytes(), nil
+}
+
+// schemaChecksum is the content hash stored in the .sum file beside each
+// schema file.
+func schemaChecksum(data []byte) string {
+	hash := fnv.New64a()
+	_, _ = hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil))
+}
 
-	newChecksum := hex.EncodeToString(hash.Sum(nil))
+// writeSchema writes the schema for t to the file named by schemaFileName.
+func (s SchemaBuilder) writeSchema(t syntax.TypeID, dialect Dialect, targetDir string, noChanges bool) (wroteNew bool, err error) {
+	var (
+		filePath = filepath.Join(targetDir, s.schemaFileName(t, dialect))
+		sumPath  = filePath + ".sum"
+		tmpFile  *os.File
+		data     []byte
+	)
+	if data, err = s.schemaFile(t, dialect); err != nil {
+		return false, err
+	}
+	newChecksum := schemaChecksum(data)
 
 	// Check if content actually changed by comparing with old checksum
 	wroteNew = true
@@ -1007,6 +1385,30 @@ func (s SchemaBuilder) writeSchema(t syntax.TypeID, targetDir string, noChanges
 		return true, nil
 	}
 
+	// Create temp file in same directory to ensure same filesystem
+	if tmpFile, err = os.CreateTemp(targetDir, fmt.Sprintf("%s.*.json.tmp", t.TypeName)); err != nil {
+		return false, fmt.Errorf("could not create temp file: %w", err)
+	}
+	defer func() {
+		if fCloseErr := tmpFile.Close(); fCloseErr != nil && !errors.Is(fCloseErr, os.ErrClosed) {
+			err = errors.Join(err, fmt.Errorf("could not close temp file: %w", fCloseErr))
+		}
+		// Clean up temp file if we're returning with an error or if we didn't use it
+		_, statErr := os.Stat(tmpFile.Name())
+		if os.IsNotExist(statErr) {
+			return
+		} else if statErr != nil {
+			err = errors.Join(err, fmt.Errorf("could not stat temp file: %w", statErr))
+			return
+		}
+		if rmErr := os.Remove(tmpFile.Name()); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+			err = errors.Join(err, fmt.Errorf("could not remove temp file: %w", rmErr))
+		}
+	}()
+	if _, err = tmpFile.Write(data); err != nil {
+		return false, fmt.Errorf("could not write schema: %w", err)
+	}
+
 	// Move temp file into place and write new checksum
 	if err = tmpFile.Close(); err != nil {
 		return false, fmt.Errorf("could not close temp file: %w", err)
@@ -1030,9 +1432,8 @@ func sortedCustomTypeNames(customTypes map[string][]InterfaceProp) []string {
 	return names
 }
 
-func (s *SchemaBuilder) RenderGoCode() (err error) {
+func (s *SchemaBuilder) prepareGoCode() (err error) {
 	importMap := s.imports()
-	s.Imports = importMap.ImportStatements()
 	generatedInterfaceHelpers := make(map[string]bool)
 
 	// for _, poop := range s.SchemaMethods() {
@@ -1063,15 +1464,28 @@ func (s *SchemaBuilder) RenderGoCode() (err error) {
 	// 	}
 	// }
 
-	for _, n := range sortedCustomTypeNames(s.customTypes) {
+	var enumAdapters map[string][]EnumProp
+	if s.StringerEnums, enumAdapters, err = s.stringerEnums(importMap); err != nil {
+		return err
+	}
+	specialNames := sortedCustomTypeNames(s.customTypes)
+	for n := range enumAdapters {
+		if _, ok := s.customTypes[n]; !ok {
+			specialNames = append(specialNames, n)
+		}
+	}
+	slices.Sort(specialNames)
+	for _, n := range specialNames {
 		itsProps := slices.Clone(s.customTypes[n])
 		for i := range itsProps {
 			ifacePkg := itsProps[i].Interface.TypeSpec.Pkg()
 			itsProps[i].InterfaceTypeNameWithPrefix = importMap.PrefixExpr(itsProps[i].Interface.TypeSpec.Name(), ifacePkg)
+			itsProps[i].Containers = qualifyContainers(itsProps[i].Containers, importMap.Qualifier())
 		}
 		s.SpecialTypes = append(s.SpecialTypes, CustomMarshaledType{
 			Name:           n,
 			InterfaceProps: itsProps,
+			EnumProps:      enumAdapters[n],
 			Initial:        strings.ToLower(n[0:1]),
 		})
 		for _, ifaceProp := range itsProps {
@@ -1080,34 +1494,30 @@ func (s *SchemaBuilder) RenderGoCode() (err error) {
 			}
 			generatedInterfaceHelpers[ifaceProp.UnmarshalerFunc()] = true
 			var (
-				discriminators = map[string]bool{}
 				ifacePkg       = ifaceProp.Interface.TypeSpec.Pkg()
+				discriminators = discriminatorValues(ifaceProp.Interface.Impls, ifaceProp.DiscriminatorValues)
 			)
 			var opts []InterfaceOptionInfo
-			for _, option := range ifaceProp.Interface.Impls {
+			for i, option := range ifaceProp.Interface.Impls {
 				pkg, ok := s.Scan.GetPackage(option.PkgPath)
 				if !ok {
 					panic("could not find package at RenderGoCode: " + option.PkgPath)
 				}
-				var disc string
-				if explicit, ok := ifaceProp.DiscriminatorValues[option]; ok {
-					disc = explicit
-				} else {
-					disc = option.TypeName
-					for i := 1; discriminators[disc]; i++ {
-						disc = strings.TrimSuffix(disc, strconv.Itoa(i-1))
-						disc = fmt.Sprintf("%s%d", disc, i)
-					}
-				}
-				discriminators[disc] = true
-				opts = append(opts, InterfaceOptionInfo{
+				opt := InterfaceOptionInfo{
 					TypeNameWithPrefix: importMap.PrefixExpr(option.TypeName, pkg.Pkg),
-					Discriminator:      disc,
+					Discriminator:      discriminators[i],
 					TypeName:           option.TypeName,
 					PkgPath:            option.PkgPath,
 					Pointer:            option.Indirection == syntax.Pointer,
-				})
+				}
+				if ifaceProp.Untagged {
+					if opt.Schema, err = s.untaggedOptionSchema(option); err != nil {
+						return fmt.Errorf("untagged interface %s: %w", ifaceProp.Interface.TypeSpec.Name(), err)
+					}
+				}
+				opts = append(opts, opt)
 			}
+			setMarshalCases(opts)
 			// Determine discriminator property name for this field-specific unmarshaler (only if overridden)
 			discProp := ifaceProp.DiscPropName
 			s.Interfaces = append(s.Interfaces, InterfaceInfo{
@@ -1116,8 +1526,13 @@ func (s *SchemaBuilder) RenderGoCode() (err error) {
 				TypeName:              ifaceProp.Interface.TypeSpec.Name(),
 				PkgPath:               ifacePkg.PkgPath,
 				UnmarshalerFunc:       ifaceProp.UnmarshalerFunc(),
+				MarshalerFunc:         ifaceProp.MarshalerFunc(),
 				DiscriminatorPropName: discProp,
 				Options:               opts,
+				Untagged:              ifaceProp.Untagged,
+				ExternallyTagged:      ifaceProp.ExternallyTagged,
+				ContentPropName:       ifaceProp.ContentPropName,
+				SchemasVar:            "__jsonSchemas__" + strings.TrimPrefix(ifaceProp.UnmarshalerFunc(), "__jsonUnmarshal__"),
 			})
 		}
 	}
@@ -1141,19 +1556,90 @@ func (s *SchemaBuilder) RenderGoCode() (err error) {
 			})
 		}
 	}
+	s.Tools = s.toolInfos(importMap)
+	s.Imports = importMap.ImportStatements()
+	return nil
+}
+
+// goCodeFile is the name of the generated Go file in each package.
+const goCodeFile = "jsonschema_gen.go"
+
+// renderGoCode prepares the template data and renders the formatted
+// contents of jsonschema_gen.go. It must be called at most once per builder.
+func (s *SchemaBuilder) renderGoCode() ([]byte, error) {
+	if err := s.prepareGoCode(); err != nil {
+		return nil, err
+	}
 	data, err := RenderTemplate(schemasTemplate, s)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	result, err := FormatCodeWithGoimports(data.Bytes())
+	return FormatCodeWithGoimports(data.Bytes())
+}
+
+func (s *SchemaBuilder) RenderGoCode() (err error) {
+	result, err := s.renderGoCode()
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(filepath.Join(s.Scan.Pkg.Dir, "jsonschema_gen.go"), result, 0644)
-	if err != nil {
-		return err
+	return os.WriteFile(filepath.Join(s.Scan.Pkg.Dir, goCodeFile), result, 0644)
+}
+
+// setMarshalCases fills in the type switch case for each option. A value
+// implementation also matches its pointer, since *T implements every
+// interface T does, unless *T is registered as an option of its own.
+// discriminatorValues returns the wire value of each implementation: its Impl
+// value, or else its type name, numbered apart from the values before it.
+func discriminatorValues(impls []syntax.TypeID, explicit map[syntax.TypeID]string) []string {
+	var (
+		values = make([]string, len(impls))
+		seen   = map[string]bool{}
+	)
+	for i, impl := range impls {
+		disc, ok := explicit[impl]
+		if !ok {
+			disc = impl.TypeName
+			for n := 1; seen[disc]; n++ {
+				disc = strings.TrimSuffix(disc, strconv.Itoa(n-1))
+				disc = fmt.Sprintf("%s%d", disc, n)
+			}
+		}
+		seen[disc] = true
+		values[i] = disc
+	}
+	return values
+}
+
+func setMarshalCases(opts []InterfaceOptionInfo) {
+	pointers := map[string]bool{}
+	for _, opt := range opts {
+		if opt.Pointer {
+			pointers[opt.TypeNameWithPrefix] = true
+		}
+	}
+	for i, opt := range opts {
+		switch {
+		case opt.Pointer:
+			opts[i].MarshalCase = "*" + opt.TypeNameWithPrefix
+		case pointers[opt.TypeNameWithPrefix]:
+			opts[i].MarshalCase = opt.TypeNameWithPrefix
+		default:
+			opts[i].MarshalCase = opt.TypeNameWithPrefix + ", *" + opt.TypeNameWithPrefix
+		}
 	}
-	return nil
+}
+
+// schemaReceivers lists every type that gets a schema file, in declaration
+// order: Schema methods first, then schema functions.
+func (s SchemaBuilder) schemaReceivers() []syntax.TypeID {
+	receivers := make([]syntax.TypeID, 0, len(s.Scan.SchemaMethods)+len(s.Scan.SchemaFuncs))
+	for _, method := range s.Scan.SchemaMethods {
+		receivers = append(receivers, method.Receiver)
+	}
+	for _, fn := range s.Scan.SchemaFuncs {
+		receivers = append(receivers, fn.Receiver)
+	}
+	return receivers
 }
 
 func (s SchemaBuilder) RenderSchemas(noChanges, force bool) (changedSchemas map[string]bool, err error) {
@@ -1163,19 +1649,23 @@ func (s SchemaBuilder) RenderSchemas(noChanges, force bool) (changedSchemas map[
 	if err = os.MkdirAll(targetDir, 0755); err != nil {
 		return nil, fmt.Errorf("could not create subdir %s: %w", targetDir, err)
 	}
-	for _, method := range s.Scan.SchemaMethods {
+	var dialectErrs []error
+	for _, receiver := range s.schemaReceivers() {
 		var changed bool
-		if changed, err = s.writeSchema(method.Receiver, targetDir, noChanges); err != nil {
+		if changed, err = s.writeSchema(receiver, "", targetDir, noChanges); err != nil {
 			return nil, err
 		}
-		changedSchemas[method.Receiver.TypeName] = changed || force
-	}
-	for _, fn := range s.Scan.SchemaFuncs {
-		var changed bool
-		if changed, err = s.writeSchema(fn.Receiver, targetDir, noChanges); err != nil {
-			return nil, err
+		for _, dialect := range s.Dialects {
+			dialectChanged, dialectErr := s.writeSchema(receiver, dialect, targetDir, noChanges)
+			if dialectErr != nil {
+				dialectErrs = append(dialectErrs, dialectErr)
+			}
+			changed = changed || dialectChanged
 		}
-		changedSchemas[fn.Receiver.TypeName] = changed || force
+		changedSchemas[receiver.TypeName] = changed || force
+	}
+	if err = errors.Join(dialectErrs...); err != nil {
+		return nil, err
 	}
 	return changedSchemas, nil
 }
@@ -1215,32 +1705,83 @@ func (s SchemaBuilder) resolveEmbeddedType(t syntax.TypeExpr, seen syntax.SeenTy
 	}
 }
 
-func (s SchemaBuilder) renderStructProps(t syntax.StructType, seenProps syntax.SeenProps, seen syntax.SeenTypes) (props ObjectPropSet, err error) {
-	var myProps = slices.Clone(seenProps)
-	for _, prop := range t.Fields() {
-		if prop.Skip() {
+// renderStructProps renders the properties of t, with those promoted from its
+// embedded structs. Of the properties sharing a name, it keeps the one
+// encoding/json writes: the shallowest, or the tagged one among several at
+// that depth. A name that is still ambiguous is dropped, as encoding/json
+// drops it.
+func (s SchemaBuilder) renderStructProps(t syntax.StructType, seen syntax.SeenTypes) (ObjectPropSet, error) {
+	promoted, err := s.renderPromotedProps(t, 0, seen)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string][]promotedProp{}
+	for _, prop := range promoted {
+		byName[prop.Name] = append(byName[prop.Name], prop)
+	}
+	var props ObjectPropSet
+	for _, prop := range promoted {
+		depth := prop.depth
+		for _, other := range byName[prop.Name] {
+			depth = min(depth, other.depth)
+		}
+		if prop.depth != depth {
 			continue
 		}
-		for _, name := range prop.PropNames() {
-			myProps = myProps.See(name)
+		var rivals, tagged int
+		for _, other := range byName[prop.Name] {
+			if other.depth == depth {
+				rivals++
+				if other.tagged {
+					tagged++
+				}
+			}
+		}
+		if rivals == 1 || (prop.tagged && tagged == 1) {
+			props = append(props, prop.ObjectProp)
 		}
 	}
+	return props, nil
+}
+
+// promotedProp is a property of a struct, promoted through depth embedded
+// structs. Tagged properties take their name from a json tag.
+type promotedProp struct {
+	ObjectProp
+	depth  int
+	tagged bool
+}
+
+func (s SchemaBuilder) renderPromotedProps(t syntax.StructType, depth int, seen syntax.SeenTypes) (props []promotedProp, err error) {
 	for _, prop := range t.Fields() {
-		var tempProps ObjectPropSet
 		if prop.Skip() {
 			continue
 		}
 		if prop.Embedded() {
-			var embeddedType syntax.StructType
+			var (
+				embeddedType syntax.StructType
+				tempProps    []promotedProp
+			)
 			if embeddedType, err = s.resolveEmbeddedType(prop.TypeExpr, seen); err != nil {
 				return nil, fmt.Errorf("resolving embedded type: %w", err)
-			} else if tempProps, err = s.renderStructProps(embeddedType, myProps, seen); err != nil {
+			} else if tempProps, err = s.renderPromotedProps(embeddedType, depth+1, seen); err != nil {
 				return nil, fmt.Errorf("rendering embedded type: %w", err)
 			}
-		} else if tempProps, err = s.renderStructField(t, prop, seen); err != nil {
+			props = append(props, tempProps...)
+			continue
+		}
+		tempProps, err := s.renderStructField(t, prop, seen)
+		if err != nil {
 			return nil, fmt.Errorf("rendering struct field: %w", err)
 		}
-		props = append(props, tempProps...)
+		tag := prop.JSONTag()
+		for _, tempProp := range tempProps {
+			props = append(props, promotedProp{
+				ObjectProp: tempProp,
+				depth:      depth,
+				tagged:     tag != nil && tag.Options[0] != "",
+			})
+		}
 	}
 	return props, nil
 }
@@ -1276,8 +1817,12 @@ func (s SchemaBuilder) renderStructField(owner syntax.StructType, f syntax.Struc
 		return nil, err
 	}
 	// Prefer centralized tag parsing
+	var tag common.JSONSchemaTag
 	if f.Field.Tag != nil && f.Field.Tag.Value != "" {
-		if tag := common.ParseJSONSchemaTag(f.Field.Tag.Value); tag.HasRef {
+		if tag = common.ParseJSONSchemaTag(f.Field.Tag.Value); tag.Err != nil {
+			return nil, fmt.Errorf("field %s at %s: jsonschema tag: %w", strings.Join(f.PropNames(), ","), f.Position(), tag.Err)
+		}
+		if tag.HasRef {
 			schema = RefNode{Ref: tag.Ref}
 			specialSource = "explicit refs"
 		}
@@ -1320,25 +1865,58 @@ func (s SchemaBuilder) renderStructField(owner syntax.StructType, f syntax.Struc
 					}
 				}
 
+				// EnumDescriptions describes the values, so the field's own
+				// comment goes beside them.
+				var desc string
+				var descriptions []string
+				if cfg.Descriptions {
+					desc = f.Comments()
+					for _, v := range enumSet.Values {
+						descriptions = append(descriptions, v.Comments())
+					}
+				}
+
+				enumID := syntax.TypeID{PkgPath: pkgPath, TypeName: ident.Name}
+				if isStringEnum {
+					if err = s.checkEnumNames(enumID, enumSet.Values); err != nil {
+						return nil, err
+					}
+				}
 				// Use string mode if it's a string-based enum or WithStringerEnum was used
 				if isStringEnum || cfg.UseStringer {
 					var vals []string
-					for _, v := range enumSet.Values {
-						var value string
-						if isStringEnum && len(v.Value().Values) > 0 {
-							// Get the actual string value
-							if lit, ok := v.Value().Values[0].(*dst.BasicLit); ok {
-								value = strings.Trim(lit.Value, "\"")
-							} else {
-								value = v.Value().Names[0].Name
+					if isStringEnum {
+						for _, v := range enumSet.Values {
+							value := v.Value().Names[0].Name
+							if len(v.Value().Values) > 0 {
+								// Get the actual string value
+								if lit, ok := v.Value().Values[0].(*dst.BasicLit); ok {
+									value = strings.Trim(lit.Value, "\"")
+								}
 							}
-						} else {
-							// For iota enums with string mode, use the constant name
-							value = v.Value().Names[0].Name
+							vals = append(vals, value)
+						}
+					} else {
+						// For iota enums with string mode, use the constant names
+						field, err := s.stringerEnumNames(enumID, scanRes, enumSet.Values)
+						if err != nil {
+							return nil, err
+						}
+						if s.stringerFields[owner.Name()] == nil {
+							s.stringerFields[owner.Name()] = map[string]stringerEnumField{}
 						}
-						vals = append(vals, value)
+						s.stringerFields[owner.Name()][goField.Name] = field
+						vals = field.Names
+						names := make(map[string]string, len(field.Consts))
+						for i, c := range field.Consts {
+							names[c] = field.Names[i]
+						}
+						s.addEnumHelperSource(enumID, enumSet.Values, names)
+					}
+					if isStringEnum {
+						s.addEnumHelperSource(enumID, enumSet.Values, nil)
 					}
-					schema = PropertyNode[string]{Typ: "string", Enum: vals, TypeID_: f.ID()}
+					schema = PropertyNode[string]{Desc: desc, Typ: "string", Enum: vals, EnumDescriptions: descriptions, TypeID_: f.ID()}
 				} else {
 					var vals []int
 					iotaVal := 0
@@ -1353,23 +1931,21 @@ func (s SchemaBuilder) renderStructField(owner syntax.StructType, f syntax.Struc
 						vals = append(vals, iotaVal)
 						iotaVal++
 					}
-					schema = PropertyNode[int]{Typ: "integer", Enum: vals, TypeID_: f.ID()}
+					s.addEnumHelperSource(enumID, enumSet.Values, nil)
+					schema = PropertyNode[int]{Desc: desc, Typ: "integer", Enum: vals, EnumDescriptions: descriptions, TypeID_: f.ID()}
 				}
 				specialSource = "enums"
 				break
 			}
 		}
-		// Registered interfaces, including the one supported container shape:
-		// a direct one-dimensional slice of the registered interface.
+		// Registered interfaces, directly or inside slices, arrays and maps.
 		if interfaceField != nil {
 			union, unionErr := s.renderRegisteredInterfaceUnion(*interfaceField, f, seen)
 			if unionErr != nil {
 				return nil, unionErr
 			}
-			if interfaceField.Repeated {
-				schema = ArrayNode{Desc: f.Comments(), Items: union, TypeID_: f.ID()}
-			} else {
-				schema = union
+			if schema, err = s.wrapInterfaceContainers(union, interfaceField.Containers, f, seen); err != nil {
+				return nil, err
 			}
 			specialSource = "registered interfaces"
 		}
@@ -1396,27 +1972,73 @@ func (s SchemaBuilder) renderStructField(owner syntax.StructType, f syntax.Struc
 			}
 		}
 	}
+	if tag.HasValidation() {
+		if schema, err = applyValidationKeywords(schema, tag); err != nil {
+			return nil, fmt.Errorf("field %s at %s: %w", strings.Join(f.PropNames(), ","), f.Position(), err)
+		}
+	}
+	var def json.RawMessage
+	if tag.HasDefault {
+		if def, err = defaultValue(schema, tag.Default); err != nil {
+			return nil, fmt.Errorf("field %s at %s: %w", strings.Join(f.PropNames(), ","), f.Position(), err)
+		}
+	}
 	if wrapper == syntax.WrapperNullable {
 		if _, isArrayOrSlice := renderType.(*dst.ArrayType); isArrayOrSlice {
 			return nil, fmt.Errorf("%s does not support arrays/slices at %s", wrapper, f.Position())
 		}
-		if specialSource != "" && specialSource != "enums" {
+		if specialSource != "" && specialSource != "enums" && specialSource != "registered interfaces" {
 			return nil, fmt.Errorf("%s does not support %s at %s", wrapper, specialSource, f.Position())
 		}
 		if schema, err = nullableSchema(schema); err != nil {
 			return nil, fmt.Errorf("%s field %s at %s: %w", wrapper, strings.Join(f.PropNames(), ","), f.Position(), err)
 		}
 	}
+	examples, err := s.fieldExamples(owner.Name(), f, specialSource)
+	if err != nil {
+		return nil, err
+	}
+	if specialSource != "providers" {
+		for _, value := range slices.Concat([]json.RawMessage{def}, examples) {
+			if value == nil {
+				continue
+			}
+			if err = s.checkValue(schema, value); err != nil {
+				return nil, fmt.Errorf("field %s at %s: %w", strings.Join(f.PropNames(), ","), f.Position(), err)
+			}
+		}
+	}
 	for _, name = range f.PropNames() {
 		props = append(props, ObjectProp{
 			Name:     name,
 			Schema:   schema,
 			Optional: !f.Required(),
+			Default:  def,
+			Examples: examples,
 		})
 	}
 	return props, nil
 }
 
+// fieldExamples encodes the WithExamples values registered for f.
+func (s SchemaBuilder) fieldExamples(owner string, f syntax.StructField, specialSource string) ([]json.RawMessage, error) {
+	var examples []json.RawMessage
+	for _, ident := range f.Field.Names {
+		values := s.FieldExamples[owner][ident.Name]
+		if len(values) > 0 && specialSource == "providers" {
+			return nil, fmt.Errorf("field %s at %s: WithExamples cannot be combined with a schema provider", strings.Join(f.PropNames(), ","), f.Position())
+		}
+		for _, value := range values {
+			data, err := s.encodeValue(value, owner, ident.Name)
+			if err != nil {
+				return nil, err
+			}
+			examples = append(examples, data)
+		}
+	}
+	return examples, nil
+}
+
 func nullableSchema(schema JSONSchema) (JSONSchema, error) {
 	switch value := schema.(type) {
 	case PropertyNode[int]:
@@ -1429,16 +2051,25 @@ func nullableSchema(schema JSONSchema) (JSONSchema, error) {
 		return nullableProperty(value)
 	case ObjectNode:
 		return NullableObjectNode{Object: value}, nil
-	case RefNode:
+	case RefNode, MapNode:
 		return NullableUnionNode{Schema: value}, nil
+	case NullableUnionNode:
+		// A back-edge through a pointer is nullable already.
+		return value, nil
+	case AnyNode:
+		// An unconstrained schema accepts null already.
+		return value, nil
+	case UnionTypeNode:
+		value.Nullable = true
+		return value, nil
 	default:
-		return nil, fmt.Errorf("inner schema shape %T is unsupported; supported nullable values are scalars, enums, structs, pointers to structs, and AsRef structs", schema)
+		return nil, fmt.Errorf("inner schema shape %T is unsupported; supported nullable values are scalars, enums, structs, pointers to structs, maps, AsRef structs, and registered interfaces", schema)
 	}
 }
 
 func nullableProperty[T ~int | ~string | ~bool | float32 | float64](value PropertyNode[T]) (JSONSchema, error) {
 	if value.Const != nil {
-		return nil, errors.New("consts are unsupported; supported nullable values are scalars, enums, structs, pointers to structs, and AsRef structs")
+		return nil, errors.New("consts are unsupported; supported nullable values are scalars, enums, structs, pointers to structs, maps, AsRef structs, and registered interfaces")
 	}
 	if len(value.Enum) > 0 {
 		return NullableUnionNode{Schema: value}, nil
@@ -1453,47 +2084,23 @@ type registeredInterfaceField struct {
 	DiscriminatorValues map[syntax.TypeID]string
 	FuncNameAlias       string
 	Optional            bool
-	Repeated            bool
+	Nullable            bool
+	Untagged            bool
+	ExternallyTagged    bool
+	ContentPropName     string
+	Containers          []InterfaceContainer
 	V1                  bool
 }
 
-func directInterfaceFieldType(expr dst.Expr) (ident *dst.Ident, repeated, ok bool) {
-	switch value := expr.(type) {
-	case *dst.Ident:
-		return value, false, true
-	case *dst.ArrayType:
-		if value.Len != nil {
-			return nil, false, false
-		}
-		ident, ok = value.Elt.(*dst.Ident)
-		return ident, true, ok
-	default:
-		return nil, false, false
-	}
-}
-
-func containsArrayType(expr dst.Expr) bool {
-	found := false
-	dst.Inspect(expr, func(node dst.Node) bool {
-		if _, ok := node.(*dst.ArrayType); ok {
-			found = true
-			return false
-		}
-		return !found
-	})
-	return found
-}
-
-func (s SchemaBuilder) resolveNamedType(ident *dst.Ident, localPkg *decorator.Package) (syntax.TypeSpec, bool) {
-	pkgPath := ident.Path
-	if pkgPath == "" {
-		pkgPath = localPkg.PkgPath
+func (s SchemaBuilder) resolveNamedType(named *types.Named) (syntax.TypeSpec, bool) {
+	if named.Obj().Pkg() == nil {
+		return syntax.TypeSpec{}, false
 	}
-	scan, ok := s.Scan.GetPackage(pkgPath)
+	scan, ok := s.Scan.GetPackage(named.Obj().Pkg().Path())
 	if !ok {
 		return syntax.TypeSpec{}, false
 	}
-	typeSpec, ok := scan.LocalNamedTypes[ident.Name]
+	typeSpec, ok := scan.LocalNamedTypes[named.Obj().Name()]
 	return typeSpec, ok
 }
 
@@ -1569,32 +2176,21 @@ func (s SchemaBuilder) resolveRegisteredInterfaceField(owner syntax.StructType,
 		}
 	}
 
-	ident, repeated, direct := directInterfaceFieldType(fieldType)
+	// Slices, arrays and maps of the interface, in any nesting, are decoded
+	// level by level down to the interface values.
+	containers, iface := interfaceContainers(goTypeOf(prop.Derive(fieldType).ToExpr()))
 	if v1Configured {
-		if !direct {
-			if containsArrayType(fieldType) {
-				return nil, fmt.Errorf("field %s.%s: %s at %s", owner.Name(), v1GoField, unsupportedRegisteredInterfaceContainer, prop.Position())
-			}
-			return nil, fmt.Errorf("registered interface field %s.%s must have a direct named interface type at %s", owner.Name(), v1GoField, prop.Position())
+		if iface == nil {
+			return nil, fmt.Errorf("registered interface field %s.%s must have a named interface type, directly or inside slices, arrays and maps, at %s", owner.Name(), v1GoField, prop.Position())
 		}
-		if repeated && wrapper != syntax.WrapperNone {
-			return nil, fmt.Errorf("field %s.%s: %s at %s", owner.Name(), v1GoField, unsupportedRegisteredInterfaceContainer, prop.Position())
-		}
-		typeSpec, ok := s.resolveNamedType(ident, s.Scan.Pkg)
+		typeSpec, ok := s.resolveNamedType(iface)
 		if !ok {
-			return nil, fmt.Errorf("could not resolve interface type %s", ident.Name)
-		}
-		switch typeSpec.Type().Expr().(type) {
-		case *dst.ArrayType:
-			return nil, fmt.Errorf("field %s.%s through named type %s: %s at %s", owner.Name(), v1GoField, ident.Name, unsupportedRegisteredInterfaceContainer, prop.Position())
-		case *dst.InterfaceType:
-		default:
-			return nil, fmt.Errorf("registered interface field %s.%s resolves to non-interface type %s at %s", owner.Name(), v1GoField, ident.Name, prop.Position())
+			return nil, fmt.Errorf("could not resolve interface type %s", iface.Obj().Name())
 		}
-		if wrapper == syntax.WrapperNullable {
-			return nil, fmt.Errorf("%s does not support registered interfaces at %s", wrapper, prop.Position())
+		if wrapper == syntax.WrapperNullable && len(containers) > 0 {
+			return nil, fmt.Errorf("%s does not support containers of registered interfaces at %s", wrapper, prop.Position())
 		}
-		if err := s.validateInterfaceImplementations(typeSpec, v1Cfg.Impls); err != nil {
+		if err := s.validateInterfaceImplementations(typeSpec, v1Cfg.undiscoveredImpls()); err != nil {
 			return nil, fmt.Errorf("field %s.%s: %w", owner.Name(), v1GoField, err)
 		}
 		funcAlias := fmt.Sprintf("__jsonUnmarshal__%s__%s__%s__%s", typeSpec.Pkg().Name, typeSpec.Name(), owner.Name(), v1GoField)
@@ -1604,45 +2200,53 @@ func (s SchemaBuilder) resolveRegisteredInterfaceField(owner syntax.StructType,
 			DiscriminatorValues: cloneDiscriminatorValues(v1Cfg.DiscriminatorValues),
 			FuncNameAlias:       funcAlias,
 			Optional:            wrapper == syntax.WrapperOptional,
-			Repeated:            repeated,
+			Nullable:            wrapper == syntax.WrapperNullable,
+			Untagged:            v1Cfg.Untagged,
+			ExternallyTagged:    v1Cfg.ExternallyTagged,
+			ContentPropName:     v1Cfg.ContentPropName,
+			Containers:          containers,
 			V1:                  true,
 		}, nil
 	}
 
-	if direct {
-		if iface, ok := s.findInterfaceImpl(ident, s.Scan.Pkg); ok {
-			if repeated && wrapper != syntax.WrapperNone {
-				return nil, fmt.Errorf("%s at %s", unsupportedRegisteredInterfaceContainer, prop.Position())
-			}
-			if wrapper == syntax.WrapperNullable {
-				return nil, fmt.Errorf("%s does not support registered interfaces at %s", wrapper, prop.Position())
+	if iface != nil {
+		if registered, ok := s.registeredInterface(iface); ok {
+			if wrapper == syntax.WrapperNullable && len(containers) > 0 {
+				return nil, fmt.Errorf("%s does not support containers of registered interfaces at %s", wrapper, prop.Position())
 			}
 			return &registeredInterfaceField{
-				Interface: iface,
-				Optional:  wrapper == syntax.WrapperOptional,
-				Repeated:  repeated,
+				Interface:  registered,
+				Optional:   wrapper == syntax.WrapperOptional,
+				Nullable:   wrapper == syntax.WrapperNullable,
+				Containers: containers,
 			}, nil
 		}
 	}
 
 	if interfaceName, found := s.registeredInterfaceInExpr(fieldType, s.Scan.Pkg); found {
-		if containsArrayType(fieldType) {
-			return nil, fmt.Errorf("%s for interface %s at %s", unsupportedRegisteredInterfaceContainer, interfaceName, prop.Position())
-		}
 		return nil, fmt.Errorf("found registered interface type %s in an unsupported location at %s", interfaceName, prop.Position())
 	}
-	if ident, ok := fieldType.(*dst.Ident); ok {
-		if typeSpec, found := s.resolveNamedType(ident, s.Scan.Pkg); found {
-			if underlying, isArray := typeSpec.Type().Expr().(*dst.ArrayType); isArray {
-				if interfaceName, containsInterface := s.registeredInterfaceInExpr(underlying, typeSpec.Pkg()); containsInterface {
-					return nil, fmt.Errorf("%s for interface %s through named type %s at %s", unsupportedRegisteredInterfaceContainer, interfaceName, ident.Name, prop.Position())
-				}
-			}
-		}
-	}
 	return nil, nil
 }
 
+// untaggedOptionSchema returns the schema that an untagged interface's
+// generated unmarshaler matches values of impl against, as a Go string
+// literal.
+func (s SchemaBuilder) untaggedOptionSchema(impl syntax.TypeID) (string, error) {
+	schema, ok := s.GetSchema(impl)
+	if !ok {
+		return "", fmt.Errorf("type %s is not a known schema", impl)
+	}
+	data, _, err := s.compileSchema(schema)
+	if err != nil {
+		return "", fmt.Errorf("schema of %s: %w", impl.TypeName, err)
+	}
+	if bytes.ContainsRune(data, '`') {
+		return strconv.Quote(string(data)), nil
+	}
+	return "`" + string(data) + "`", nil
+}
+
 func (s SchemaBuilder) renderRegisteredInterfaceUnion(field registeredInterfaceField, prop syntax.StructField, seen syntax.SeenTypes) (UnionTypeNode, error) {
 	if !field.V1 {
 		if err := s.mapType(field.Interface.TypeSpec.ID(), seen); err != nil {
@@ -1659,9 +2263,15 @@ func (s SchemaBuilder) renderRegisteredInterfaceUnion(field registeredInterfaceF
 		return union, nil
 	}
 
-	union := UnionTypeNode{DiscriminatorPropName: field.DiscPropName, TypeID_: prop.ID()}
+	union := UnionTypeNode{
+		DiscriminatorPropName: field.DiscPropName,
+		Untagged:              field.Untagged,
+		ExternallyTagged:      field.ExternallyTagged,
+		ContentPropName:       field.ContentPropName,
+		TypeID_:               prop.ID(),
+	}
 	for _, impl := range field.Interface.Impls {
-		if err := s.mapType(impl, seen); err != nil {
+		if err := s.mapType(impl, seen.See(prop.ID())); err != nil {
 			return UnionTypeNode{}, fmt.Errorf("rendering interface impl: %w", err)
 		}
 		implSchema, ok := s.GetSchema(impl)
@@ -1689,7 +2299,11 @@ type InterfaceProp struct {
 	FuncNameAlias               string
 	InterfaceTypeNameWithPrefix string
 	Optional                    bool
-	Repeated                    bool
+	Nullable                    bool
+	Untagged                    bool
+	ExternallyTagged            bool
+	ContentPropName             string
+	Containers                  []InterfaceContainer
 }
 
 func (s InterfaceProp) UnmarshalerFunc() string {
@@ -1699,6 +2313,13 @@ func (s InterfaceProp) UnmarshalerFunc() string {
 	return fmt.Sprintf("__jsonUnmarshal__%s__%s", s.Interface.TypeSpec.Pkg().Name, s.Interface.TypeSpec.Name())
 }
 
+// MarshalerFunc names the generated helper that encodes one interface value
+// with its discriminator. It shares the unmarshaler's per-field suffix so that
+// fields with different Impl wire values get separate helpers.
+func (s InterfaceProp) MarshalerFunc() string {
+	return "__jsonMarshal__" + strings.TrimPrefix(s.UnmarshalerFunc(), "__jsonUnmarshal__")
+}
+
 func (i InterfaceProp) FieldNames() string {
 	var names []string
 	for _, name := range i.Field.Field.Names {
@@ -1714,6 +2335,12 @@ func (i InterfaceProp) StructTag() string {
 	return i.Field.Field.Tag.Value
 }
 
+// OmitsNil reports whether the field's tag drops a nil interface value from
+// the JSON, as omitempty and omitzero do.
+func (i InterfaceProp) OmitsNil() bool {
+	return i.Field.HasJSONOption("omitempty") || i.Field.HasJSONOption("omitzero")
+}
+
 func (i InterfaceProp) JSONName() string {
 	names := i.Field.PropNames()
 	if len(names) == 0 {
@@ -1723,8 +2350,8 @@ func (i InterfaceProp) JSONName() string {
 }
 
 // resolveLocalInterfaceProps finds supported registered-interface properties on
-// local structs. A direct interface and a direct []interface are supported;
-// registered interfaces nested in any other container shape are rejected.
+// local structs. The interface may be the field type or sit inside slices,
+// arrays and maps nested to any depth; any other placement is rejected.
 //
 // Valid:
 // ```
@@ -1732,6 +2359,7 @@ func (i InterfaceProp) JSONName() string {
 //
 //	type struct Foo {
 //	  Bar MyInterface `json:"bar"`
+//	  Baz map[string][]MyInterface `json:"baz"`
 //	}
 //
 // ```
@@ -1741,8 +2369,8 @@ func (i InterfaceProp) JSONName() string {
 //
 //	MyInterface interface{}
 //	struct Foo {
-//	  Bar (MyInterface) `json:"bar"`
-//	  Baz [][]MyInterface `json:"baz"`
+//	  Bar *MyInterface `json:"bar"`
+//	  Baz []*MyInterface `json:"baz"`
 //	  Bap struct { // Inline structs are permissible, but they cannot contain interfaces.
 //	    Rap MyInterface `json:"rap"`
 //	  }
@@ -1785,7 +2413,11 @@ func (s SchemaBuilder) resolveLocalInterfaceProps(t syntax.StructType, seenProps
 			DiscriminatorValues: cloneDiscriminatorValues(field.DiscriminatorValues),
 			FuncNameAlias:       field.FuncNameAlias,
 			Optional:            field.Optional,
-			Repeated:            field.Repeated,
+			Nullable:            field.Nullable,
+			Untagged:            field.Untagged,
+			ExternallyTagged:    field.ExternallyTagged,
+			ContentPropName:     field.ContentPropName,
+			Containers:          field.Containers,
 		})
 	}
 	for _, prop := range t.Fields() {
@@ -1803,6 +2435,19 @@ func (s SchemaBuilder) resolveLocalInterfaceProps(t syntax.StructType, seenProps
 	return props, nil
 }
 
+// registeredInterface returns the registration of a named interface type.
+func (s SchemaBuilder) registeredInterface(named *types.Named) (iface syntax.IfaceImplementations, ok bool) {
+	if named.Obj().Pkg() == nil {
+		return iface, false
+	}
+	scan, ok := s.Scan.GetPackage(named.Obj().Pkg().Path())
+	if !ok {
+		return iface, false
+	}
+	iface, ok = scan.Interfaces[named.Obj().Name()]
+	return iface, ok
+}
+
 func (s SchemaBuilder) findInterfaceImpl(ident *dst.Ident, localPkg *decorator.Package) (iface syntax.IfaceImplementations, ok bool) {
 	var pkgPath = ident.Path
 	if pkgPath == "" {
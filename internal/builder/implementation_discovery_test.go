@@ -0,0 +1,220 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+const discoveryFixtureTypes = `
+type Shape interface{ shape() }
+
+type CreditCard struct {
+	Number string ` + "`json:\"number\"`" + `
+}
+
+func (CreditCard) shape() {}
+
+func (CreditCard) Kind() string { return "card" }
+
+type BankAccount struct {
+	IBAN string ` + "`json:\"iban\"`" + `
+}
+
+func (*BankAccount) shape() {}
+
+func (*BankAccount) Kind() string { return "bank" }
+
+type Unrelated struct{}
+
+type Owner struct {
+	Shape Shape ` + "`json:\"shape\"`" + `
+}
+`
+
+// The tests in this file do not run in parallel: discovery loads every
+// package of the module, including the fixtures other tests are writing.
+
+func TestImplementationsDiscovery(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		options string
+		values  []string
+	}{
+		{name: "type names", values: []string{"BankAccount", "CreditCard"}},
+		{name: "snake case", options: "jsonschema.SnakeCaseWireValues(),", values: []string{"bank_account", "credit_card"}},
+		{name: "method", options: `jsonschema.WireValueMethod("Kind"),`, values: []string{"bank", "card"}},
+		{name: "impl override", options: `jsonschema.Impl("cc", CreditCard{}),`, values: []string{"BankAccount", "cc"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			targetDir := writeAnnotationsFixture(t, discoveryFixtureTypes, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Shape,
+		jsonschema.Implementations(),
+		`+tc.options+`
+	),
+)
+`)
+			pkgs, err := syntax.Load(targetDir)
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			require.Empty(t, pkgs[0].Errors)
+
+			builder, err := New(pkgs[0])
+			require.NoError(t, err)
+			cfg := builder.IfaceV1["Owner"]["Shape"]
+			require.Equal(t, []syntax.TypeID{
+				{PkgPath: pkgs[0].PkgPath, TypeName: "BankAccount", Indirection: syntax.Pointer},
+				{PkgPath: pkgs[0].PkgPath, TypeName: "CreditCard"},
+			}, cfg.Impls)
+			require.Equal(t, tc.values, []string{cfg.DiscriminatorValues[cfg.Impls[0]], cfg.DiscriminatorValues[cfg.Impls[1]]})
+
+			code, err := builder.renderGoCode()
+			require.NoError(t, err)
+			require.Contains(t, string(code), "var obj BankAccount\n")
+		})
+	}
+}
+
+func TestImplementationsDiscoveryErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		types   string
+		options string
+		err     string
+	}{
+		{
+			name:    "collision",
+			options: `jsonschema.Implementations(), jsonschema.Impl("BankAccount", CreditCard{}),`,
+			err:     `CreditCard both have wire value "BankAccount"; set one with Impl(...)`,
+		},
+		{
+			name:    "method not constant",
+			types:   "\nfunc (Unrelated) shape() {}\n\nfunc (u Unrelated) Kind() string { return strings.ToLower(\"u\") }\n",
+			options: `jsonschema.Implementations(), jsonschema.WireValueMethod("Kind"),`,
+			err:     "method Unrelated.Kind must return a string constant to name a wire value",
+		},
+		{
+			name:    "naming without discovery",
+			options: "jsonschema.SnakeCaseWireValues(), jsonschema.Impl(\"cc\", CreditCard{}),",
+			err:     "SnakeCaseWireValues and WireValueMethod name discovered implementations; add Implementations()",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			targetDir := writeAnnotationsFixture(t, discoveryFixtureTypes+tc.types, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Shape,
+		`+tc.options+`
+	),
+)
+`)
+			pkgs, err := syntax.Load(targetDir)
+			if err == nil {
+				require.Len(t, pkgs, 1)
+				require.Empty(t, pkgs[0].Errors)
+				_, err = New(pkgs[0])
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestImplementationsDiscoveryAcrossPackages(t *testing.T) {
+	root := writeDiscoveryAcrossPackagesFixture(t)
+	pkgs, err := syntax.Load(filepath.Join(root, "workflow"))
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Step")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"object","properties":{"action":{"anyOf":[
+		{"type":"object","properties":{"type":{"type":"string","const":"email"},"to":{"type":"string"}},"required":["type","to"],"additionalProperties":false},
+		{"type":"object","properties":{"type":{"type":"string","const":"slack"},"channel":{"type":"string"}},"required":["type","channel"],"additionalProperties":false},
+		{"type":"object","properties":{"type":{"type":"string","const":"wait"},"seconds":{"type":"integer"}},"required":["type","seconds"],"additionalProperties":false}
+	]}},"required":["action"],"additionalProperties":false}`, string(data))
+
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	require.Contains(t, string(code), "var obj email.Send\n")
+	require.Contains(t, string(code), "case email.Send, *email.Send:")
+}
+
+func TestImplementationsDiscoveryRejectsUnimportablePackages(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		file    string
+		content string
+		err     string
+	}{
+		{
+			name:    "main package",
+			file:    "cmd/run/main.go",
+			content: "package main\n\nimport \"" + fixturePath + "/actions\"\n\ntype Dry struct{}\n\nfunc (Dry) Run() error { return nil }\n\nfunc (Dry) Kind() actions.Kind { return \"dry\" }\n\nfunc main() {}\n",
+			err:     "/cmd/run.Dry of Action is in a main package",
+		},
+		{
+			name:    "import cycle",
+			file:    "actions/retry/retry.go",
+			content: "package retry\n\nimport (\n\t\"" + fixturePath + "/actions\"\n\t\"" + fixturePath + "/workflow\"\n)\n\ntype Retry struct{ Step workflow.Step }\n\nfunc (Retry) Run() error { return nil }\n\nfunc (Retry) Kind() actions.Kind { return \"retry\" }\n",
+			err:     "/actions/retry.Retry of Action is in a package that imports",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			root := writeDiscoveryAcrossPackagesFixture(t)
+			writeFixtureFiles(t, root, map[string]string{tc.file: tc.content})
+
+			pkgs, err := syntax.Load(filepath.Join(root, "workflow"))
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			require.Empty(t, pkgs[0].Errors)
+			_, err = New(pkgs[0])
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+// writeDiscoveryAcrossPackagesFixture extends the sibling packages fixture so
+// that Step discovers its actions. The email package is imported by nothing
+// in the fixture. Action takes a Kind declared in the fixture, so that types
+// elsewhere in the module do not implement it.
+func writeDiscoveryAcrossPackagesFixture(t *testing.T) string {
+	t.Helper()
+
+	root := writeRemoteInterfaceImplsFixture(t)
+	// Slack's Kind is overridden by its Impl option.
+	writeFixtureFiles(t, root, map[string]string{
+		"actions/actions.go":    "package actions\n\ntype Kind string\n\ntype Action interface {\n\tRun() error\n\tKind() Kind\n}\n",
+		"actions/email/kind.go": "package email\n\nimport \"" + fixturePath + "/actions\"\n\nfunc (Send) Kind() actions.Kind { return \"email\" }\n",
+		"actions/slack/kind.go": "package slack\n\nimport \"" + fixturePath + "/actions\"\n\nfunc (*Post) Kind() actions.Kind { return \"post\" }\n",
+		"workflow/kind.go":      "package workflow\n\nimport \"" + fixturePath + "/actions\"\n\nconst waitKind = \"wait\"\n\nfunc (Wait) Kind() actions.Kind { return waitKind }\n",
+		"workflow/schema.go": `//go:build jsonschema
+
+package workflow
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+	"` + fixturePath + `/actions/slack"
+)
+
+func (Step) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Step.Schema,
+	jsonschema.WithInterface(Step{}.Action,
+		jsonschema.Implementations(),
+		jsonschema.WireValueMethod("Kind"),
+		jsonschema.Impl("slack", (*slack.Post)(nil)),
+	),
+)
+`,
+	})
+	return root
+}
@@ -2,8 +2,10 @@ package builder
 
 import (
 	"fmt"
+	"go/types"
 
 	"github.com/dave/dst/decorator"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
 )
 
 // ImportMap helps with code generation by storing a list of packages
@@ -12,10 +14,11 @@ import (
 type ImportMap struct {
 	localPackage *decorator.Package
 	aliasCount   int
-	types        []struct {
-		alias string
-		pkg   *decorator.Package
-	}
+	types        []importEntry
+}
+
+type importEntry struct {
+	alias, name, path string
 }
 
 func (m *ImportMap) LocalPkgName() string {
@@ -30,27 +33,28 @@ func NewImportMap(localPackage *decorator.Package) *ImportMap {
 // there.  Adds an alias if the alias has already been found.  Keeps a simple
 // counter for creating very simple aliases.
 func (m *ImportMap) AddPackage(pkg *decorator.Package) {
-	if m.localPackage.ID == pkg.ID {
+	m.add(pkg.PkgPath, pkg.Name)
+}
+
+func (m *ImportMap) add(path, name string) {
+	if m.localPackage.PkgPath == path {
 		return
 	}
 	haveName := false
 
-	newObj := struct {
-		alias string
-		pkg   *decorator.Package
-	}{pkg: pkg}
+	newObj := importEntry{name: name, path: path}
 
 	for _, t := range m.types {
-		if t.pkg.ID == pkg.ID {
+		if t.path == path {
 			return
 		}
-		if t.pkg.Name == pkg.Name {
+		if t.name == name {
 			haveName = true
 		}
 	}
 	if haveName {
 		m.aliasCount++
-		newObj.alias = fmt.Sprintf("%s%d", pkg.Name, m.aliasCount)
+		newObj.alias = fmt.Sprintf("%s%d", name, m.aliasCount)
 	}
 	m.types = append(m.types, newObj)
 }
@@ -60,28 +64,55 @@ func (m *ImportMap) AddPackage(pkg *decorator.Package) {
 // expression using the right package name prefix/alias (or none if the
 // expression refers to an identifier defined in the local package).
 func (m *ImportMap) PrefixExpr(expr string, pkg *decorator.Package) string {
-	if pkg.ID == m.localPackage.ID {
+	if pkg.PkgPath == m.localPackage.PkgPath {
 		return expr
 	}
 	return fmt.Sprintf("%s.%s", m.Alias(pkg), expr)
 }
 
+// Qualifier returns a types.Qualifier for printing type names in generated
+// code. Each package it is asked about is added to the map. The schema
+// package is always imported as genjsonschema.
+func (m *ImportMap) Qualifier() types.Qualifier {
+	return func(pkg *types.Package) string {
+		switch pkg.Path() {
+		case m.localPackage.PkgPath:
+			return ""
+		case syntax.SchemaPackagePath:
+			return "genjsonschema"
+		case "context", "encoding/json", "errors", "fmt":
+			// Imported by the template whenever tools are generated.
+			return pkg.Name()
+		}
+		m.add(pkg.Path(), pkg.Name())
+		return m.alias(pkg.Path())
+	}
+}
+
 func (m *ImportMap) ImportStatements() []string {
 	var result []string
 	for _, t := range m.types {
 		// Note that we'll use `goimports` on this file later so imports will be
 		// cleaned up and ordered.  Don't worry about the extra whitespace here.
-		result = append(result, fmt.Sprintf("%s \"%s\"", t.pkg.Name, t.pkg.PkgPath))
+		name := t.name
+		if t.alias != "" {
+			name = t.alias
+		}
+		result = append(result, fmt.Sprintf("%s \"%s\"", name, t.path))
 	}
 
 	return result
 }
 
 func (m *ImportMap) Alias(pkg *decorator.Package) string {
+	return m.alias(pkg.PkgPath)
+}
+
+func (m *ImportMap) alias(path string) string {
 	for _, t := range m.types {
-		if t.pkg.ID == pkg.ID {
+		if t.path == path {
 			if t.alias == "" {
-				return pkg.Name
+				return t.name
 			}
 			return t.alias
 		}
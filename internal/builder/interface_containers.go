@@ -0,0 +1,198 @@
+package builder
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+type InterfaceContainerKind int
+
+const (
+	SliceContainer InterfaceContainerKind = iota
+	ArrayContainer
+	MapContainer
+)
+
+// InterfaceContainer is one slice, array or map level between a registered
+// interface field and its interface values. A field lists its containers
+// outermost first.
+type InterfaceContainer struct {
+	Kind InterfaceContainerKind
+	// TypeName and KeyType are the level's type and a map's key type as
+	// written in generated code.
+	TypeName string
+	KeyType  string
+
+	goType  types.Type
+	keyType types.Type
+}
+
+func (c InterfaceContainer) IsArray() bool { return c.Kind == ArrayContainer }
+
+func (c InterfaceContainer) IsMap() bool { return c.Kind == MapContainer }
+
+// interfaceContainers unwraps the slices, arrays and maps around a field's
+// type. It returns the containers and the named interface they hold, or a
+// nil interface when typ is not such a type. A named container type that
+// marshals itself is not unwrapped.
+func interfaceContainers(typ types.Type) ([]InterfaceContainer, *types.Named) {
+	var containers []InterfaceContainer
+	for typ != nil {
+		named, isNamed := types.Unalias(typ).(*types.Named)
+		switch u := typ.Underlying().(type) {
+		case *types.Interface:
+			return containers, named
+		case *types.Slice, *types.Array, *types.Map:
+			if isNamed && hasMarshalMethods(types.NewPointer(named)) {
+				return nil, nil
+			}
+			container := InterfaceContainer{goType: typ}
+			switch u := u.(type) {
+			case *types.Slice:
+				typ = u.Elem()
+			case *types.Array:
+				container.Kind = ArrayContainer
+				typ = u.Elem()
+			case *types.Map:
+				container.Kind = MapContainer
+				container.keyType = u.Key()
+				typ = u.Elem()
+			}
+			containers = append(containers, container)
+		default:
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// goTypeOf returns the type that the type checker recorded for expr.
+func goTypeOf(expr syntax.Expr) types.Type {
+	pkg := expr.Pkg()
+	astExpr, ok := pkg.Decorator.Map.Ast.Nodes[expr.Expr()].(ast.Expr)
+	if !ok || pkg.TypesInfo == nil {
+		return nil
+	}
+	return pkg.TypesInfo.TypeOf(astExpr)
+}
+
+// wrapInterfaceContainers nests a registered interface's union schema in the
+// field's containers: arrays for slices and arrays, and open objects for
+// maps, whose keys follow the rules for map keys elsewhere.
+func (s SchemaBuilder) wrapInterfaceContainers(union UnionTypeNode, containers []InterfaceContainer, prop syntax.StructField, seen syntax.SeenTypes) (JSONSchema, error) {
+	var schema JSONSchema = union
+	for i := len(containers) - 1; i >= 0; i-- {
+		var desc string
+		if i == 0 {
+			desc = prop.Comments()
+		}
+		switch containers[i].Kind {
+		case MapContainer:
+			propertyNames, err := s.renderMapKeyTypeSchema(prop.TypeExpr, containers[i].keyType, seen)
+			if err != nil {
+				return nil, err
+			}
+			schema = MapNode{Desc: desc, PropertyNames: propertyNames, Values: schema, TypeID_: prop.ID()}
+		default:
+			schema = ArrayNode{Desc: desc, Items: schema, TypeID_: prop.ID()}
+		}
+	}
+	return schema, nil
+}
+
+// qualifyContainers writes the containers' types as generated code names
+// them.
+func qualifyContainers(containers []InterfaceContainer, qualify types.Qualifier) []InterfaceContainer {
+	containers = append([]InterfaceContainer(nil), containers...)
+	for i := range containers {
+		containers[i].TypeName = types.TypeString(containers[i].goType, qualify)
+		if containers[i].keyType != nil {
+			containers[i].KeyType = types.TypeString(containers[i].keyType, qualify)
+		}
+	}
+	return containers
+}
+
+// InterfaceCodec is one step of a generated MarshalJSON or UnmarshalJSON
+// through an interface field: Value is the Go value at this level of the
+// field's containers and Raw its JSON. Path and PathArgs locate the value
+// in error messages.
+type InterfaceCodec struct {
+	Prop     InterfaceProp
+	Index    int
+	Depth    int
+	Value    string
+	Raw      string
+	Path     string
+	PathArgs string
+}
+
+// Codec starts the walk through prop, the index'th interface field of its
+// struct, between the field's value and its JSON.
+func (p InterfaceProp) Codec(index int, value, raw string) InterfaceCodec {
+	return InterfaceCodec{Prop: p, Index: index, Value: value, Raw: raw, Path: "field " + p.JSONName()}
+}
+
+// Container is the container at this level, or nil for an interface value.
+func (c InterfaceCodec) Container() *InterfaceContainer {
+	if c.Depth < len(c.Prop.Containers) {
+		return &c.Prop.Containers[c.Depth]
+	}
+	return nil
+}
+
+// Var names a variable of this level, unique within the generated method.
+func (c InterfaceCodec) Var(name string) string {
+	return fmt.Sprintf("%s%d%s", name, c.Index, c.suffix())
+}
+
+// Loop names a loop variable of this level.
+func (c InterfaceCodec) Loop(name string) string {
+	return name + c.suffix()
+}
+
+func (c InterfaceCodec) suffix() string {
+	if c.Depth == 0 {
+		return ""
+	}
+	return fmt.Sprintf("_%d", c.Depth)
+}
+
+// DecodeItem is the next level down when decoding: an element or map value
+// of this level's container.
+func (c InterfaceCodec) DecodeItem() InterfaceCodec {
+	if c.Container().Kind == MapContainer {
+		return c.item(fmt.Sprintf("%s[%s]", c.Var("__decoded"), c.Loop("__key")), c.Loop("__raw"))
+	}
+	return c.item(fmt.Sprintf("%s[%s]", c.Var("__decoded"), c.Loop("__index")), c.Loop("__raw"))
+}
+
+// EncodeItem is the next level down when encoding.
+func (c InterfaceCodec) EncodeItem() InterfaceCodec {
+	if c.Container().Kind == MapContainer {
+		return c.item(c.Loop("__value"), c.Loop("__item"))
+	}
+	return c.item(c.Loop("__value"), fmt.Sprintf("%s[%s]", c.Var("__raw"), c.Loop("__index")))
+}
+
+func (c InterfaceCodec) item(value, raw string) InterfaceCodec {
+	next := c
+	next.Depth++
+	next.Value = value
+	next.Raw = raw
+	if c.Container().Kind == MapContainer {
+		verb := "%v"
+		if basic, ok := c.Container().keyType.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+			verb = "%q"
+		}
+		next.Path += "[" + verb + "]"
+		next.PathArgs += ", " + c.Loop("__key")
+	} else {
+		next.Path += "[%d]"
+		next.PathArgs += ", " + c.Loop("__index")
+	}
+	return next
+}
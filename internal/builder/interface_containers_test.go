@@ -0,0 +1,108 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestV1InterfaceContainers(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Variant interface{ variant() }
+
+type First struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+func (First) variant() {}
+
+type Variants []Variant
+
+type Color string
+
+const (
+	Red  Color = "red"
+	Blue Color = "blue"
+)
+
+var _ = jsonschema.NewEnumType[Color]()
+
+type Owner struct {
+	Pair    [2]Variant                     `+"`json:\"pair\"`"+`
+	Named   Variants                       `+"`json:\"named\"`"+`
+	Maybe   jsonschema.Optional[[]Variant] `+"`json:\"maybe,omitzero\"`"+`
+	ByColor map[Color][]Variant            `+"`json:\"by_color\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Pair),
+	jsonschema.WithInterfaceImpls(Owner{}.Pair, First{}),
+	jsonschema.WithInterface(Owner{}.Named),
+	jsonschema.WithInterfaceImpls(Owner{}.Named, First{}),
+	jsonschema.WithInterface(Owner{}.Maybe),
+	jsonschema.WithInterfaceImpls(Owner{}.Maybe, First{}),
+	jsonschema.WithInterface(Owner{}.ByColor),
+	jsonschema.WithInterfaceImpls(Owner{}.ByColor, First{}),
+	jsonschema.WithDiscriminator(Owner{}.ByColor, "kind"),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Owner")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+
+	first := func(disc string) string {
+		return `{"anyOf":[{"type":"object","properties":{"` + disc + `":{"type":"string","const":"First"},"name":{"type":"string"}},"required":["` + disc + `","name"],"additionalProperties":false}]}`
+	}
+	require.JSONEq(t, `{"type":"object","properties":{
+		"pair":{"type":"array","items":`+first("type")+`},
+		"named":{"type":"array","items":`+first("type")+`},
+		"maybe":{"type":"array","items":`+first("type")+`},
+		"by_color":{"type":"object","propertyNames":{"type":"string","enum":["red","blue"]},"additionalProperties":{"type":"array","items":`+first("kind")+`}}
+	},"required":["pair","named","by_color"],"additionalProperties":false}`, string(data))
+
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	require.Contains(t, string(code), "var __decoded0 [2]Variant")
+	require.Contains(t, string(code), "__decoded1 = make(Variants, len(__raw1))")
+	require.Contains(t, string(code), "__next.Maybe.Value = __decoded2")
+	require.Contains(t, string(code), "var __raw3 map[Color]json.RawMessage")
+	require.Contains(t, string(code), `return fmt.Errorf("field by_color[%q][%d]: %w", __key, __index_1, err)`)
+}
+
+func TestInterfaceContainerMapKeys(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Variant interface{ variant() }
+
+type First struct{}
+
+func (First) variant() {}
+
+type Owner struct {
+	ByFlag map[bool]Variant `+"`json:\"by_flag\"`"+`
+}
+
+var _ = jsonschema.NewInterfaceImpl[Variant](First{})
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	_, err = New(pkgs[0])
+	require.ErrorContains(t, err, "map key type bool must be string-kinded or implement encoding.TextMarshaler")
+}
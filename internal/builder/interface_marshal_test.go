@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestMarshalJSONOmitsNilInterfaceWithOmitempty(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Shape interface{ shape() }
+
+type Circle struct {
+	Radius float64 `+"`json:\"radius\"`"+`
+}
+
+func (Circle) shape() {}
+
+type Owner struct {
+	Shape Shape `+"`json:\"shape\"`"+`
+	Hint  Shape `+"`json:\"hint,omitempty\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Shape, jsonschema.Impl("circle", Circle{})),
+	jsonschema.WithInterface(Owner{}.Hint, jsonschema.Impl("circle", Circle{})),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	source := string(code)
+	// A nil Hint leaves its json.RawMessage empty, which omitempty drops;
+	// a nil Shape is still written as null.
+	require.Contains(t, source, "if o.Hint != nil {\n\t\tif wrapper.Hint, err = ")
+	require.Contains(t, source, "\tif wrapper.Shape, err = ")
+	require.NotContains(t, source, "if o.Shape != nil {")
+}
@@ -0,0 +1,116 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestExternallyAndAdjacentlyTaggedInterfaces(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, untaggedFixtureTypes, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Shape,
+		jsonschema.ExternallyTagged(),
+		jsonschema.Impl("circle", Circle{}),
+		jsonschema.Impl("square", (*Square)(nil)),
+	),
+	jsonschema.WithInterface(Owner{}.Shapes,
+		jsonschema.Discriminator("kind"),
+		jsonschema.AdjacentlyTagged("data"),
+		jsonschema.Impl("circle", Circle{}),
+		jsonschema.Impl("square", (*Square)(nil)),
+	),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	typeID := syntax.TypeID{PkgPath: pkgs[0].PkgPath, TypeName: "Owner"}
+	schema, ok := builder.schemas.Get(typeID.PkgPath, typeID.TypeName)
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"object","properties":{
+		"shape":{"anyOf":[
+			{"type":"object","properties":{"circle":{"type":"object","properties":{"radius":{"type":"number"}},"required":["radius"],"additionalProperties":false}},"required":["circle"],"additionalProperties":false},
+			{"type":"object","properties":{"square":{"type":"object","properties":{"side":{"type":"number"}},"required":["side"],"additionalProperties":false}},"required":["square"],"additionalProperties":false}
+		]},
+		"shapes":{"type":"array","items":{"anyOf":[
+			{"type":"object","properties":{"kind":{"type":"string","const":"circle"},"data":{"type":"object","properties":{"radius":{"type":"number"}},"required":["radius"],"additionalProperties":false}},"required":["kind","data"],"additionalProperties":false},
+			{"type":"object","properties":{"kind":{"type":"string","const":"square"},"data":{"type":"object","properties":{"side":{"type":"number"}},"required":["side"],"additionalProperties":false}},"required":["kind","data"],"additionalProperties":false}
+		]}}
+	},"required":["shape","shapes"],"additionalProperties":false}`, string(data))
+
+	hardlines, err := marshalSchemaHardlines(schema)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(hardlines))
+
+	root, ok := builder.GetSchema(typeID)
+	require.True(t, ok)
+	_, err = DialectOpenAIStrict.rewrite(builder, root, "Owner")
+	require.NoError(t, err)
+	_, err = DialectGemini.rewrite(builder, root, "Owner")
+	require.NoError(t, err)
+
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	source := string(code)
+	require.Contains(t, source, "} else if len(temp) != 1 {\n\t\treturn nil, fmt.Errorf(\"expected one property naming the implementation of Shape, found %d\", len(temp))")
+	require.Contains(t, source, "return __jsonschema__marshalTagged(\"\", discriminator, \"\", value)")
+	require.Contains(t, source, "} else if data, ok = temp[\"data\"]; !ok {")
+	require.Contains(t, source, "return __jsonschema__marshalTagged(\"kind\", discriminator, \"data\", value)")
+}
+
+func TestInterfaceTaggingOptionConflicts(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		options string
+		err     string
+	}{
+		"external with discriminator": {
+			options: `jsonschema.ExternallyTagged(), jsonschema.Discriminator("kind"),`,
+			err:     "field Owner.Shape: an ExternallyTagged interface has no discriminator property to set",
+		},
+		"two styles": {
+			options: `jsonschema.Untagged(), jsonschema.AdjacentlyTagged("data"),`,
+			err:     "field Owner.Shape: Untagged, ExternallyTagged and AdjacentlyTagged are mutually exclusive",
+		},
+		"empty content": {
+			options: `jsonschema.AdjacentlyTagged(""),`,
+			err:     "adjacently tagged option requires a content property name",
+		},
+		"content is the tag": {
+			options: `jsonschema.AdjacentlyTagged("type"),`,
+			err:     `field Owner.Shape: AdjacentlyTagged content property "type" is also the discriminator property`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			targetDir := writeAnnotationsFixture(t, untaggedFixtureTypes, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Shape, `+tc.options+`
+		jsonschema.Impl("circle", Circle{}),
+	),
+	jsonschema.WithInterface(Owner{}.Shapes),
+	jsonschema.WithInterfaceImpls(Owner{}.Shapes, Circle{}),
+)
+`)
+			pkgs, err := syntax.Load(targetDir)
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			require.Empty(t, pkgs[0].Errors)
+
+			_, err = New(pkgs[0])
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
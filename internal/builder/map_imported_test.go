@@ -0,0 +1,92 @@
+package builder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestMapFieldsResolveImportedTypes(t *testing.T) {
+	t.Parallel()
+
+	root := writeImportedMapFixture(t)
+	pkgs, err := syntax.Load(root)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Ledger")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.JSONEq(t, `{"type":"object",
+		"propertyNames":{"type":"string","enum":["EUR","USD"]},
+		"additionalProperties":{"type":"integer"}}`, string(got.Properties["balances"]))
+	require.JSONEq(t, `{"type":"object","additionalProperties":{"type":"object",
+		"properties":{"cents":{"type":"integer"},"currency":{"type":"string","enum":["EUR","USD"]}},
+		"required":["cents","currency"],"additionalProperties":false}}`, string(got.Properties["totals"]))
+}
+
+// writeImportedMapFixture writes a package whose Ledger type reaches the
+// types of its domain subpackage only through map keys and values.
+func writeImportedMapFixture(t *testing.T) string {
+	t.Helper()
+
+	files := map[string]string{
+		"types.go": `package ledger
+
+import "` + fixturePath + `/domain"
+
+type Ledger struct {
+	Balances map[domain.Currency]int    ` + "`json:\"balances\"`" + `
+	Totals   map[string]domain.Money    ` + "`json:\"totals\"`" + `
+}
+`,
+		"schema.go": `//go:build jsonschema
+
+package ledger
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Ledger) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Ledger.Schema)
+`,
+		"domain/money.go": `package domain
+
+type Money struct {
+	Cents    int      ` + "`json:\"cents\"`" + `
+	Currency Currency ` + "`json:\"currency\"`" + `
+}
+
+type Currency string
+
+const (
+	EUR Currency = "EUR"
+	USD Currency = "USD"
+)
+`,
+		"domain/schema.go": `//go:build jsonschema
+
+package domain
+
+import jsonschema "github.com/tylergannon/go-gen-jsonschema"
+
+var _ = jsonschema.NewEnumType[Currency]()
+`,
+	}
+	return writeFixture(t, "imported_map_", files)
+}
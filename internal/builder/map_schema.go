@@ -0,0 +1,143 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+// textMarshalerType is encoding.TextMarshaler, built here so key types can be
+// checked without loading the encoding package.
+var textMarshalerType = func() *types.Interface {
+	results := types.NewTuple(
+		types.NewVar(0, nil, "", types.NewSlice(types.Typ[types.Byte])),
+		types.NewVar(0, nil, "", types.Universe.Lookup("error").Type()),
+	)
+	sig := types.NewSignatureType(nil, nil, nil, nil, results, false)
+	iface := types.NewInterfaceType([]*types.Func{types.NewFunc(0, nil, "MarshalText", sig)}, nil)
+	iface.Complete()
+	return iface
+}()
+
+// renderMapSchema renders a Go map as an object whose values all share one
+// schema. Keys follow encoding/json: they must be string-kinded or implement
+// encoding.TextMarshaler.
+func (s SchemaBuilder) renderMapSchema(t syntax.TypeExpr, node *dst.MapType, description string, seen syntax.SeenTypes) (JSONSchema, error) {
+	propertyNames, err := s.renderMapKeySchema(t, node.Key, seen)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.renderSchema(t.Derive(node.Value), "", seen)
+	if err != nil {
+		return nil, err
+	}
+	if _, isUnion := values.(UnionTypeNode); isUnion {
+		return nil, fmt.Errorf("%s at %s", unsupportedRegisteredInterfaceContainer, t.Position())
+	}
+	return MapNode{
+		Desc:          description,
+		PropertyNames: propertyNames,
+		Values:        values,
+		TypeID_:       t.ID(),
+	}, nil
+}
+
+// renderMapKeySchema returns the propertyNames schema for a map key, or nil
+// when any property name is allowed. Keys drawn from a registered string enum
+// are restricted to the enum's values.
+func (s SchemaBuilder) renderMapKeySchema(t syntax.TypeExpr, key dst.Expr, seen syntax.SeenTypes) (JSONSchema, error) {
+	var keyType types.Type
+	switch k := key.(type) {
+	case *dst.Ident:
+		keyType = lookupGoType(k, t.Pkg())
+	case *dst.StarExpr:
+		if x, ok := k.X.(*dst.Ident); ok {
+			if named := lookupGoType(x, t.Pkg()); named != nil {
+				keyType = types.NewPointer(named)
+			}
+		}
+	}
+	if keyType == nil {
+		return nil, fmt.Errorf("unsupported map key type %s at %s", t.Name(), t.Position())
+	}
+	return s.renderMapKeyTypeSchema(t, keyType, seen)
+}
+
+// renderMapKeyTypeSchema is renderMapKeySchema for a key type already
+// resolved by the type checker.
+func (s SchemaBuilder) renderMapKeyTypeSchema(t syntax.TypeExpr, keyType types.Type, seen syntax.SeenTypes) (JSONSchema, error) {
+	if basic, ok := keyType.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+		named, ok := types.Unalias(keyType).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return nil, nil
+		}
+		return s.renderEnumKeySchema(t, syntax.TypeID{TypeName: named.Obj().Name(), PkgPath: named.Obj().Pkg().Path()}, seen)
+	}
+	if types.Implements(keyType, textMarshalerType) {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("map key type %s must be string-kinded or implement encoding.TextMarshaler at %s", keyType, t.Position())
+}
+
+func (s SchemaBuilder) renderEnumKeySchema(t syntax.TypeExpr, keyID syntax.TypeID, seen syntax.SeenTypes) (JSONSchema, error) {
+	scan, ok := s.Scan.GetPackage(keyID.PkgPath)
+	if !ok {
+		return nil, nil
+	}
+	if _, isEnum := scan.Constants[keyID.TypeName]; !isEnum {
+		return nil, nil
+	}
+	if err := s.mapType(keyID, seen.See(t.ID())); err != nil {
+		return nil, err
+	}
+	schema, _ := s.GetSchema(keyID)
+	enum, ok := schema.(PropertyNode[string])
+	if !ok || len(enum.Enum) == 0 {
+		return nil, nil
+	}
+	return PropertyNode[string]{Typ: "string", Enum: enum.Enum, TypeID_: keyID}, nil
+}
+
+// lookupGoType resolves a type name as seen from local, following the
+// package's direct imports for qualified names.
+func lookupGoType(ident *dst.Ident, local *decorator.Package) types.Type {
+	scope := types.Universe
+	switch {
+	case ident.Path == "" || ident.Path == local.PkgPath:
+		if local.Types != nil {
+			scope = local.Types.Scope()
+		}
+	default:
+		imported := importedPackage(local, ident.Path)
+		if imported == nil {
+			return nil
+		}
+		scope = imported.Scope()
+	}
+	obj := scope.Lookup(ident.Name)
+	if obj == nil {
+		obj = types.Universe.Lookup(ident.Name)
+	}
+	typeName, ok := obj.(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return typeName.Type()
+}
+
+// importedPackage returns the package local imports as pkgPath. The loader
+// does not populate local.Imports, but the type checker records every import.
+func importedPackage(local *decorator.Package, pkgPath string) *types.Package {
+	if local.Types == nil {
+		return nil
+	}
+	for _, imported := range local.Types.Imports() {
+		if imported.Path() == pkgPath {
+			return imported
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package builder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+const mapFixtureTypes = `
+type Color string
+
+const (
+	Red  Color = "red"
+	Blue Color = "blue"
+)
+
+type Label string
+
+type Point struct {
+	X int ` + "`json:\"x\"`" + `
+}
+
+type Coord struct{ X, Y int }
+
+func (c Coord) MarshalText() ([]byte, error) { return nil, nil }
+
+type Counts map[string]int
+
+type Owner struct {
+	Scores   map[string]float64                 ` + "`json:\"scores\"`" + `
+	Labels   map[Label]string                   ` + "`json:\"labels\"`" + `
+	ByColor  map[Color]Point                    ` + "`json:\"byColor\"`" + `
+	ByCoord  map[Coord]bool                     ` + "`json:\"byCoord\"`" + `
+	Counts   Counts                             ` + "`json:\"counts\"`" + `
+	Nested   map[string][]string                ` + "`json:\"nested\"`" + `
+	Maybe    jsonschema.Optional[map[string]int] ` + "`json:\"maybe,omitzero\"`" + `
+	Nullable jsonschema.Nullable[map[string]int] ` + "`json:\"nullable\"`" + `
+}
+`
+
+func TestMapFieldsRenderAdditionalProperties(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeMapFixture(t, mapFixtureTypes, `var _ = jsonschema.NewEnumType[Color]()`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Owner")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.JSONEq(t, `{"type":"object","additionalProperties":{"type":"number"}}`, string(got.Properties["scores"]))
+	require.JSONEq(t, `{"type":"object","additionalProperties":{"type":"string"}}`, string(got.Properties["labels"]))
+	require.JSONEq(t, `{"type":"object","propertyNames":{"type":"string","enum":["red","blue"]},
+		"additionalProperties":{"type":"object","properties":{"x":{"type":"integer"}},"required":["x"],"additionalProperties":false}}`,
+		string(got.Properties["byColor"]))
+	require.JSONEq(t, `{"type":"object","additionalProperties":{"type":"boolean"}}`, string(got.Properties["byCoord"]))
+	require.JSONEq(t, `{"type":"object","additionalProperties":{"type":"integer"}}`, string(got.Properties["counts"]))
+	require.JSONEq(t, `{"type":"object","additionalProperties":{"type":"array","items":{"type":"string"}}}`, string(got.Properties["nested"]))
+	require.JSONEq(t, `{"type":"object","additionalProperties":{"type":"integer"}}`, string(got.Properties["maybe"]))
+	require.JSONEq(t, `{"anyOf":[{"type":"object","additionalProperties":{"type":"integer"}},{"type":"null"}]}`, string(got.Properties["nullable"]))
+	require.NotContains(t, got.Required, "maybe")
+
+	err = builder.LintStrict()
+	require.ErrorContains(t, err, "dialect openai-strict: Owner.scores: open objects (maps) cannot be expressed")
+	require.ErrorContains(t, err, "dialect openai-strict: Owner.nullable: open objects (maps) cannot be expressed")
+	require.ErrorContains(t, err, "dialect openai-strict: Owner.maybe: optional properties cannot be expressed")
+}
+
+func TestMapKeyDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		field     string
+		wantError string
+	}{
+		{
+			name:      "integer key",
+			field:     `Values map[int]string ` + "`json:\"values\"`",
+			wantError: "map key type int must be string-kinded or implement encoding.TextMarshaler",
+		},
+		{
+			name:      "struct key without MarshalText",
+			field:     `Values map[Plain]string ` + "`json:\"values\"`",
+			wantError: "must be string-kinded or implement encoding.TextMarshaler",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			targetDir := writeMapFixture(t, `
+type Plain struct{ A int }
+
+type Owner struct {
+	`+tc.field+`
+}
+`, "")
+			pkgs, err := syntax.Load(targetDir)
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			require.Empty(t, pkgs[0].Errors)
+
+			_, err = New(pkgs[0])
+			require.ErrorContains(t, err, tc.wantError)
+			require.Contains(t, err.Error(), targetDir)
+		})
+	}
+}
+
+func writeMapFixture(t *testing.T, types, registrations string) string {
+	t.Helper()
+
+	source := `//go:build jsonschema
+
+package fixture
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+` + types + `
+func (Owner) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
+` + registrations + "\n"
+	return writeFixture(t, "map_fields_", map[string]string{"schema.go": source})
+}
@@ -58,6 +58,27 @@ func (a *Assertion) UnmarshalJSON(data []byte) (err error) {
 	*a = __next
 	return nil
 }
+
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// Assertion. Interface values are written with their discriminator.
+func (a Assertion) MarshalJSON() ([]byte, error) {
+	type Alias Assertion
+	type Wrapper struct {
+		Alias
+		Value json.RawMessage `json:"value"`
+	}
+	var (
+		wrapper = Wrapper{Alias: Alias(a)}
+		err     error
+	)
+
+	if wrapper.Value, err = __jsonMarshal__messages__AssertionValue(a.Value); err != nil {
+		return nil, fmt.Errorf("field value: %w", err)
+	}
+
+	return json.Marshal(wrapper)
+}
+
 func __jsonUnmarshal__messages__AssertionValue(data []byte) (AssertionValue, error) {
 	var (
 		temp          map[string]json.RawMessage
@@ -108,6 +129,68 @@ func __jsonUnmarshal__messages__AssertionValue(data []byte) (AssertionValue, err
 	}
 }
 
+func __jsonMarshal__messages__AssertionValue(value AssertionValue) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case AssertNumericValue, *AssertNumericValue:
+		discriminator = "AssertNumericValue"
+	case AssertStringValue, *AssertStringValue:
+		discriminator = "AssertStringValue"
+	case AssertBoolValue, *AssertBoolValue:
+		discriminator = "AssertBoolValue"
+	case AssertType, *AssertType:
+		discriminator = "AssertType"
+	case AssertArrayLength, *AssertArrayLength:
+		discriminator = "AssertArrayLength"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of AssertionValue: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("type", discriminator, value)
+}
+
 func __jsonschema__unmarshalDiscriminatorError(discriminator json.RawMessage, err error) error {
 	return fmt.Errorf("unable to unmarshal discriminator value %v: %w", discriminator, err)
 }
+
+// __jsonschema__marshalWithDiscriminator encodes value, which must be a JSON
+// object, with the discriminator property written first. A value whose own
+// MarshalJSON already writes the discriminator is kept as it is.
+func __jsonschema__marshalWithDiscriminator(propName, discriminator string, value any) (json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("%T must marshal to a JSON object to carry discriminator %q", value, discriminator)
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if existing, ok := fields[propName]; ok {
+		var written string
+		if err = json.Unmarshal(existing, &written); err != nil || written != discriminator {
+			return nil, fmt.Errorf("%T writes %s %s, want %q", value, propName, existing, discriminator)
+		}
+		return data, nil
+	}
+	key, err := json.Marshal(propName)
+	if err != nil {
+		return nil, err
+	}
+	val, err := json.Marshal(discriminator)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)+len(key)+len(val)+2)
+	out = append(out, '{')
+	out = append(out, key...)
+	out = append(out, ':')
+	out = append(out, val...)
+	if data[1] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, data[1:]...), nil
+}
@@ -33,6 +33,10 @@ type (
 		Name     string
 		Schema   JSONSchema
 		Optional bool
+		// Default and Examples are JSON values added beside the property's
+		// schema keywords.
+		Default  json.RawMessage
+		Examples []json.RawMessage
 	}
 
 	ObjectPropSet []ObjectProp
@@ -57,6 +61,22 @@ type (
 		Typ      string        `json:"type,omitempty"`
 		Nullable bool          `json:"-"`
 		TypeID_  syntax.TypeID `json:"-"`
+
+		// Validation keywords from the field's jsonschema tag. Numeric bounds
+		// apply to integer and number properties; length and pattern to strings.
+		Minimum   *float64 `json:"minimum,omitempty"`
+		Maximum   *float64 `json:"maximum,omitempty"`
+		MinLength *int     `json:"minLength,omitempty"`
+		MaxLength *int     `json:"maxLength,omitempty"`
+		Pattern   string   `json:"pattern,omitempty"`
+
+		// Formats holds the string format. Several formats mean the value
+		// may match any one of them.
+		Formats []string `json:"-"`
+
+		// EnumDescriptions holds a description per Enum value. When set, the
+		// values are written as an "anyOf" of described "const" schemas.
+		EnumDescriptions []string `json:"-"`
 	}
 
 	// NullableObjectNode represents a nullable inlined object schema.
@@ -76,20 +96,50 @@ type (
 	}
 
 	ArrayNode struct {
-		Desc    string        `json:"description,omitempty"`
-		Items   JSONSchema    `json:"items,omitempty"`
-		TypeID_ syntax.TypeID `json:"-"`
+		Desc     string        `json:"description,omitempty"`
+		Items    JSONSchema    `json:"items,omitempty"`
+		MinItems *int          `json:"minItems,omitempty"`
+		MaxItems *int          `json:"maxItems,omitempty"`
+		TypeID_  syntax.TypeID `json:"-"`
+	}
+
+	// MapNode is an open object keyed by arbitrary property names, rendered
+	// from a Go map. PropertyNames is set when the keys are a registered enum.
+	MapNode struct {
+		Desc          string
+		PropertyNames JSONSchema
+		Values        JSONSchema
+		TypeID_       syntax.TypeID
 	}
 
 	// UnionTypeNode means `{"anyOf": [ <object1-with-discriminator>, ... ]}`.
+	// An Untagged union lists its options without the discriminator, an
+	// ExternallyTagged one wraps each option in a single property named by
+	// its discriminator, and one with a ContentPropName puts the option in
+	// that property beside the discriminator. A Nullable union also lists
+	// `{"type":"null"}` as its last option.
 	UnionTypeNode struct {
 		DiscriminatorPropName string
 		Options               []ObjectNode
+		Untagged              bool
+		ExternallyTagged      bool
+		ContentPropName       string
+		Nullable              bool
 		TypeID_               syntax.TypeID `json:"-"`
 	}
 
+	// RefNode means `{"$ref": Ref}`. BackEdge marks a reference to a type
+	// that is still being rendered further up the type graph.
 	RefNode struct {
-		Ref string
+		Ref      string
+		BackEdge bool
+	}
+
+	// AnyNode is an unconstrained schema, `{}` or `{"description": Desc}`,
+	// rendered for a type whose declaration could not be loaded.
+	AnyNode struct {
+		Desc    string
+		TypeID_ syntax.TypeID
 	}
 
 	// TemplateHoleNode writes a raw template placeholder like {{.FieldName}}
@@ -97,6 +147,20 @@ type (
 		Name string
 	}
 
+	// OrderedNode is a schema object whose keywords are written in the given
+	// order. Dialect rewrites use it for shapes the draft 2020-12 node types
+	// cannot express, such as OpenAPI's "nullable" keyword.
+	OrderedNode struct {
+		Keywords []Keyword
+		TypeID_  syntax.TypeID
+	}
+
+	// Keyword is one member of an OrderedNode.
+	Keyword struct {
+		Name  string
+		Value json.Marshaler
+	}
+
 	// RootSchema wraps a root schema with a "$defs" map, splicing "$defs" in
 	// as the leading key so the rest of the root's deterministic key
 	// ordering is left untouched.
@@ -180,10 +244,13 @@ var (
 	_ schemaNode = ArrayNode{}
 	_ schemaNode = PropertyNode[int]{}
 	_ schemaNode = ObjectNode{}
+	_ schemaNode = MapNode{}
 	_ JSONSchema = RefNode{}
 	_ JSONSchema = TemplateHoleNode{}
 	_ JSONSchema = NullableObjectNode{}
 	_ JSONSchema = NullableUnionNode{}
+	_ JSONSchema = OrderedNode{}
+	_ JSONSchema = AnyNode{}
 )
 
 func (n NullableObjectNode) MarshalJSON() ([]byte, error) {
@@ -254,7 +321,7 @@ func (o ObjectNode) MarshalJSON() ([]byte, error) {
 			encodeString(&sb, prop.Name)
 			sb.WriteByte(':')
 
-			data, err := prop.Schema.MarshalJSON()
+			data, err := prop.annotatedSchema().MarshalJSON()
 			if err != nil {
 				return nil, fmt.Errorf("object property %q: %w", prop.Name, err)
 			}
@@ -306,7 +373,7 @@ func (p PropertyNode[T]) setDescription(s string) schemaNode {
 	return p
 }
 
-// Sample order: type -> description -> const -> enum
+// Sample order: type -> description -> format -> const -> enum (or anyOf) -> validation keywords
 func (p PropertyNode[T]) MarshalJSON() ([]byte, error) {
 	var sb strings.Builder
 	sb.WriteByte('{')
@@ -327,7 +394,26 @@ func (p PropertyNode[T]) MarshalJSON() ([]byte, error) {
 		encodeString(&sb, p.Desc)
 	}
 
-	// 3. "const"
+	// 3. "format", or an "anyOf" of formats
+	switch len(p.Formats) {
+	case 0:
+	case 1:
+		sb.WriteString(`,"format":`)
+		encodeString(&sb, p.Formats[0])
+	default:
+		sb.WriteString(`,"anyOf":[`)
+		for i, format := range p.Formats {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(`{"format":`)
+			encodeString(&sb, format)
+			sb.WriteByte('}')
+		}
+		sb.WriteByte(']')
+	}
+
+	// 4. "const"
 	// We always output "const" even if it's zero-like.
 	// If you want to skip zero-values, you'd need a separate sentinel or pointer.
 	// We'll do a quick test if T is zero or not, but that might be insufficient if T=0 is a legit const.
@@ -339,8 +425,24 @@ func (p PropertyNode[T]) MarshalJSON() ([]byte, error) {
 		sb.WriteString(constVal)
 	}
 
-	// 4. "enum"
-	if len(p.Enum) > 0 {
+	// 5. "enum", or an "anyOf" of described consts
+	if len(p.Enum) > 0 && p.EnumDescriptions != nil {
+		sb.WriteString(`,"anyOf":[`)
+		for i, val := range p.Enum {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			strVal, _ := toJSONValue(&val)
+			sb.WriteString(`{"const":`)
+			sb.WriteString(strVal)
+			if desc := p.EnumDescriptions[i]; desc != "" {
+				sb.WriteString(`,"description":`)
+				encodeString(&sb, desc)
+			}
+			sb.WriteByte('}')
+		}
+		sb.WriteByte(']')
+	} else if len(p.Enum) > 0 {
 		sb.WriteString(`,"enum":[`)
 		for i, val := range p.Enum {
 			if i > 0 {
@@ -352,10 +454,36 @@ func (p PropertyNode[T]) MarshalJSON() ([]byte, error) {
 		sb.WriteByte(']')
 	}
 
+	// 6. validation keywords
+	writeNumberKeyword(&sb, "minimum", p.Minimum)
+	writeNumberKeyword(&sb, "maximum", p.Maximum)
+	writeCountKeyword(&sb, "minLength", p.MinLength)
+	writeCountKeyword(&sb, "maxLength", p.MaxLength)
+	if p.Pattern != "" {
+		sb.WriteString(`,"pattern":`)
+		encodeString(&sb, p.Pattern)
+	}
+
 	sb.WriteByte('}')
 	return []byte(sb.String()), nil
 }
 
+func writeNumberKeyword(sb *strings.Builder, name string, value *float64) {
+	if value == nil {
+		return
+	}
+	sb.WriteString(`,"` + name + `":`)
+	sb.WriteString(strconv.FormatFloat(*value, 'f', -1, 64))
+}
+
+func writeCountKeyword(sb *strings.Builder, name string, value *int) {
+	if value == nil {
+		return
+	}
+	sb.WriteString(`,"` + name + `":`)
+	sb.WriteString(strconv.Itoa(*value))
+}
+
 // toJSONValue returns a JSON literal for a T (~int|~string|~bool).
 // Also returns a bool indicating if we consider this a “valid” value (always true here).
 func toJSONValue[T ~int | ~string | ~bool | float64 | float32](v *T) (string, bool) {
@@ -401,7 +529,9 @@ func (a ArrayNode) implementsJSONSchema() {}
 //	{
 //	  "type":"array",
 //	  "description":"...",
-//	  "items": ...
+//	  "items": ...,
+//	  "minItems": ...,
+//	  "maxItems": ...
 //	}
 func (a ArrayNode) MarshalJSON() ([]byte, error) {
 	var sb strings.Builder
@@ -426,6 +556,130 @@ func (a ArrayNode) MarshalJSON() ([]byte, error) {
 		sb.Write(data)
 	}
 
+	writeCountKeyword(&sb, "minItems", a.MinItems)
+	writeCountKeyword(&sb, "maxItems", a.MaxItems)
+
+	sb.WriteByte('}')
+	return []byte(sb.String()), nil
+}
+
+//---------------------------------------------------------------------
+// AnyNode
+//---------------------------------------------------------------------
+
+func (a AnyNode) TypeID() syntax.TypeID { return a.TypeID_ }
+
+func (a AnyNode) implementsJSONSchema() {}
+
+func (a AnyNode) MarshalJSON() ([]byte, error) {
+	if a.Desc == "" {
+		return []byte("{}"), nil
+	}
+	var sb strings.Builder
+	sb.WriteString(`{"description":`)
+	encodeString(&sb, a.Desc)
+	sb.WriteByte('}')
+	return []byte(sb.String()), nil
+}
+
+//---------------------------------------------------------------------
+// OrderedNode
+//---------------------------------------------------------------------
+
+func (o OrderedNode) TypeID() syntax.TypeID { return o.TypeID_ }
+
+func (o OrderedNode) implementsJSONSchema() {}
+
+func (o OrderedNode) MarshalJSON() ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, keyword := range o.Keywords {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		encodeString(&sb, keyword.Name)
+		sb.WriteByte(':')
+		data, err := keyword.Value.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("keyword %q: %w", keyword.Name, err)
+		}
+		sb.Write(data)
+	}
+	sb.WriteByte('}')
+	return []byte(sb.String()), nil
+}
+
+// insertAfter returns a copy of o with keyword inserted after the keyword
+// named after, or first when after is absent.
+func (o OrderedNode) insertAfter(after string, keyword Keyword) OrderedNode {
+	at := 0
+	for i, existing := range o.Keywords {
+		if existing.Name == after {
+			at = i + 1
+			break
+		}
+	}
+	keywords := make([]Keyword, 0, len(o.Keywords)+1)
+	keywords = append(keywords, o.Keywords[:at]...)
+	keywords = append(keywords, keyword)
+	keywords = append(keywords, o.Keywords[at:]...)
+	return OrderedNode{Keywords: keywords, TypeID_: o.TypeID_}
+}
+
+//---------------------------------------------------------------------
+// MapNode
+//---------------------------------------------------------------------
+
+func (m MapNode) TypeID() syntax.TypeID { return m.TypeID_ }
+
+func (m MapNode) Type() string {
+	return "object"
+}
+
+func (m MapNode) Description() string {
+	return m.Desc
+}
+
+func (m MapNode) implementsJSONSchema() {}
+
+func (m MapNode) setDescription(s string) schemaNode {
+	m.Desc = s
+	return m
+}
+
+// Marshal as:
+//
+//	{
+//	  "type":"object",
+//	  "description":"...",
+//	  "propertyNames": ...,
+//	  "additionalProperties": ...
+//	}
+func (m MapNode) MarshalJSON() ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteString(`{"type":"object"`)
+
+	if m.Desc != "" {
+		sb.WriteString(`,"description":`)
+		encodeString(&sb, m.Desc)
+	}
+
+	if m.PropertyNames != nil {
+		sb.WriteString(`,"propertyNames":`)
+		data, err := m.PropertyNames.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("mapNode propertyNames: %w", err)
+		}
+		sb.Write(data)
+	}
+
+	sb.WriteString(`,"additionalProperties":`)
+	data, err := m.Values.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("mapNode additionalProperties: %w", err)
+	}
+	sb.Write(data)
+
 	sb.WriteByte('}')
 	return []byte(sb.String()), nil
 }
@@ -445,6 +699,7 @@ func (u UnionTypeNode) implementsJSONSchema() {}
 //	    <ObjectNode-with-type-const>,
 //	    <ObjectNode-with-type-const>,
 //	    ...
+//	    {"type": "null"}   // only when Nullable
 //	  ]
 //	}
 func (u UnionTypeNode) MarshalJSON() ([]byte, error) {
@@ -456,15 +711,15 @@ func (u UnionTypeNode) MarshalJSON() ([]byte, error) {
 			sb.WriteByte(',')
 		}
 
-		// We'll produce a new node with a prepended discriminator property:
-		//   type: { "type":"string", "const": obj.Discriminator }
-		tmpNode := prependDiscriminator(obj, u.DiscriminatorPropName)
-		data, err := tmpNode.MarshalJSON()
+		data, err := u.option(obj).MarshalJSON()
 		if err != nil {
 			return nil, fmt.Errorf("union option %d: %w", i, err)
 		}
 		sb.Write(data)
 	}
+	if u.Nullable {
+		sb.WriteString(`,{"type":"null"}`)
+	}
 
 	sb.WriteByte(']')
 	sb.WriteByte('}')
@@ -472,6 +727,28 @@ func (u UnionTypeNode) MarshalJSON() ([]byte, error) {
 	return []byte(sb.String()), nil
 }
 
+// option is the schema of one of the union's options: obj with a prepended
+// discriminator property, type: { "type":"string", "const": obj.Discriminator },
+// unless the union is untagged, or obj wrapped as the union's tagging style
+// requires.
+func (u UnionTypeNode) option(obj ObjectNode) ObjectNode {
+	switch {
+	case u.Untagged:
+		return obj
+	case u.ExternallyTagged:
+		return ObjectNode{
+			Properties:    ObjectPropSet{{Name: obj.Discriminator, Schema: obj}},
+			Discriminator: obj.Discriminator,
+		}
+	case u.ContentPropName != "":
+		return prependDiscriminator(ObjectNode{
+			Properties:    ObjectPropSet{{Name: u.ContentPropName, Schema: obj}},
+			Discriminator: obj.Discriminator,
+		}, u.DiscriminatorPropName)
+	}
+	return prependDiscriminator(obj, u.DiscriminatorPropName)
+}
+
 // prependDiscriminator returns an ObjectNode that has an extra property
 // at the front: e.g. type => { type:"string", const:"(the Discriminator)" },
 // making that property required.
@@ -0,0 +1,127 @@
+package builder
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunGeneratesEveryMatchedPackage(t *testing.T) {
+	root := writeMultiPackageFixture(t)
+	require.NoError(t, Run(BuilderArgs{TargetDir: root, Patterns: []string{root + "/..."}}))
+
+	owner, err := os.ReadFile(filepath.Join(root, "a", "jsonschema", "Owner.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"item": {
+				"type": "object",
+				"properties": {"sku": {"type": "string"}},
+				"required": ["sku"],
+				"additionalProperties": false
+			}
+		},
+		"required": ["item"],
+		"additionalProperties": false
+	}`, string(owner))
+	require.FileExists(t, filepath.Join(root, "a", goCodeFile))
+	require.FileExists(t, filepath.Join(root, "b", "jsonschema", "Item.json"))
+	require.FileExists(t, filepath.Join(root, "b", goCodeFile))
+	require.NoFileExists(t, filepath.Join(root, "c", goCodeFile), "packages without schemas are skipped")
+}
+
+func TestRunQualifiesChangedTypesAcrossPackages(t *testing.T) {
+	root := writeMultiPackageFixture(t)
+	err := Run(BuilderArgs{TargetDir: root, Patterns: []string{root + "/..."}, NoChanges: true})
+	require.ErrorContains(t, err, "/a.Owner, ")
+	require.ErrorContains(t, err, "/b.Item (and --no-changes")
+	require.NoFileExists(t, filepath.Join(root, "a", goCodeFile))
+}
+
+func TestCheckAppliesPackagePatternDirectives(t *testing.T) {
+	root := writeMultiPackageFixture(t)
+	// The directive is split so that go generate does not run it in this package.
+	doc := "package multi\n\n//go:" + "generate go tool gen-jsonschema gen ./...\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "doc.go"), []byte(doc), 0o644))
+	require.NoError(t, Run(BuilderArgs{TargetDir: root, Patterns: []string{root + "/..."}}))
+
+	report, err := Check(CheckArgs{
+		Patterns: []string{root + "/..."},
+		GenArgs: func(args []string) (BuilderArgs, error) {
+			require.Equal(t, []string{"./..."}, args)
+			return BuilderArgs{Patterns: args}, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, report.Drift)
+	require.Len(t, report.Packages, 2)
+	require.ElementsMatch(t, []string{"a", "b"}, []string{path.Base(report.Packages[0]), path.Base(report.Packages[1])})
+
+	require.NoError(t, os.Remove(filepath.Join(root, "b", "jsonschema", "Item.json")))
+	report, err = Check(CheckArgs{
+		Patterns: []string{root + "/..."},
+		GenArgs:  func(args []string) (BuilderArgs, error) { return BuilderArgs{Patterns: args}, nil },
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Drift, 1)
+	require.Equal(t, DriftMissing, report.Drift[0].Kind)
+	require.Equal(t, "Item", report.Drift[0].Type)
+}
+
+func writeMultiPackageFixture(t *testing.T) string {
+	t.Helper()
+
+	files := map[string]string{
+		"a/types.go": `package a
+
+import "` + fixturePath + `/b"
+
+type Owner struct {
+	Item b.Item ` + "`json:\"item\"`" + `
+}
+`,
+		"a/schema.go": `//go:build jsonschema
+
+package a
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Owner) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
+`,
+		"b/types.go": `package b
+
+type Item struct {
+	SKU string ` + "`json:\"sku\"`" + `
+}
+`,
+		"b/schema.go": `//go:build jsonschema
+
+package b
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Item) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Item.Schema)
+`,
+		"c/types.go": `package c
+
+type Plain struct{}
+`,
+	}
+	return writeFixture(t, "multi_", files)
+}
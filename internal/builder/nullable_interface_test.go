@@ -0,0 +1,70 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestNullableRegisteredInterface(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Attachment interface{ attachment() }
+
+type Image struct {
+	URL string `+"`json:\"url\"`"+`
+}
+
+func (Image) attachment() {}
+
+type Document struct {
+	Title string `+"`json:\"title\"`"+`
+}
+
+func (*Document) attachment() {}
+
+type Owner struct {
+	Attachment jsonschema.Nullable[Attachment] `+"`json:\"attachment\"`"+`
+}
+`, `
+var (
+	_ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
+	_ = jsonschema.NewInterfaceImpl[Attachment](Image{}, (*Document)(nil))
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	typeID := syntax.TypeID{PkgPath: pkgs[0].PkgPath, TypeName: "Owner"}
+	schema, ok := builder.schemas.Get(typeID.PkgPath, typeID.TypeName)
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"object","properties":{"attachment":{"anyOf":[
+		{"type":"object","properties":{"type":{"type":"string","const":"Image"},"url":{"type":"string"}},"required":["type","url"],"additionalProperties":false},
+		{"type":"object","properties":{"type":{"type":"string","const":"Document"},"title":{"type":"string"}},"required":["type","title"],"additionalProperties":false},
+		{"type":"null"}
+	]}},"required":["attachment"],"additionalProperties":false}`, string(data))
+
+	hardlines, err := marshalSchemaHardlines(schema)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(hardlines))
+
+	root, ok := builder.GetSchema(typeID)
+	require.True(t, ok)
+	_, err = DialectOpenAIStrict.rewrite(builder, root, "Owner")
+	require.NoError(t, err)
+	_, err = DialectGemini.rewrite(builder, root, "Owner")
+	require.ErrorContains(t, err, "dialect gemini: Owner.attachment: nullable unions cannot be expressed")
+
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	require.Contains(t, string(code), `if len(wrapper.Attachment) > 0 && string(wrapper.Attachment) != "null" {`)
+	require.Contains(t, string(code), "if !o.Attachment.Present {\n\t\twrapper.Attachment = json.RawMessage(\"null\")")
+}
@@ -0,0 +1,209 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	santhosh "github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestRecursiveTypesRenderAsDefs(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeRecursiveFixture(t, `
+type Leaf struct {
+	V int `+"`json:\"v\"`"+`
+}
+
+type Node struct {
+	Value    Leaf   `+"`json:\"value\"`"+`
+	Children []Node `+"`json:\"children\"`"+`
+	Next     *Node  `+"`json:\"next\"`"+`
+}
+
+type Comment struct {
+	Body    string `+"`json:\"body\"`"+`
+	Replies Thread `+"`json:\"replies\"`"+`
+}
+
+type Thread struct {
+	Comments []Comment `+"`json:\"comments\"`"+`
+}
+
+type Owner struct {
+	Root  Node                         `+"`json:\"root\"`"+`
+	Maybe jsonschema.Nullable[Node]    `+"`json:\"maybe\"`"+`
+	Talk  Thread                       `+"`json:\"talk\"`"+`
+	Leaf  Leaf                         `+"`json:\"leaf\"`"+`
+}
+`, "")
+	got := renderRecursiveFixture(t, targetDir, "Owner")
+	require.JSONEq(t, `{
+		"$defs":{
+			"Node":{"type":"object","properties":{
+				"value":{"type":"object","properties":{"v":{"type":"integer"}},"required":["v"],"additionalProperties":false},
+				"children":{"type":"array","items":{"$ref":"#/$defs/Node"}},
+				"next":{"anyOf":[{"$ref":"#/$defs/Node"},{"type":"null"}]}
+			},"required":["value","children","next"],"additionalProperties":false},
+			"Thread":{"type":"object","properties":{
+				"comments":{"type":"array","items":{"$ref":"#/$defs/Comment"}}
+			},"required":["comments"],"additionalProperties":false},
+			"Comment":{"type":"object","properties":{
+				"body":{"type":"string"},
+				"replies":{"$ref":"#/$defs/Thread"}
+			},"required":["body","replies"],"additionalProperties":false}
+		},
+		"type":"object",
+		"properties":{
+			"root":{"$ref":"#/$defs/Node"},
+			"maybe":{"anyOf":[{"$ref":"#/$defs/Node"},{"type":"null"}]},
+			"talk":{"$ref":"#/$defs/Thread"},
+			"leaf":{"type":"object","properties":{"v":{"type":"integer"}},"required":["v"],"additionalProperties":false}
+		},
+		"required":["root","maybe","talk","leaf"],
+		"additionalProperties":false
+	}`, got)
+}
+
+func TestRecursiveRootTypeReferencesItsOwnDefinition(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeRecursiveFixture(t, `
+type Owner struct {
+	Name     string  `+"`json:\"name\"`"+`
+	Children []Owner `+"`json:\"children\"`"+`
+}
+`, "")
+	got := renderRecursiveFixture(t, targetDir, "Owner")
+	require.JSONEq(t, `{
+		"type":"object",
+		"properties":{
+			"name":{"type":"string"},
+			"children":{"type":"array","items":{"$ref":"#"}}
+		},
+		"required":["name","children"],
+		"additionalProperties":false
+	}`, got)
+}
+
+func TestRecursiveRootTypeThroughPointerIsNullable(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeRecursiveFixture(t, `
+type Owner struct {
+	Name    string   `+"`json:\"name\"`"+`
+	Next    *Owner   `+"`json:\"next\"`"+`
+	Replies []Reply  `+"`json:\"replies\"`"+`
+}
+
+type Reply struct {
+	Parent *Owner `+"`json:\"parent\"`"+`
+}
+`, "")
+	got := renderRecursiveFixture(t, targetDir, "Owner")
+	require.JSONEq(t, `{
+		"$defs":{
+			"Reply":{"type":"object","properties":{
+				"parent":{"anyOf":[{"$ref":"#"},{"type":"null"}]}
+			},"required":["parent"],"additionalProperties":false}
+		},
+		"type":"object",
+		"properties":{
+			"name":{"type":"string"},
+			"next":{"anyOf":[{"$ref":"#"},{"type":"null"}]},
+			"replies":{"type":"array","items":{"$ref":"#/$defs/Reply"}}
+		},
+		"required":["name","next","replies"],
+		"additionalProperties":false
+	}`, got)
+
+	// The nil pointer that ends the chain is what encoding/json writes.
+	doc, err := santhosh.UnmarshalJSON(strings.NewReader(got))
+	require.NoError(t, err)
+	c := santhosh.NewCompiler()
+	require.NoError(t, c.AddResource("owner.json", doc))
+	schema, err := c.Compile("owner.json")
+	require.NoError(t, err)
+	inst, err := santhosh.UnmarshalJSON(strings.NewReader(`{"name":"a","next":{"name":"b","next":null,"replies":[]},"replies":[{"parent":null}]}`))
+	require.NoError(t, err)
+	require.NoError(t, schema.Validate(inst))
+}
+
+func TestRecursionThroughInterfaceUnionFailsDuringGeneration(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeRecursiveFixture(t, `
+type Value interface{ isValue() }
+
+type Group struct {
+	Inner Value `+"`json:\"inner\"`"+`
+}
+
+func (Group) isValue() {}
+
+type Owner struct {
+	Value Value `+"`json:\"value\"`"+`
+}
+
+func (Group) Schema() json.RawMessage { panic("not implemented") }
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Group.Schema,
+	jsonschema.WithInterface(Group{}.Inner, jsonschema.Impl("group", Group{})),
+)
+
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Value, jsonschema.Impl("group", Group{})),
+)`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	_, err = New(pkgs[0])
+	require.ErrorContains(t, err, "circular dependency found for type Group")
+	require.ErrorContains(t, err, "cycles through registered interface implementations cannot be rendered")
+}
+
+func renderRecursiveFixture(t *testing.T, targetDir, typeName string) string {
+	t.Helper()
+
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	outDir := t.TempDir()
+	_, err = builder.writeSchema(syntax.TypeID{PkgPath: pkgs[0].PkgPath, TypeName: typeName}, "", outDir, false)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(outDir, typeName+".json"))
+	require.NoError(t, err)
+	require.True(t, json.Valid(data))
+	return string(data)
+}
+
+func writeRecursiveFixture(t *testing.T, types, registrations string) string {
+	t.Helper()
+
+	if registrations == "" {
+		registrations = "var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema)"
+	}
+	source := `//go:build jsonschema
+
+package fixture
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+` + types + `
+func (Owner) Schema() json.RawMessage { panic("not implemented") }
+` + registrations + "\n"
+	return writeFixture(t, "recursive_types_", map[string]string{"schema.go": source})
+}
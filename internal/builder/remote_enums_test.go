@@ -0,0 +1,180 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dave/dst/decorator"
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestEnumsFromDependencyPackages(t *testing.T) {
+	t.Parallel()
+
+	root := writeRemoteEnumsFixture(t)
+	pkgs, err := syntax.Load(filepath.Join(root, "tools"))
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Owner")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+
+	// Status is registered by the package that declares it, Priority by
+	// this one. Both apply to every type this package renders, including
+	// Ticket from the tickets package, which registers neither.
+	status := `{"type":"string","description":"open: \nOpen is accepting work.","enum":["open","closed"]}`
+	priority := `{"type":"string","enum":["low","high"]}`
+	require.JSONEq(t, `{"type":"object","properties":{
+		"status":`+status+`,
+		"statuses":{"type":"array","items":`+status+`},
+		"priority":`+priority+`,
+		"by_status":{"type":"object","propertyNames":{"type":"string","enum":["open","closed"]},"additionalProperties":{"type":"integer"}},
+		"ticket":{"type":"object","properties":{"status":`+status+`,"priority":`+priority+`},"required":["status","priority"],"additionalProperties":false}
+	},"required":["status","statuses","priority","by_status","ticket"],"additionalProperties":false}`, string(data))
+
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	require.NotContains(t, string(code), "func (v Status) IsValid", "helpers are generated only for local enums")
+}
+
+func TestDependencyEnumsAreScopedToTheRegisteringPackage(t *testing.T) {
+	t.Parallel()
+
+	root := writeRemoteEnumsFixture(t)
+	pkgs, err := syntax.Load(filepath.Join(root, "tools"), filepath.Join(root, "reports"))
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+	byName := map[string]*decorator.Package{}
+	for _, pkg := range pkgs {
+		byName[pkg.Name] = pkg
+	}
+
+	render := func(set *syntax.PackageSet, name, typeName string) string {
+		t.Helper()
+		scan, err := set.Scan(byName[name])
+		require.NoError(t, err)
+		builder, err := newFromScan(scan)
+		require.NoError(t, err)
+		schema, ok := builder.schemas.Get(byName[name].PkgPath, typeName)
+		require.True(t, ok)
+		data, err := schema.MarshalJSON()
+		require.NoError(t, err)
+		return string(data)
+	}
+	// Only tools registers Priority, so reports renders it as a plain string
+	// whichever package is scanned first.
+	for _, order := range [][]string{{"tools", "reports"}, {"reports", "tools"}} {
+		var (
+			set   = syntax.NewPackageSet(pkgs)
+			got   = map[string]string{}
+			types = map[string]string{"tools": "Owner", "reports": "Report"}
+		)
+		for _, name := range order {
+			got[name] = render(set, name, types[name])
+		}
+		require.Contains(t, got["tools"], `"priority":{"type":"string","enum":["low","high"]}`, order)
+		require.JSONEq(t, `{"type":"object","properties":{"priority":{"type":"string"}},"required":["priority"],"additionalProperties":false}`, got["reports"], order)
+	}
+}
+
+func writeRemoteEnumsFixture(t *testing.T) string {
+	t.Helper()
+
+	files := map[string]string{
+		"types/types.go": `package types
+
+type Status string
+
+const (
+	// Open is accepting work.
+	Open   Status = "open"
+	Closed Status = "closed"
+)
+
+type Priority string
+
+const (
+	Low  Priority = "low"
+	High Priority = "high"
+)
+`,
+		"types/schema.go": `//go:build jsonschema
+
+package types
+
+import jsonschema "github.com/tylergannon/go-gen-jsonschema"
+
+var _ = jsonschema.NewEnumType[Status]()
+`,
+		"tickets/tickets.go": `package tickets
+
+import "` + fixturePath + `/types"
+
+type Ticket struct {
+	Status   types.Status   ` + "`json:\"status\"`" + `
+	Priority types.Priority ` + "`json:\"priority\"`" + `
+}
+`,
+		"tools/tools.go": `package tools
+
+import (
+	"` + fixturePath + `/tickets"
+	"` + fixturePath + `/types"
+)
+
+type Owner struct {
+	Status   types.Status         ` + "`json:\"status\"`" + `
+	Statuses []types.Status       ` + "`json:\"statuses\"`" + `
+	Priority types.Priority       ` + "`json:\"priority\"`" + `
+	ByStatus map[types.Status]int ` + "`json:\"by_status\"`" + `
+	Ticket   tickets.Ticket       ` + "`json:\"ticket\"`" + `
+}
+`,
+		"reports/reports.go": `package reports
+
+import "` + fixturePath + `/types"
+
+type Report struct {
+	Priority types.Priority ` + "`json:\"priority\"`" + `
+}
+`,
+		"reports/schema.go": `//go:build jsonschema
+
+package reports
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Report) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Report.Schema)
+`,
+		"tools/schema.go": `//go:build jsonschema
+
+package tools
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+	"` + fixturePath + `/types"
+)
+
+func (Owner) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
+
+var _ = jsonschema.NewEnumType[types.Priority]()
+`,
+	}
+	return writeFixture(t, "remote_enums_", files)
+}
@@ -0,0 +1,104 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestInterfaceImplsFromSiblingPackages(t *testing.T) {
+	t.Parallel()
+
+	root := writeRemoteInterfaceImplsFixture(t)
+	pkgs, err := syntax.Load(filepath.Join(root, "workflow"))
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Step")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"object","properties":{"action":{"anyOf":[
+		{"type":"object","properties":{"type":{"type":"string","const":"email"},"to":{"type":"string"}},"required":["type","to"],"additionalProperties":false},
+		{"type":"object","properties":{"type":{"type":"string","const":"slack"},"channel":{"type":"string"}},"required":["type","channel"],"additionalProperties":false},
+		{"type":"object","properties":{"type":{"type":"string","const":"wait"},"seconds":{"type":"integer"}},"required":["type","seconds"],"additionalProperties":false}
+	]}},"required":["action"],"additionalProperties":false}`, string(data))
+
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	source := string(code)
+	require.Contains(t, source, `"`+builder.Scan.Pkg.PkgPath[:len(builder.Scan.Pkg.PkgPath)-len("workflow")]+`actions/email"`)
+	require.Contains(t, source, "var obj email.Send\n")
+	require.Contains(t, source, "var obj slack.Post\n")
+	require.Contains(t, source, "return &obj, nil")
+	require.Contains(t, source, "case email.Send, *email.Send:")
+}
+
+func writeRemoteInterfaceImplsFixture(t *testing.T) string {
+	t.Helper()
+
+	files := map[string]string{
+		"actions/actions.go": `package actions
+
+type Action interface{ Run() error }
+`,
+		"actions/email/email.go": `package email
+
+type Send struct {
+	To string ` + "`json:\"to\"`" + `
+}
+
+func (Send) Run() error { return nil }
+`,
+		"actions/slack/slack.go": `package slack
+
+type Post struct {
+	Channel string ` + "`json:\"channel\"`" + `
+}
+
+func (*Post) Run() error { return nil }
+`,
+		"workflow/workflow.go": `package workflow
+
+import "` + fixturePath + `/actions"
+
+type Wait struct {
+	Seconds int ` + "`json:\"seconds\"`" + `
+}
+
+func (Wait) Run() error { return nil }
+
+type Step struct {
+	Action actions.Action ` + "`json:\"action\"`" + `
+}
+`,
+		"workflow/schema.go": `//go:build jsonschema
+
+package workflow
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+	"` + fixturePath + `/actions/email"
+	"` + fixturePath + `/actions/slack"
+)
+
+func (Step) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Step.Schema,
+	jsonschema.WithInterface(Step{}.Action,
+		jsonschema.Impl("email", email.Send{}),
+		jsonschema.Impl("slack", (*slack.Post)(nil)),
+		jsonschema.Impl("wait", Wait{}),
+	),
+)
+`,
+	}
+	return writeFixture(t, "remote_impls_", files)
+}
@@ -0,0 +1,507 @@
+package builder
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ChangeKind names one kind of difference between two versions of a schema.
+type ChangeKind string
+
+const (
+	ChangeTypeAdded              ChangeKind = "type-added"
+	ChangeTypeRemoved            ChangeKind = "type-removed"
+	ChangePropertyAdded          ChangeKind = "property-added"
+	ChangeRequiredPropertyAdded  ChangeKind = "required-property-added"
+	ChangePropertyRemoved        ChangeKind = "property-removed"
+	ChangePropertyRequired       ChangeKind = "property-required"
+	ChangePropertyOptional       ChangeKind = "property-optional"
+	ChangeTypeNarrowed           ChangeKind = "type-narrowed"
+	ChangeTypeWidened            ChangeKind = "type-widened"
+	ChangeEnumValueAdded         ChangeKind = "enum-value-added"
+	ChangeEnumValueRemoved       ChangeKind = "enum-value-removed"
+	ChangeConstChanged           ChangeKind = "const-changed"
+	ChangeDiscriminatorAdded     ChangeKind = "discriminator-added"
+	ChangeDiscriminatorRemoved   ChangeKind = "discriminator-removed"
+	ChangeVariantAdded           ChangeKind = "variant-added"
+	ChangeVariantRemoved         ChangeKind = "variant-removed"
+	ChangeConstraintTightened    ChangeKind = "constraint-tightened"
+	ChangeConstraintRelaxed      ChangeKind = "constraint-relaxed"
+	ChangeAdditionalPropsClosed  ChangeKind = "additional-properties-closed"
+	ChangeAdditionalPropsOpened  ChangeKind = "additional-properties-opened"
+	ChangeSchemaReplaced         ChangeKind = "schema-replaced"
+	ChangeReferenceTargetChanged ChangeKind = "reference-changed"
+)
+
+// SchemaChange is one difference between the base and current schema of a
+// type. A change is breaking when some value valid under the base schema is
+// rejected by the current one.
+type SchemaChange struct {
+	Package string     `json:"package"`
+	Type    string     `json:"type"`
+	Path    string     `json:"path"`
+	Kind    ChangeKind `json:"kind"`
+	// Value distinguishes changes of the same kind at the same path, such as
+	// the enum value or discriminator that was removed.
+	Value        string `json:"value,omitempty"`
+	Detail       string `json:"detail"`
+	Breaking     bool   `json:"breaking"`
+	Acknowledged bool   `json:"acknowledged,omitempty"`
+}
+
+// Key identifies the change in an acknowledgement file, for example
+// "example.com/app/tools.Owner#/properties/name property-removed".
+func (c SchemaChange) Key() string {
+	key := c.Package + "." + c.Type + "#" + c.Path + " " + string(c.Kind)
+	if c.Value != "" {
+		key += " " + c.Value
+	}
+	return key
+}
+
+var (
+	lowerBounds = []string{"minimum", "exclusiveMinimum", "minLength", "minItems", "minProperties"}
+	upperBounds = []string{"maximum", "exclusiveMaximum", "maxLength", "maxItems", "maxProperties"}
+	// assertions restrict values when present; adding or changing one is
+	// breaking and removing one is not.
+	assertions = []string{"pattern", "format", "multipleOf"}
+)
+
+// schemaDiffer compares two decoded schema documents keyword by keyword.
+// Annotations such as description and examples are not part of the contract
+// and are ignored.
+type schemaDiffer struct {
+	oldRoot, newRoot map[string]any
+	changes          []SchemaChange
+	// refs holds the pairs of references already compared, so recursive
+	// schemas terminate.
+	refs map[[2]string]bool
+}
+
+// diffSchemas returns the changes from base to current, without their
+// Package and Type.
+func diffSchemas(base, current []byte) ([]SchemaChange, error) {
+	var oldRoot, newRoot map[string]any
+	if err := json.Unmarshal(base, &oldRoot); err != nil {
+		return nil, fmt.Errorf("base schema: %w", err)
+	}
+	if err := json.Unmarshal(current, &newRoot); err != nil {
+		return nil, fmt.Errorf("current schema: %w", err)
+	}
+	d := schemaDiffer{oldRoot: oldRoot, newRoot: newRoot, refs: map[[2]string]bool{}}
+	d.compare("", oldRoot, newRoot)
+	return d.changes, nil
+}
+
+func (d *schemaDiffer) add(path string, kind ChangeKind, breaking bool, value, format string, args ...any) {
+	d.changes = append(d.changes, SchemaChange{
+		Path:     path,
+		Kind:     kind,
+		Value:    value,
+		Detail:   fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+func (d *schemaDiffer) compare(path string, oldNode, newNode any) {
+	oldSchema, oldOK := oldNode.(map[string]any)
+	newSchema, newOK := newNode.(map[string]any)
+	if !oldOK || !newOK {
+		if !reflect.DeepEqual(oldNode, newNode) {
+			d.add(path, ChangeSchemaReplaced, true, "", "schema changed from %s to %s", compactJSON(oldNode), compactJSON(newNode))
+		}
+		return
+	}
+
+	oldRef, _ := oldSchema["$ref"].(string)
+	newRef, _ := newSchema["$ref"].(string)
+	if oldRef != "" || newRef != "" {
+		pair := [2]string{oldRef, newRef}
+		if d.refs[pair] {
+			return
+		}
+		d.refs[pair] = true
+		oldSchema, newSchema = resolveRef(d.oldRoot, oldSchema), resolveRef(d.newRoot, newSchema)
+		if oldSchema == nil || newSchema == nil {
+			d.add(path, ChangeReferenceTargetChanged, true, "", "reference %q no longer resolves to a definition in both versions", cmp.Or(newRef, oldRef))
+			return
+		}
+	}
+
+	oldSchema, newSchema = constsAsEnum(oldSchema), constsAsEnum(newSchema)
+	_, oldUnion := unionOptions(oldSchema)
+	_, newUnion := unionOptions(newSchema)
+	if oldUnion && newUnion {
+		// Keywords beside anyOf or oneOf apply to every option.
+		d.compareKeywords(path, oldSchema, newSchema)
+	}
+	if oldUnion || newUnion {
+		d.compareUnion(path, oldSchema, newSchema)
+		return
+	}
+	d.compareKeywords(path, oldSchema, newSchema)
+}
+
+// compareKeywords compares the keywords of two schemas other than $ref and
+// the union keywords.
+func (d *schemaDiffer) compareKeywords(path string, oldSchema, newSchema map[string]any) {
+	d.compareType(path, oldSchema, newSchema)
+	d.compareEnum(path, oldSchema, newSchema)
+	if oldConst, ok := oldSchema["const"]; ok {
+		if newConst, ok := newSchema["const"]; !ok {
+			d.add(path, ChangeConstraintRelaxed, false, "", "const %s was removed", compactJSON(oldConst))
+		} else if !reflect.DeepEqual(oldConst, newConst) {
+			d.add(path, ChangeConstChanged, true, "", "const changed from %s to %s", compactJSON(oldConst), compactJSON(newConst))
+		}
+	} else if newConst, ok := newSchema["const"]; ok {
+		d.add(path, ChangeConstChanged, true, "", "const %s was added", compactJSON(newConst))
+	}
+	d.compareBounds(path, oldSchema, newSchema)
+	d.compareProperties(path, oldSchema, newSchema)
+	d.compareSubschema(path, "items", oldSchema, newSchema)
+	d.compareSubschema(path, "propertyNames", oldSchema, newSchema)
+}
+
+// compareSubschema compares the schemas two versions give keyword, such as
+// items. Adding one restricts values and removing one does not.
+func (d *schemaDiffer) compareSubschema(path, keyword string, oldSchema, newSchema map[string]any) {
+	oldValue, oldHas := oldSchema[keyword]
+	newValue, newHas := newSchema[keyword]
+	switch {
+	case oldHas && newHas:
+		d.compare(path+"/"+keyword, oldValue, newValue)
+	case newHas:
+		d.add(path+"/"+keyword, ChangeConstraintTightened, true, "", "%s schema was added", keyword)
+	case oldHas:
+		d.add(path+"/"+keyword, ChangeConstraintRelaxed, false, "", "%s schema was removed", keyword)
+	}
+}
+
+// compareType reports type keywords that accept fewer or more JSON types.
+func (d *schemaDiffer) compareType(path string, oldSchema, newSchema map[string]any) {
+	oldTypes, newTypes := schemaTypes(oldSchema), schemaTypes(newSchema)
+	switch {
+	case newTypes == nil:
+		if oldTypes != nil {
+			d.add(path, ChangeTypeWidened, false, "", "type %s was removed", strings.Join(slices.Sorted(maps.Keys(oldTypes)), ", "))
+		}
+		return
+	case oldTypes == nil:
+		d.add(path, ChangeTypeNarrowed, true, "", "type %s was added", strings.Join(slices.Sorted(maps.Keys(newTypes)), ", "))
+		return
+	}
+	for _, typ := range slices.Sorted(maps.Keys(oldTypes)) {
+		if !newTypes[typ] && !(typ == "integer" && newTypes["number"]) {
+			d.add(path, ChangeTypeNarrowed, true, typ, "type no longer allows %s", typ)
+		}
+	}
+	for _, typ := range slices.Sorted(maps.Keys(newTypes)) {
+		if !oldTypes[typ] && !(typ == "integer" && oldTypes["number"]) {
+			d.add(path, ChangeTypeWidened, false, typ, "type now allows %s", typ)
+		}
+	}
+}
+
+func (d *schemaDiffer) compareEnum(path string, oldSchema, newSchema map[string]any) {
+	oldEnum, oldHas := oldSchema["enum"].([]any)
+	newEnum, newHas := newSchema["enum"].([]any)
+	switch {
+	case !oldHas && !newHas:
+		return
+	case !newHas:
+		d.add(path, ChangeConstraintRelaxed, false, "", "enum was removed")
+		return
+	case !oldHas:
+		d.add(path, ChangeConstraintTightened, true, "", "enum %s was added", compactJSON(newEnum))
+		return
+	}
+	contains := func(values []any, value any) bool {
+		return slices.ContainsFunc(values, func(v any) bool { return reflect.DeepEqual(v, value) })
+	}
+	for _, value := range oldEnum {
+		if !contains(newEnum, value) {
+			d.add(path, ChangeEnumValueRemoved, true, compactJSON(value), "enum value %s was removed", compactJSON(value))
+		}
+	}
+	for _, value := range newEnum {
+		if !contains(oldEnum, value) {
+			d.add(path, ChangeEnumValueAdded, false, compactJSON(value), "enum value %s was added", compactJSON(value))
+		}
+	}
+}
+
+// compareBounds reports numeric bounds and assertions that were added,
+// removed, or moved.
+func (d *schemaDiffer) compareBounds(path string, oldSchema, newSchema map[string]any) {
+	bound := func(keyword string, lower bool) {
+		oldValue, oldHas := oldSchema[keyword].(float64)
+		newValue, newHas := newSchema[keyword].(float64)
+		switch {
+		case !oldHas && !newHas, oldHas && newHas && oldValue == newValue:
+		case !newHas:
+			d.add(path, ChangeConstraintRelaxed, false, keyword, "%s %v was removed", keyword, oldValue)
+		case !oldHas:
+			d.add(path, ChangeConstraintTightened, true, keyword, "%s %v was added", keyword, newValue)
+		case (newValue > oldValue) == lower:
+			d.add(path, ChangeConstraintTightened, true, keyword, "%s changed from %v to %v", keyword, oldValue, newValue)
+		default:
+			d.add(path, ChangeConstraintRelaxed, false, keyword, "%s changed from %v to %v", keyword, oldValue, newValue)
+		}
+	}
+	for _, keyword := range lowerBounds {
+		bound(keyword, true)
+	}
+	for _, keyword := range upperBounds {
+		bound(keyword, false)
+	}
+	for _, keyword := range assertions {
+		oldValue, oldHas := oldSchema[keyword]
+		newValue, newHas := newSchema[keyword]
+		switch {
+		case !oldHas && !newHas, reflect.DeepEqual(oldValue, newValue):
+		case !newHas:
+			d.add(path, ChangeConstraintRelaxed, false, keyword, "%s %s was removed", keyword, compactJSON(oldValue))
+		case !oldHas:
+			d.add(path, ChangeConstraintTightened, true, keyword, "%s %s was added", keyword, compactJSON(newValue))
+		default:
+			d.add(path, ChangeConstraintTightened, true, keyword, "%s changed from %s to %s", keyword, compactJSON(oldValue), compactJSON(newValue))
+		}
+	}
+}
+
+func (d *schemaDiffer) compareProperties(path string, oldSchema, newSchema map[string]any) {
+	oldProps, _ := oldSchema["properties"].(map[string]any)
+	newProps, _ := newSchema["properties"].(map[string]any)
+	oldRequired, newRequired := requiredSet(oldSchema), requiredSet(newSchema)
+	for _, name := range slices.Sorted(maps.Keys(oldProps)) {
+		propPath := path + "/properties/" + escapePointer(name)
+		newProp, ok := newProps[name]
+		switch {
+		case !ok:
+			d.add(propPath, ChangePropertyRemoved, true, "", "property %q was removed", name)
+			continue
+		case !oldRequired[name] && newRequired[name]:
+			d.add(propPath, ChangePropertyRequired, true, "", "property %q is now required", name)
+		case oldRequired[name] && !newRequired[name]:
+			d.add(propPath, ChangePropertyOptional, false, "", "property %q is now optional", name)
+		}
+		d.compare(propPath, oldProps[name], newProp)
+	}
+	for _, name := range slices.Sorted(maps.Keys(newProps)) {
+		if _, ok := oldProps[name]; ok {
+			continue
+		}
+		propPath := path + "/properties/" + escapePointer(name)
+		if newRequired[name] {
+			d.add(propPath, ChangeRequiredPropertyAdded, true, "", "required property %q was added", name)
+		} else {
+			d.add(propPath, ChangePropertyAdded, false, "", "optional property %q was added", name)
+		}
+	}
+
+	oldAdditional, oldHas := oldSchema["additionalProperties"]
+	newAdditional, newHas := newSchema["additionalProperties"]
+	oldClosed, newClosed := oldAdditional == false, newAdditional == false
+	switch {
+	case !oldClosed && newClosed:
+		d.add(path, ChangeAdditionalPropsClosed, true, "", "additional properties are no longer allowed")
+	case oldClosed && !newClosed:
+		d.add(path, ChangeAdditionalPropsOpened, false, "", "additional properties are now allowed")
+	case oldHas && newHas && !oldClosed:
+		d.compare(path+"/additionalProperties", oldAdditional, newAdditional)
+	}
+}
+
+// compareUnion matches the options of two anyOf or oneOf schemas by their
+// discriminator value or external tag, or by position when the options have
+// neither. A schema
+// that is not a union counts as a union of itself, so making a field
+// nullable reports only the added null option.
+func (d *schemaDiffer) compareUnion(path string, oldSchema, newSchema map[string]any) {
+	keyword, oldOptions := unionKeyword(oldSchema)
+	newKeyword, newOptions := unionKeyword(newSchema)
+	keyword = cmp.Or(newKeyword, keyword)
+	oldKeys, newKeys := optionKeys(oldOptions), optionKeys(newOptions)
+	for i, key := range oldKeys {
+		j := slices.Index(newKeys, key)
+		if j < 0 {
+			kind, detail := ChangeVariantRemoved, "variant %s was removed"
+			if isTagKey(key) {
+				kind, detail = ChangeDiscriminatorRemoved, "discriminator %s was removed"
+			}
+			d.add(path+"/"+keyword, kind, true, key, detail, key)
+			continue
+		}
+		d.compare(fmt.Sprintf("%s/%s/%d", path, keyword, j), oldOptions[i], newOptions[j])
+	}
+	for _, key := range newKeys {
+		if slices.Contains(oldKeys, key) {
+			continue
+		}
+		kind, detail := ChangeVariantAdded, "variant %s was added"
+		if isTagKey(key) {
+			kind, detail = ChangeDiscriminatorAdded, "discriminator %s was added"
+		}
+		d.add(path+"/"+keyword, kind, false, key, detail, key)
+	}
+}
+
+// unionKeyword returns the union keyword and options of schema, treating a
+// schema without one as a single option.
+func unionKeyword(schema map[string]any) (string, []any) {
+	if options, ok := unionOptions(schema); ok {
+		if _, ok := schema["oneOf"]; ok {
+			return "oneOf", options
+		}
+		return "anyOf", options
+	}
+	return "", []any{schema}
+}
+
+// constsAsEnum rewrites an "anyOf" of described consts, as rendered for
+// EnumDescriptions, into the "enum" it is equivalent to.
+func constsAsEnum(schema map[string]any) map[string]any {
+	options, ok := schema["anyOf"].([]any)
+	if !ok || len(options) == 0 {
+		return schema
+	}
+	values := make([]any, len(options))
+	for i, option := range options {
+		option, _ := option.(map[string]any)
+		value, ok := option["const"]
+		if !ok {
+			return schema
+		}
+		for keyword := range option {
+			if keyword != "const" && keyword != "description" {
+				return schema
+			}
+		}
+		values[i] = value
+	}
+	rewritten := maps.Clone(schema)
+	delete(rewritten, "anyOf")
+	rewritten["enum"] = values
+	return rewritten
+}
+
+func unionOptions(schema map[string]any) ([]any, bool) {
+	if options, ok := schema["anyOf"].([]any); ok {
+		return options, true
+	}
+	options, ok := schema["oneOf"].([]any)
+	return options, ok
+}
+
+// optionKeys names each union option: "prop=value" for a discriminated
+// option, "{Tag}" for an externally tagged one, "null" for the null option,
+// or its position otherwise. Options are taken as externally tagged when
+// every one that has no discriminator is an object with a single required
+// property, and no two share that property.
+func optionKeys(options []any) []string {
+	var (
+		keys = make([]string, len(options))
+		tags = map[string]int{}
+	)
+	for i, option := range options {
+		keys[i] = "#" + fmt.Sprint(i)
+		schema, _ := option.(map[string]any)
+		if schema["type"] == "null" {
+			keys[i] = "null"
+			continue
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for _, name := range slices.Sorted(maps.Keys(props)) {
+			prop, _ := props[name].(map[string]any)
+			if value, ok := prop["const"]; ok {
+				keys[i] = name + "=" + compactJSON(value)
+				break
+			}
+		}
+		if len(props) == 1 && strings.HasPrefix(keys[i], "#") {
+			for name := range props {
+				if requiredSet(schema)[name] {
+					tags[name] = i
+				}
+			}
+		}
+	}
+	untagged := 0
+	for _, key := range keys {
+		if strings.HasPrefix(key, "#") {
+			untagged++
+		}
+	}
+	if untagged > 0 && len(tags) == untagged {
+		for name, i := range tags {
+			keys[i] = "{" + name + "}"
+		}
+	}
+	return keys
+}
+
+// isTagKey reports whether an option key from optionKeys names a
+// discriminator value or external tag rather than a variant.
+func isTagKey(key string) bool {
+	return strings.Contains(key, "=") || strings.HasPrefix(key, "{")
+}
+
+func resolveRef(root, schema map[string]any) map[string]any {
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return schema
+	}
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if ref == "#" {
+		return root
+	} else if !ok {
+		return nil
+	}
+	defs, _ := root["$defs"].(map[string]any)
+	def, _ := defs[name].(map[string]any)
+	return def
+}
+
+func schemaTypes(schema map[string]any) map[string]bool {
+	switch typ := schema["type"].(type) {
+	case string:
+		return map[string]bool{typ: true}
+	case []any:
+		types := map[string]bool{}
+		for _, t := range typ {
+			if s, ok := t.(string); ok {
+				types[s] = true
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func requiredSet(schema map[string]any) map[string]bool {
+	required := map[string]bool{}
+	values, _ := schema["required"].([]any)
+	for _, value := range values {
+		if name, ok := value.(string); ok {
+			required[name] = true
+		}
+	}
+	return required
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func compactJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -171,7 +171,7 @@ func TestWriteSchemaUsesSchemaHardlines(t *testing.T) {
 	})
 
 	targetDir := t.TempDir()
-	changed, err := builder.writeSchema(typeID, targetDir, false)
+	changed, err := builder.writeSchema(typeID, "", targetDir, false)
 	require.NoError(t, err)
 	require.True(t, changed)
 
@@ -183,7 +183,7 @@ func TestWriteSchemaUsesSchemaHardlines(t *testing.T) {
 },"required":["name"],"additionalProperties":false}
 `, string(generated))
 
-	changed, err = builder.writeSchema(typeID, targetDir, false)
+	changed, err = builder.writeSchema(typeID, "", targetDir, false)
 	require.NoError(t, err)
 	require.False(t, changed)
 }
@@ -204,7 +204,7 @@ func TestWriteTemplateSchemaUsesSchemaHardlines(t *testing.T) {
 	})
 
 	targetDir := t.TempDir()
-	changed, err := builder.writeSchema(typeID, targetDir, false)
+	changed, err := builder.writeSchema(typeID, "", targetDir, false)
 	require.NoError(t, err)
 	require.True(t, changed)
 
@@ -40,10 +40,20 @@ func writeSchemaHardlines(sb *strings.Builder, schema json.Marshaler) error {
 		return writeArrayHardlines(sb, node)
 	case *ArrayNode:
 		return writeArrayHardlines(sb, *node)
+	case MapNode:
+		return writeMapHardlines(sb, node)
+	case *MapNode:
+		return writeMapHardlines(sb, *node)
 	case UnionTypeNode:
 		return writeUnionHardlines(sb, node)
 	case *UnionTypeNode:
 		return writeUnionHardlines(sb, *node)
+	case annotatedNode:
+		var inner strings.Builder
+		if err := writeSchemaHardlines(&inner, node.Schema); err != nil {
+			return err
+		}
+		return node.splice(sb, []byte(inner.String()))
 	default:
 		data, err := schema.MarshalJSON()
 		if err != nil {
@@ -74,7 +84,7 @@ func writeObjectHardlines(sb *strings.Builder, object ObjectNode) error {
 		for i, property := range object.Properties {
 			encodeString(sb, property.Name)
 			sb.WriteByte(':')
-			if err := writeSchemaHardlines(sb, property.Schema); err != nil {
+			if err := writeSchemaHardlines(sb, property.annotatedSchema()); err != nil {
 				return fmt.Errorf("object property %q: %w", property.Name, err)
 			}
 			if i < len(object.Properties)-1 {
@@ -131,6 +141,28 @@ func writeArrayHardlines(sb *strings.Builder, array ArrayNode) error {
 			return fmt.Errorf("array items: %w", err)
 		}
 	}
+	writeCountKeyword(sb, "minItems", array.MinItems)
+	writeCountKeyword(sb, "maxItems", array.MaxItems)
+	sb.WriteByte('}')
+	return nil
+}
+
+func writeMapHardlines(sb *strings.Builder, m MapNode) error {
+	sb.WriteString(`{"type":"object"`)
+	if m.Desc != "" {
+		sb.WriteString(`,"description":`)
+		encodeString(sb, m.Desc)
+	}
+	if m.PropertyNames != nil {
+		sb.WriteString(`,"propertyNames":`)
+		if err := writeSchemaHardlines(sb, m.PropertyNames); err != nil {
+			return fmt.Errorf("map propertyNames: %w", err)
+		}
+	}
+	sb.WriteString(`,"additionalProperties":`)
+	if err := writeSchemaHardlines(sb, m.Values); err != nil {
+		return fmt.Errorf("map additionalProperties: %w", err)
+	}
 	sb.WriteByte('}')
 	return nil
 }
@@ -159,10 +191,13 @@ func writeUnionHardlines(sb *strings.Builder, union UnionTypeNode) error {
 		if i > 0 {
 			sb.WriteString(",\n")
 		}
-		if err := writeObjectHardlines(sb, prependDiscriminator(option, union.DiscriminatorPropName)); err != nil {
+		if err := writeObjectHardlines(sb, union.option(option)); err != nil {
 			return fmt.Errorf("union option %d: %w", i, err)
 		}
 	}
+	if union.Nullable {
+		sb.WriteString(",\n" + `{"type":"null"}`)
+	}
 	sb.WriteString(`]}`)
 	return nil
 }
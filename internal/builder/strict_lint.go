@@ -0,0 +1,18 @@
+package builder
+
+import "errors"
+
+// LintStrict reports schema constructs that strict-mode providers, such as
+// OpenAI Structured Outputs, reject. It applies the openai-strict dialect's
+// checks to every schema method without writing a dialect profile.
+func (s SchemaBuilder) LintStrict() error {
+	var errs []error
+	for _, method := range s.SchemaMethods() {
+		schema, ok := s.GetSchema(method.Receiver)
+		if !ok {
+			continue
+		}
+		errs = append(errs, checkOpenAIStrict(s, schema, method.Receiver.TypeName))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,337 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+// stringerEnumField records a field that WithStringerEnum rendered from an
+// integer enum, so that its schema lists constant names rather than numbers.
+type stringerEnumField struct {
+	Enum syntax.TypeID
+	// Consts are the enum's constant identifiers and Names their wire names.
+	Consts []string
+	Names  []string
+}
+
+// StringerEnum is an integer enum that is encoded as its constant names by
+// generated MarshalText and UnmarshalText methods.
+type StringerEnum struct {
+	// Receiver is the type the methods are declared on: the enum itself, or
+	// a local adapter type for an owner-side adapter.
+	Receiver string
+	// TypeName is the enum type as written in generated code.
+	TypeName string
+	Values   []StringerEnumValue
+}
+
+type StringerEnumValue struct {
+	Name string
+	// Const is the constant as written in generated code.
+	Const string
+	// Marshal is set on the first name of each distinct value, which
+	// MarshalText writes.
+	Marshal bool
+}
+
+// EnumProp is an owner field whose enum cannot carry methods of its own, so
+// the owner's generated MarshalJSON and UnmarshalJSON route it through an
+// adapter type.
+type EnumProp struct {
+	FieldName string
+	StructTag string
+	Adapter   string
+	TypeName  string
+	Wrapper   syntax.WrapperKind
+}
+
+// WrapperType is the type of the field in the owner's wrapper struct.
+func (p EnumProp) WrapperType() string {
+	switch p.Wrapper {
+	case syntax.WrapperOptional, syntax.WrapperNullable:
+		return fmt.Sprintf("%s[%s]", p.wrapperName(), p.Adapter)
+	default:
+		return p.Adapter
+	}
+}
+
+// Wrap converts expr, a field value, to the wrapper field's type.
+func (p EnumProp) Wrap(expr string) string {
+	switch p.Wrapper {
+	case syntax.WrapperOptional, syntax.WrapperNullable:
+		return fmt.Sprintf("%s{Present: %s.Present, Value: %s(%s.Value)}", p.WrapperType(), expr, p.Adapter, expr)
+	default:
+		return fmt.Sprintf("%s(%s)", p.Adapter, expr)
+	}
+}
+
+// Unwrap converts expr, a wrapper field value, back to the field's type.
+func (p EnumProp) Unwrap(expr string) string {
+	switch p.Wrapper {
+	case syntax.WrapperOptional, syntax.WrapperNullable:
+		return fmt.Sprintf("%s[%s]{Present: %s.Present, Value: %s(%s.Value)}", p.wrapperName(), p.TypeName, expr, p.TypeName, expr)
+	default:
+		return fmt.Sprintf("%s(%s)", p.TypeName, expr)
+	}
+}
+
+func (p EnumProp) wrapperName() string {
+	return "genjsonschema." + strings.TrimPrefix(p.Wrapper.String(), "jsonschema.")
+}
+
+// UsesEnumAdapterWrappers reports whether an owner-side adapter wraps a
+// field in Optional or Nullable, which the generated code then names.
+func (s SchemaBuilder) UsesEnumAdapterWrappers() bool {
+	for _, special := range s.SpecialTypes {
+		for _, prop := range special.EnumProps {
+			if prop.Wrapper != syntax.WrapperNone {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringerEnums decides how each integer enum rendered by WithStringerEnum
+// decodes its constant names. An enum declared in this package gets
+// MarshalText and UnmarshalText of its own when nothing else depends on its
+// numeric encoding. Otherwise its fields are adapted by their owners, which
+// are returned by owner type name.
+func (s SchemaBuilder) stringerEnums(importMap *ImportMap) ([]StringerEnum, map[string][]EnumProp, error) {
+	var (
+		enums    []StringerEnum
+		adapters = map[string][]EnumProp{}
+		seen     = map[string]bool{}
+		qualify  = importMap.Qualifier()
+	)
+	owners := make([]string, 0, len(s.stringerFields))
+	for owner := range s.stringerFields {
+		owners = append(owners, owner)
+	}
+	slices.Sort(owners)
+	for _, owner := range owners {
+		ownerType, err := s.localStruct(owner)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields := s.stringerFields[owner]
+		fieldNames := make([]string, 0, len(fields))
+		for name := range fields {
+			fieldNames = append(fieldNames, name)
+		}
+		slices.Sort(fieldNames)
+		for _, fieldName := range fieldNames {
+			field := fields[fieldName]
+			enum, err := s.lookupEnum(field.Enum)
+			if err != nil {
+				return nil, nil, err
+			}
+			typeName := types.TypeString(enum, qualify)
+			ownMethods := s.stringerEnumMethodsAllowed(enum)
+			receiver := typeName
+			if !ownMethods {
+				receiver = "__jsonschema_enum_" + strings.ReplaceAll(typeName, ".", "_")
+				if hasMarshalMethods(types.NewPointer(ownerType)) {
+					return nil, nil, fmt.Errorf("WithStringerEnum field %s.%s: %s cannot be given MarshalText and UnmarshalText, and %s declares its own JSON methods, so no adapter can be generated", owner, fieldName, typeName, owner)
+				}
+				st := ownerType.Underlying().(*types.Struct)
+				index := fieldIndex(st, fieldName)
+				wrapper, _ := wrapperKind(st.Field(index).Type())
+				adapters[owner] = append(adapters[owner], EnumProp{
+					FieldName: fieldName,
+					StructTag: "`" + st.Tag(index) + "`",
+					Adapter:   receiver,
+					TypeName:  typeName,
+					Wrapper:   wrapper,
+				})
+			}
+			if seen[receiver] {
+				continue
+			}
+			seen[receiver] = true
+			values, err := stringerEnumValues(enum, field, qualify)
+			if err != nil {
+				return nil, nil, err
+			}
+			enums = append(enums, StringerEnum{Receiver: receiver, TypeName: typeName, Values: values})
+		}
+	}
+	return enums, adapters, nil
+}
+
+func (s SchemaBuilder) localStruct(name string) (*types.Named, error) {
+	obj, ok := s.Scan.Pkg.Types.Scope().Lookup(name).(*types.TypeName)
+	if ok {
+		if named, ok := obj.Type().(*types.Named); ok {
+			if _, ok = named.Underlying().(*types.Struct); ok {
+				return named, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("WithStringerEnum owner %s is not a struct type", name)
+}
+
+func (s SchemaBuilder) lookupEnum(id syntax.TypeID) (*types.Named, error) {
+	scan, ok := s.Scan.GetPackage(id.PkgPath)
+	if ok {
+		if obj, ok := scan.Pkg.Types.Scope().Lookup(id.TypeName).(*types.TypeName); ok {
+			if named, ok := obj.Type().(*types.Named); ok {
+				return named, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("WithStringerEnum type %s not found", id)
+}
+
+// stringerEnumMethodsAllowed reports whether MarshalText and UnmarshalText
+// can be declared on enum. The methods change how every value of the type
+// encodes, so the enum must be declared in this package, must not marshal
+// itself already, and every struct field in the package that holds it must
+// be rendered by WithStringerEnum.
+func (s SchemaBuilder) stringerEnumMethodsAllowed(enum *types.Named) bool {
+	if enum.Obj().Pkg().Path() != s.Scan.Pkg.PkgPath || hasMarshalMethods(types.NewPointer(enum)) {
+		return false
+	}
+	scope := s.Scan.Pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := range st.NumFields() {
+			field := st.Field(i)
+			if !mentionsType(field.Type(), enum) {
+				continue
+			}
+			if _, ok := s.stringerFields[name][field.Name()]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func hasMarshalMethods(typ types.Type) bool {
+	for _, method := range []string{"MarshalJSON", "UnmarshalJSON", "MarshalText", "UnmarshalText"} {
+		if obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, method); obj != nil {
+			if _, ok := obj.(*types.Func); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mentionsType reports whether typ is target or is composed from it.
+func mentionsType(typ types.Type, target *types.Named) bool {
+	switch t := typ.(type) {
+	case *types.Named:
+		if types.Identical(t, target) {
+			return true
+		}
+		for i := range t.TypeArgs().Len() {
+			if mentionsType(t.TypeArgs().At(i), target) {
+				return true
+			}
+		}
+	case *types.Pointer:
+		return mentionsType(t.Elem(), target)
+	case *types.Slice:
+		return mentionsType(t.Elem(), target)
+	case *types.Array:
+		return mentionsType(t.Elem(), target)
+	case *types.Map:
+		return mentionsType(t.Key(), target) || mentionsType(t.Elem(), target)
+	case *types.Struct:
+		for i := range t.NumFields() {
+			if mentionsType(t.Field(i).Type(), target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func stringerEnumValues(enum *types.Named, field stringerEnumField, qualify types.Qualifier) ([]StringerEnumValue, error) {
+	var (
+		values []StringerEnumValue
+		marked = map[string]bool{}
+		prefix = qualify(enum.Obj().Pkg())
+	)
+	if prefix != "" {
+		prefix += "."
+	}
+	for i, name := range field.Consts {
+		obj, ok := enum.Obj().Pkg().Scope().Lookup(name).(*types.Const)
+		if !ok {
+			return nil, fmt.Errorf("WithStringerEnum constant %s.%s not found", enum.Obj().Name(), name)
+		}
+		value := obj.Val().ExactString()
+		values = append(values, StringerEnumValue{Name: field.Names[i], Const: prefix + name, Marshal: !marked[value]})
+		marked[value] = true
+	}
+	return values, nil
+}
+
+// stringerEnumNames returns the wire names that WithStringerEnum gives the
+// constants of enum: a WithEnumName override, else a jsonschema:"name"
+// comment, else the identifier. Constants with different values cannot
+// share a name.
+func (s SchemaBuilder) stringerEnumNames(enum syntax.TypeID, scan syntax.ScanResult, values []syntax.ValueSpec) (stringerEnumField, error) {
+	field := stringerEnumField{Enum: enum}
+	named := map[string]string{}
+	for _, v := range values {
+		ident := v.Value().Names[0].Name
+		constID := syntax.TypeID{PkgPath: enum.PkgPath, TypeName: ident}
+		name, ok := s.enumNames[constID]
+		if ok {
+			s.usedEnumNames[constID] = true
+		} else {
+			var err error
+			if name, ok, err = v.EnumName(); err != nil {
+				return field, err
+			} else if !ok {
+				name = ident
+			}
+		}
+		if other, ok := named[name]; ok && constValue(scan, other) != constValue(scan, ident) {
+			return field, fmt.Errorf("WithStringerEnum %s: constants %s and %s are both named %q", enum.TypeName, other, ident, name)
+		}
+		named[name] = ident
+		field.Consts = append(field.Consts, ident)
+		field.Names = append(field.Names, name)
+	}
+	return field, nil
+}
+
+// checkEnumNames rejects a string enum constant that carries a wire name, as
+// a string constant's value is already its wire name.
+func (s SchemaBuilder) checkEnumNames(enum syntax.TypeID, values []syntax.ValueSpec) error {
+	for _, v := range values {
+		ident := v.Value().Names[0].Name
+		_, named := s.enumNames[syntax.TypeID{PkgPath: enum.PkgPath, TypeName: ident}]
+		_, directive, err := v.EnumName()
+		if err != nil {
+			return err
+		}
+		if named || directive {
+			return fmt.Errorf("constant %s.%s: enum names apply only to integer enums rendered with WithStringerEnum; a string constant's value is its wire name", enum.TypeName, ident)
+		}
+	}
+	return nil
+}
+
+func constValue(scan syntax.ScanResult, name string) string {
+	if obj, ok := scan.Pkg.Types.Scope().Lookup(name).(*types.Const); ok {
+		return obj.Val().ExactString()
+	}
+	return name
+}
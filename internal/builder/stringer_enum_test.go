@@ -0,0 +1,228 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestStringerEnumMethods(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Level int
+
+const (
+	Low Level = iota
+	High
+	Urgent = High
+)
+
+type Shared int
+
+const (
+	Small Shared = iota
+	Large
+)
+
+type Owner struct {
+	Level  Level                       `+"`json:\"level\"`"+`
+	Size   jsonschema.Optional[Shared] `+"`json:\"size,omitzero\"`"+`
+}
+
+type Other struct {
+	Size Shared `+"`json:\"size\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithStringerEnum(Owner{}.Level),
+	jsonschema.WithStringerEnum(Owner{}.Size),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	data, err := builder.renderGoCode()
+	require.NoError(t, err)
+	code := string(data)
+
+	// Level is only rendered by WithStringerEnum, so it carries the methods.
+	require.Contains(t, code, "func (v Level) MarshalText() ([]byte, error) {")
+	require.Contains(t, code, "case High:\n\t\treturn []byte(\"High\"), nil")
+	require.NotContains(t, code, `return []byte("Urgent")`)
+	require.Contains(t, code, "case \"Urgent\":\n\t\t*v = Urgent")
+	require.NotContains(t, code, "__jsonschema_enum_Level")
+
+	// Other encodes Shared as an integer, so Owner adapts its field instead.
+	require.Contains(t, code, "type __jsonschema_enum_Shared Shared")
+	require.Contains(t, code, "Size genjsonschema.Optional[__jsonschema_enum_Shared] `json:\"size,omitzero\"`")
+	require.NotContains(t, code, "func (v Shared) MarshalText")
+}
+
+func TestStringerEnumOwnerWithJSONMethods(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+
+func (l Level) MarshalJSON() ([]byte, error) { return json.Marshal(int(l)) }
+
+type Owner struct {
+	Level Level `+"`json:\"level\"`"+`
+}
+
+func (o *Owner) UnmarshalJSON(data []byte) error { return nil }
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema, jsonschema.WithStringerEnum(Owner{}.Level))
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	_, err = builder.renderGoCode()
+	require.ErrorContains(t, err, "WithStringerEnum field Owner.Level: Level cannot be given MarshalText and UnmarshalText, and Owner declares its own JSON methods")
+}
+
+func TestStringerEnumNames(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, `
+type Level int
+
+const (
+	// Debug is for diagnostics.
+	//
+	// jsonschema:"debug"
+	Debug Level = iota
+	Info        // jsonschema:"info"
+	Warning
+	Verbose = Debug // jsonschema:"debug"
+)
+
+type Owner struct {
+	Level Level `+"`json:\"level\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithStringerEnum(Owner{}.Level, jsonschema.EnumDescriptions()),
+	jsonschema.WithEnumName(Warning, "warn"),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Owner")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"object","properties":{"level":{"type":"string","anyOf":[
+		{"const":"debug","description":"Debug is for diagnostics."},
+		{"const":"info"},
+		{"const":"warn"},
+		{"const":"debug"}
+	]}},"required":["level"],"additionalProperties":false}`, string(data))
+
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	require.Contains(t, string(code), "case Warning:\n\t\treturn []byte(\"warn\"), nil")
+	require.Contains(t, string(code), "case \"info\":\n\t\t*v = Info")
+}
+
+func TestStringerEnumNameDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		consts    string
+		options   string
+		wantError string
+	}{
+		{
+			name:      "name for a numeric enum",
+			options:   `jsonschema.WithEnum(Owner{}.Level), jsonschema.WithEnumName(Low, "low")`,
+			wantError: "WithEnumName: constant " + "%s.Low is not a value of an integer enum rendered with WithStringerEnum",
+		},
+		{
+			name:      "conflicting names",
+			options:   `jsonschema.WithStringerEnum(Owner{}.Level), jsonschema.WithEnumName(Low, "low"), jsonschema.WithEnumName(Low, "min")`,
+			wantError: `is named both "low" and "min"`,
+		},
+		{
+			name:      "shared name",
+			options:   `jsonschema.WithStringerEnum(Owner{}.Level), jsonschema.WithEnumName(High, "Low")`,
+			wantError: `WithStringerEnum Level: constants Low and High are both named "Low"`,
+		},
+		{
+			name:      "malformed directive",
+			consts:    `const Extra Level = 5 // jsonschema:extra`,
+			options:   `jsonschema.WithStringerEnum(Owner{}.Level)`,
+			wantError: `constant Extra at`,
+		},
+		{
+			name:      "string enum",
+			options:   `jsonschema.WithStringerEnum(Owner{}.Level), jsonschema.WithEnum(Owner{}.Status), jsonschema.WithEnumName(Open, "o")`,
+			wantError: "constant Status.Open: enum names apply only to integer enums",
+		},
+		{
+			name:      "not a constant expression",
+			options:   `jsonschema.WithStringerEnum(Owner{}.Level), jsonschema.WithEnumName(Level(1), "one")`,
+			wantError: "WithEnumName expects a constant identifier",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			targetDir := writeAnnotationsFixture(t, `
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+`+tc.consts+`
+
+type Status string
+
+const Open Status = "open"
+
+type Owner struct {
+	Level  Level  `+"`json:\"level\"`"+`
+	Status Status `+"`json:\"status\"`"+`
+}
+`, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema, `+tc.options+`)
+`)
+			pkgs, err := syntax.Load(targetDir)
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			require.Empty(t, pkgs[0].Errors)
+
+			_, err = New(pkgs[0])
+			want := tc.wantError
+			if strings.Contains(want, "%s") {
+				want = fmt.Sprintf(want, pkgs[0].PkgPath)
+			}
+			require.ErrorContains(t, err, want)
+		})
+	}
+}
@@ -27,3 +27,31 @@ func (Paint) Schema() json.RawMessage {
 	}
 	return data
 }
+
+// IsValid reports whether v is one of the Color constants.
+func (v Color) IsValid() bool {
+	switch v {
+	case ColorRed, ColorGreen, ColorBlue:
+		return true
+	}
+	return false
+}
+
+// ColorValues returns the Color constants in declaration order.
+func ColorValues() []Color {
+	return []Color{ColorRed, ColorGreen, ColorBlue}
+}
+
+// ParseColor returns the Color constant whose decimal value is s.
+func ParseColor(s string) (Color, error) {
+	switch s {
+	case "0":
+		return ColorRed, nil
+	case "1":
+		return ColorGreen, nil
+	case "2":
+		return ColorBlue, nil
+	}
+	var zero Color
+	return zero, fmt.Errorf("unknown Color %q", s)
+}
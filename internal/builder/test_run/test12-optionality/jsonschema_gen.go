@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	jsonschema "github.com/santhosh-tekuri/jsonschema/v6"
+	genjsonschema "github.com/tylergannon/go-gen-jsonschema"
 )
 
 //go:embed jsonschema
@@ -28,29 +29,32 @@ var (
 )
 
 func init() {
-	compile := func(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
-		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
-		}
-		c := jsonschema.NewCompiler()
-		url := typeName + ".json"
-		if err := c.AddResource(url, doc); err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
-		}
-		sch, err := c.Compile(url)
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
-		}
-		return sch
-	}
-
 	{
 		var __zero Config
-		__gen_jsonschema_compiled_Config = compile("Config", __zero.Schema())
+		__gen_jsonschema_compiled_Config = __gen_jsonschema_compile("Config", __zero.Schema())
 	}
 }
 
+// __gen_jsonschema_compile compiles a generated schema. The schemas are
+// produced by the generator, so a failure here is a generator bug.
+func __gen_jsonschema_compile(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
+	}
+	c := jsonschema.NewCompiler()
+	c.AssertFormat()
+	url := typeName + ".json"
+	if err := c.AddResource(url, doc); err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
+	}
+	sch, err := c.Compile(url)
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
+	}
+	return sch
+}
+
 func (Config) Schema() json.RawMessage {
 	const fileName = "jsonschema/Config.json"
 	data, err := __gen_jsonschema_fs.ReadFile(fileName)
@@ -61,10 +65,30 @@ func (Config) Schema() json.RawMessage {
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Config.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Config) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Config.Validate(inst)
+	if err = __gen_jsonschema_compiled_Config.Validate(inst); err != nil {
+		var __zero Config
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseConfig validates data against the schema for Config and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseConfig(data []byte) (Config, error) {
+	var value Config
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Config", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Config
+		return zero, genjsonschema.NewParseError("Config", err)
+	}
+	return value, nil
 }
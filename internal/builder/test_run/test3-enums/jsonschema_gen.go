@@ -54,3 +54,17 @@ func (SliceOfPointerToRemoteEnum) Schema() json.RawMessage {
 	}
 	return data
 }
+
+// IsValid reports whether v is one of the EnumType constants.
+func (v EnumType) IsValid() bool {
+	switch v {
+	case EnumVal1, EnumVal2, EnumVal3, EnumVal4:
+		return true
+	}
+	return false
+}
+
+// EnumTypeValues returns the EnumType constants in declaration order.
+func EnumTypeValues() []EnumType {
+	return []EnumType{EnumVal1, EnumVal2, EnumVal3, EnumVal4}
+}
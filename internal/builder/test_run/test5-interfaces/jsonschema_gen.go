@@ -80,6 +80,69 @@ func (f *FancyStruct) UnmarshalJSON(data []byte) (err error) {
 	return nil
 }
 
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// FancyStruct. Interface values are written with their discriminator.
+func (f FancyStruct) MarshalJSON() ([]byte, error) {
+	type Alias FancyStruct
+	type Wrapper struct {
+		Alias
+		IFace  json.RawMessage `json:"iface"`
+		IFaces json.RawMessage `json:"ifaces"`
+	}
+	var (
+		wrapper = Wrapper{Alias: Alias(f)}
+		err     error
+	)
+
+	if wrapper.IFace, err = __jsonMarshal__interfaces__TestInterface(f.IFace); err != nil {
+		return nil, fmt.Errorf("field iface: %w", err)
+	}
+
+	if f.IFaces != nil {
+		__raw1 := make([]json.RawMessage, len(f.IFaces))
+		for __index, __value := range f.IFaces {
+			if __raw1[__index], err = __jsonMarshal__interfaces__TestInterface(__value); err != nil {
+				return nil, fmt.Errorf("field ifaces[%d]: %w", __index, err)
+			}
+		}
+		if wrapper.IFaces, err = json.Marshal(__raw1); err != nil {
+			return nil, fmt.Errorf("field ifaces: %w", err)
+		}
+	}
+
+	return json.Marshal(wrapper)
+}
+
+// IsValid reports whether v is one of the MyEnumType constants.
+func (v MyEnumType) IsValid() bool {
+	switch v {
+	case Val1, Val2, Val3, Val4:
+		return true
+	}
+	return false
+}
+
+// MyEnumTypeValues returns the MyEnumType constants in declaration order.
+func MyEnumTypeValues() []MyEnumType {
+	return []MyEnumType{Val1, Val2, Val3, Val4}
+}
+
+// ParseMyEnumType returns the MyEnumType constant whose value is s.
+func ParseMyEnumType(s string) (MyEnumType, error) {
+	switch s {
+	case "val1":
+		return Val1, nil
+	case "val2":
+		return Val2, nil
+	case "val3":
+		return Val3, nil
+	case "val4":
+		return Val4, nil
+	}
+	var zero MyEnumType
+	return zero, fmt.Errorf("unknown MyEnumType %q", s)
+}
+
 // UnmarshalYAML translates YAML into the JSON data model before decoding
 // FancyStruct with its JSON contract.
 func (f *FancyStruct) UnmarshalYAML(node *yaml.Node) error {
@@ -132,6 +195,64 @@ func __jsonUnmarshal__interfaces__TestInterface(data []byte) (TestInterface, err
 	}
 }
 
+func __jsonMarshal__interfaces__TestInterface(value TestInterface) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case TestInterface1, *TestInterface1:
+		discriminator = "TestInterface1"
+	case TestInterface2, *TestInterface2:
+		discriminator = "TestInterface2"
+	case *PointerToTestInterface:
+		discriminator = "PointerToTestInterface"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of TestInterface: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("type", discriminator, value)
+}
+
 func __jsonschema__unmarshalDiscriminatorError(discriminator json.RawMessage, err error) error {
 	return fmt.Errorf("unable to unmarshal discriminator value %v: %w", discriminator, err)
 }
+
+// __jsonschema__marshalWithDiscriminator encodes value, which must be a JSON
+// object, with the discriminator property written first. A value whose own
+// MarshalJSON already writes the discriminator is kept as it is.
+func __jsonschema__marshalWithDiscriminator(propName, discriminator string, value any) (json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("%T must marshal to a JSON object to carry discriminator %q", value, discriminator)
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if existing, ok := fields[propName]; ok {
+		var written string
+		if err = json.Unmarshal(existing, &written); err != nil || written != discriminator {
+			return nil, fmt.Errorf("%T writes %s %s, want %q", value, propName, existing, discriminator)
+		}
+		return data, nil
+	}
+	key, err := json.Marshal(propName)
+	if err != nil {
+		return nil, err
+	}
+	val, err := json.Marshal(discriminator)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)+len(key)+len(val)+2)
+	out = append(out, '{')
+	out = append(out, key...)
+	out = append(out, ':')
+	out = append(out, val...)
+	if data[1] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, data[1:]...), nil
+}
@@ -13,6 +13,7 @@ import (
 	yaml "go.yaml.in/yaml/v4"
 
 	jsonschema "github.com/santhosh-tekuri/jsonschema/v6"
+	genjsonschema "github.com/tylergannon/go-gen-jsonschema"
 )
 
 //go:embed jsonschema
@@ -42,37 +43,46 @@ func __gen_jsonschema_yamlToJSON(data []byte) ([]byte, error) {
 
 // Compiled JSON schemas for validation, initialized once at startup.
 var (
-	__gen_jsonschema_compiled_Plain *jsonschema.Schema
-	__gen_jsonschema_compiled_Owner *jsonschema.Schema
+	__gen_jsonschema_compiled_Plain    *jsonschema.Schema
+	__gen_jsonschema_compiled_Envelope *jsonschema.Schema
+	__gen_jsonschema_compiled_Owner    *jsonschema.Schema
 )
 
 func init() {
-	compile := func(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
-		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
-		}
-		c := jsonschema.NewCompiler()
-		url := typeName + ".json"
-		if err := c.AddResource(url, doc); err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
-		}
-		sch, err := c.Compile(url)
-		if err != nil {
-			panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
-		}
-		return sch
+	{
+		var __zero Plain
+		__gen_jsonschema_compiled_Plain = __gen_jsonschema_compile("Plain", __zero.Schema())
 	}
 
 	{
-		var __zero Plain
-		__gen_jsonschema_compiled_Plain = compile("Plain", __zero.Schema())
+		var __zero Envelope
+		__gen_jsonschema_compiled_Envelope = __gen_jsonschema_compile("Envelope", __zero.Schema())
 	}
 
 	{
 		var __zero Owner
-		__gen_jsonschema_compiled_Owner = compile("Owner", __zero.Schema())
+		__gen_jsonschema_compiled_Owner = __gen_jsonschema_compile("Owner", __zero.Schema())
+	}
+}
+
+// __gen_jsonschema_compile compiles a generated schema. The schemas are
+// produced by the generator, so a failure here is a generator bug.
+func __gen_jsonschema_compile(typeName string, schemaData json.RawMessage) *jsonschema.Schema {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaData))
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to parse schema for %s: %s", typeName, err))
+	}
+	c := jsonschema.NewCompiler()
+	c.AssertFormat()
+	url := typeName + ".json"
+	if err := c.AddResource(url, doc); err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to add schema resource for %s: %s", typeName, err))
+	}
+	sch, err := c.Compile(url)
+	if err != nil {
+		panic(fmt.Sprintf("go-gen-jsonschema: failed to compile schema for %s: %s", typeName, err))
 	}
+	return sch
 }
 
 func (Plain) Schema() json.RawMessage {
@@ -84,6 +94,15 @@ func (Plain) Schema() json.RawMessage {
 	return data
 }
 
+func (Envelope) Schema() json.RawMessage {
+	const fileName = "jsonschema/Envelope.json"
+	data, err := __gen_jsonschema_fs.ReadFile(fileName)
+	if err != nil {
+		__gen_jsonschema_panic(fileName, err)
+	}
+	return data
+}
+
 func (Owner) Schema() json.RawMessage {
 	const fileName = "jsonschema/Owner.json"
 	data, err := __gen_jsonschema_fs.ReadFile(fileName)
@@ -94,16 +113,37 @@ func (Owner) Schema() json.RawMessage {
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Plain.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Plain) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Plain.Validate(inst)
+	if err = __gen_jsonschema_compiled_Plain.Validate(inst); err != nil {
+		var __zero Plain
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParsePlain validates data against the schema for Plain and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParsePlain(data []byte) (Plain, error) {
+	var value Plain
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Plain", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Plain
+		return zero, genjsonschema.NewParseError("Plain", err)
+	}
+	return value, nil
 }
 
 // ValidateYAML validates YAML against the JSON Schema for Plain.
-// YAML is interpreted using the schema's JSON property names.
+// YAML is interpreted using the schema's JSON property names. Schema
+// violations are returned as a *genjsonschema.ValidationError.
 func (Plain) ValidateYAML(data []byte) error {
 	jsonData, err := __gen_jsonschema_yamlToJSON(data)
 	if err != nil {
@@ -113,20 +153,115 @@ func (Plain) ValidateYAML(data []byte) error {
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Plain.Validate(inst)
+	if err = __gen_jsonschema_compiled_Plain.Validate(inst); err != nil {
+		var __zero Plain
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParsePlainYAML translates YAML into the JSON data model, then validates
+// and decodes it like ParsePlain.
+func ParsePlainYAML(data []byte) (Plain, error) {
+	jsonData, err := __gen_jsonschema_yamlToJSON(data)
+	if err != nil {
+		var zero Plain
+		return zero, genjsonschema.NewParseError("Plain", err)
+	}
+	return ParsePlain(jsonData)
+}
+
+// ValidateJSON validates the given JSON bytes against the schema for Envelope.
+// Schema violations are returned as a *genjsonschema.ValidationError.
+func (Envelope) ValidateJSON(data []byte) error {
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if err = __gen_jsonschema_compiled_Envelope.Validate(inst); err != nil {
+		var __zero Envelope
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseEnvelope validates data against the schema for Envelope and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseEnvelope(data []byte) (Envelope, error) {
+	var value Envelope
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Envelope", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Envelope
+		return zero, genjsonschema.NewParseError("Envelope", err)
+	}
+	return value, nil
+}
+
+// ValidateYAML validates YAML against the JSON Schema for Envelope.
+// YAML is interpreted using the schema's JSON property names. Schema
+// violations are returned as a *genjsonschema.ValidationError.
+func (Envelope) ValidateYAML(data []byte) error {
+	jsonData, err := __gen_jsonschema_yamlToJSON(data)
+	if err != nil {
+		return err
+	}
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(jsonData))
+	if err != nil {
+		return err
+	}
+	if err = __gen_jsonschema_compiled_Envelope.Validate(inst); err != nil {
+		var __zero Envelope
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseEnvelopeYAML translates YAML into the JSON data model, then validates
+// and decodes it like ParseEnvelope.
+func ParseEnvelopeYAML(data []byte) (Envelope, error) {
+	jsonData, err := __gen_jsonschema_yamlToJSON(data)
+	if err != nil {
+		var zero Envelope
+		return zero, genjsonschema.NewParseError("Envelope", err)
+	}
+	return ParseEnvelope(jsonData)
 }
 
 // ValidateJSON validates the given JSON bytes against the schema for Owner.
+// Schema violations are returned as a *genjsonschema.ValidationError.
 func (Owner) ValidateJSON(data []byte) error {
 	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Owner.Validate(inst)
+	if err = __gen_jsonschema_compiled_Owner.Validate(inst); err != nil {
+		var __zero Owner
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseOwner validates data against the schema for Owner and then
+// decodes it. Failures are returned as a *genjsonschema.ParseError whose Kind
+// tells schema violations apart from decode failures.
+func ParseOwner(data []byte) (Owner, error) {
+	var value Owner
+	if err := value.ValidateJSON(data); err != nil {
+		return value, genjsonschema.NewParseError("Owner", err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var zero Owner
+		return zero, genjsonschema.NewParseError("Owner", err)
+	}
+	return value, nil
 }
 
 // ValidateYAML validates YAML against the JSON Schema for Owner.
-// YAML is interpreted using the schema's JSON property names.
+// YAML is interpreted using the schema's JSON property names. Schema
+// violations are returned as a *genjsonschema.ValidationError.
 func (Owner) ValidateYAML(data []byte) error {
 	jsonData, err := __gen_jsonschema_yamlToJSON(data)
 	if err != nil {
@@ -136,7 +271,69 @@ func (Owner) ValidateYAML(data []byte) error {
 	if err != nil {
 		return err
 	}
-	return __gen_jsonschema_compiled_Owner.Validate(inst)
+	if err = __gen_jsonschema_compiled_Owner.Validate(inst); err != nil {
+		var __zero Owner
+		return genjsonschema.NewValidationError(err, __zero.Schema())
+	}
+	return nil
+}
+
+// ParseOwnerYAML translates YAML into the JSON data model, then validates
+// and decodes it like ParseOwner.
+func ParseOwnerYAML(data []byte) (Owner, error) {
+	jsonData, err := __gen_jsonschema_yamlToJSON(data)
+	if err != nil {
+		var zero Owner
+		return zero, genjsonschema.NewParseError("Owner", err)
+	}
+	return ParseOwner(jsonData)
+}
+
+// UnmarshalJSON is a generated custom json.Unmarshaler implementation for
+// Envelope.
+func (e *Envelope) UnmarshalJSON(data []byte) (err error) {
+	type Alias Envelope
+	type Wrapper struct {
+		Alias
+		Attachment json.RawMessage `json:"attachment" yaml:"attachment"`
+	}
+	var wrapper Wrapper
+	if err = json.Unmarshal(data, &wrapper); err != nil {
+		return err
+	}
+	__next := Envelope(wrapper.Alias)
+
+	if len(wrapper.Attachment) > 0 && string(wrapper.Attachment) != "null" {
+		if __next.Attachment.Value, err = __jsonUnmarshal__v1_interfaces_options__IFace__Envelope__Attachment(wrapper.Attachment); err != nil {
+			return err
+		}
+		__next.Attachment.Present = true
+	}
+
+	*e = __next
+	return nil
+}
+
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// Envelope. Interface values are written with their discriminator.
+func (e Envelope) MarshalJSON() ([]byte, error) {
+	type Alias Envelope
+	type Wrapper struct {
+		Alias
+		Attachment json.RawMessage `json:"attachment" yaml:"attachment"`
+	}
+	var (
+		wrapper = Wrapper{Alias: Alias(e)}
+		err     error
+	)
+
+	if !e.Attachment.Present {
+		wrapper.Attachment = json.RawMessage("null")
+	} else if wrapper.Attachment, err = __jsonMarshal__v1_interfaces_options__IFace__Envelope__Attachment(e.Attachment.Value); err != nil {
+		return nil, fmt.Errorf("field attachment: %w", err)
+	}
+
+	return json.Marshal(wrapper)
 }
 
 // UnmarshalJSON is a generated custom json.Unmarshaler implementation for
@@ -189,6 +386,61 @@ func (o *Owner) UnmarshalJSON(data []byte) (err error) {
 	return nil
 }
 
+// MarshalJSON is a generated custom json.Marshaler implementation for
+// Owner. Interface values are written with their discriminator.
+func (o Owner) MarshalJSON() ([]byte, error) {
+	type Alias Owner
+	type Wrapper struct {
+		Alias
+		IF         json.RawMessage `json:"if" yaml:"yaml_if"`
+		IFaces     json.RawMessage `json:"ifs" yaml:"yaml_ifs"`
+		OptionalIF json.RawMessage `json:"optional_if,omitzero" yaml:"yaml_optional"`
+	}
+	var (
+		wrapper = Wrapper{Alias: Alias(o)}
+		err     error
+	)
+
+	if wrapper.IF, err = __jsonMarshal__v1_interfaces_options__IFace__Owner__IF(o.IF); err != nil {
+		return nil, fmt.Errorf("field if: %w", err)
+	}
+
+	if o.IFaces != nil {
+		__raw1 := make([]json.RawMessage, len(o.IFaces))
+		for __index, __value := range o.IFaces {
+			if __raw1[__index], err = __jsonMarshal__v1_interfaces_options__IFace__Owner__IFaces(__value); err != nil {
+				return nil, fmt.Errorf("field ifs[%d]: %w", __index, err)
+			}
+		}
+		if wrapper.IFaces, err = json.Marshal(__raw1); err != nil {
+			return nil, fmt.Errorf("field ifs: %w", err)
+		}
+	}
+
+	if o.OptionalIF.Present {
+		if wrapper.OptionalIF, err = __jsonMarshal__v1_interfaces_options__IFace__Owner__OptionalIF(o.OptionalIF.Value); err != nil {
+			return nil, fmt.Errorf("field optional_if: %w", err)
+		}
+	}
+
+	return json.Marshal(wrapper)
+}
+
+// UnmarshalYAML translates YAML into the JSON data model before decoding
+// Envelope with its JSON contract.
+func (e *Envelope) UnmarshalYAML(node *yaml.Node) error {
+	data, err := __gen_jsonschema_yamlNodeToJSON(node)
+	if err != nil {
+		return err
+	}
+	var next Envelope
+	if err := json.Unmarshal(data, &next); err != nil {
+		return err
+	}
+	*e = next
+	return nil
+}
+
 // UnmarshalYAML translates YAML into the JSON data model before decoding
 // Owner with its JSON contract.
 func (o *Owner) UnmarshalYAML(node *yaml.Node) error {
@@ -218,6 +470,54 @@ func (p *Plain) UnmarshalYAML(node *yaml.Node) error {
 	*p = next
 	return nil
 }
+func __jsonUnmarshal__v1_interfaces_options__IFace__Envelope__Attachment(data []byte) (IFace, error) {
+	var (
+		temp          map[string]json.RawMessage
+		discriminator string
+		err           = json.Unmarshal(data, &temp)
+	)
+
+	if err != nil {
+		return nil, err
+	} else if _tempDiscriminator, ok := temp["!kind"]; !ok {
+		// per-field discriminator property
+		return nil, fmt.Errorf("no discriminator property '%s' found", "!kind")
+	} else if err = json.Unmarshal(_tempDiscriminator, &discriminator); err != nil {
+		return nil, __jsonschema__unmarshalDiscriminatorError(_tempDiscriminator, err)
+	}
+	switch discriminator {
+	case "one":
+		var obj Impl1
+		if err = json.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case "two":
+		var obj Impl2
+		if err = json.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("unknown discriminator: %s", discriminator)
+	}
+}
+
+func __jsonMarshal__v1_interfaces_options__IFace__Envelope__Attachment(value IFace) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case Impl1, *Impl1:
+		discriminator = "one"
+	case Impl2, *Impl2:
+		discriminator = "two"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of IFace: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("!kind", discriminator, value)
+}
+
 func __jsonUnmarshal__v1_interfaces_options__IFace__Owner__IF(data []byte) (IFace, error) {
 	var (
 		temp          map[string]json.RawMessage
@@ -250,6 +550,22 @@ func __jsonUnmarshal__v1_interfaces_options__IFace__Owner__IF(data []byte) (IFac
 		return nil, fmt.Errorf("unknown discriminator: %s", discriminator)
 	}
 }
+
+func __jsonMarshal__v1_interfaces_options__IFace__Owner__IF(value IFace) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case Impl1, *Impl1:
+		discriminator = "impl_one"
+	case Impl2, *Impl2:
+		discriminator = "impl \"two\""
+	default:
+		return nil, fmt.Errorf("unregistered implementation of IFace: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("!kind", discriminator, value)
+}
+
 func __jsonUnmarshal__v1_interfaces_options__IFace__Owner__IFaces(data []byte) (IFace, error) {
 	var (
 		temp          map[string]json.RawMessage
@@ -282,6 +598,22 @@ func __jsonUnmarshal__v1_interfaces_options__IFace__Owner__IFaces(data []byte) (
 		return nil, fmt.Errorf("unknown discriminator: %s", discriminator)
 	}
 }
+
+func __jsonMarshal__v1_interfaces_options__IFace__Owner__IFaces(value IFace) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case Impl1, *Impl1:
+		discriminator = "Impl1"
+	case Impl2, *Impl2:
+		discriminator = "Impl2"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of IFace: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("!kind", discriminator, value)
+}
+
 func __jsonUnmarshal__v1_interfaces_options__IFace__Owner__OptionalIF(data []byte) (IFace, error) {
 	var (
 		temp          map[string]json.RawMessage
@@ -315,6 +647,62 @@ func __jsonUnmarshal__v1_interfaces_options__IFace__Owner__OptionalIF(data []byt
 	}
 }
 
+func __jsonMarshal__v1_interfaces_options__IFace__Owner__OptionalIF(value IFace) (json.RawMessage, error) {
+	var discriminator string
+	switch value.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case Impl1, *Impl1:
+		discriminator = "Impl1"
+	case Impl2, *Impl2:
+		discriminator = "Impl2"
+	default:
+		return nil, fmt.Errorf("unregistered implementation of IFace: %T", value)
+	}
+	return __jsonschema__marshalWithDiscriminator("!kind", discriminator, value)
+}
+
 func __jsonschema__unmarshalDiscriminatorError(discriminator json.RawMessage, err error) error {
 	return fmt.Errorf("unable to unmarshal discriminator value %v: %w", discriminator, err)
 }
+
+// __jsonschema__marshalWithDiscriminator encodes value, which must be a JSON
+// object, with the discriminator property written first. A value whose own
+// MarshalJSON already writes the discriminator is kept as it is.
+func __jsonschema__marshalWithDiscriminator(propName, discriminator string, value any) (json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("%T must marshal to a JSON object to carry discriminator %q", value, discriminator)
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if existing, ok := fields[propName]; ok {
+		var written string
+		if err = json.Unmarshal(existing, &written); err != nil || written != discriminator {
+			return nil, fmt.Errorf("%T writes %s %s, want %q", value, propName, existing, discriminator)
+		}
+		return data, nil
+	}
+	key, err := json.Marshal(propName)
+	if err != nil {
+		return nil, err
+	}
+	val, err := json.Marshal(discriminator)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)+len(key)+len(val)+2)
+	out = append(out, '{')
+	out = append(out, key...)
+	out = append(out, ':')
+	out = append(out, val...)
+	if data[1] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, data[1:]...), nil
+}
@@ -2,6 +2,7 @@ package v1_interfaces_options
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -186,6 +187,34 @@ timeout: null
 	}
 }
 
+func TestGeneratedParseYAMLValidatesThenDecodes(t *testing.T) {
+	owner, err := ParseOwnerYAML([]byte(`
+if:
+  "!kind": impl_one
+  x: required
+ifs: []
+label: night shift
+timeout: 30
+`))
+	if err != nil {
+		t.Fatalf("ParseOwnerYAML: %v", err)
+	}
+	if owner.Label.Value != "night shift" || owner.Timeout.Value != 30 || owner.IF == nil {
+		t.Fatalf("ParseOwnerYAML = %+v", owner)
+	}
+
+	_, err = ParseOwnerYAML([]byte("ifs: []\ntimeout: null\n"))
+	var parseErr *jsonschema.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Kind != jsonschema.ParseSchemaViolation || !strings.Contains(err.Error(), "/if: missing required property") {
+		t.Fatalf("ParseOwnerYAML error = %v, want a missing if violation", err)
+	}
+
+	_, err = ParseOwnerYAML([]byte("if: [unclosed\n"))
+	if !errors.As(err, &parseErr) || parseErr.Kind != jsonschema.ParseDecodeFailure {
+		t.Fatalf("ParseOwnerYAML error = %v, want a decode failure", err)
+	}
+}
+
 func TestInterfaceSliceDecode(t *testing.T) {
 	var got Owner
 	input := []byte(`{"if":{"!kind":"impl_one","x":"required"},"ifs":[{"!kind":"Impl1","x":"one"},{"!kind":"Impl2","y":2}]}`)
@@ -269,3 +298,136 @@ func TestOptionalInterfaceStates(t *testing.T) {
 		t.Fatalf("null decode mutated destination: %#v", got)
 	}
 }
+
+func TestInterfaceMarshalInjectsDiscriminators(t *testing.T) {
+	original := Owner{
+		IF:         Impl2{Y: 7},
+		IFaces:     []IFace{Impl1{X: "one"}, &Impl2{Y: 2}},
+		OptionalIF: jsonschema.Optional[IFace]{Present: true, Value: Impl2{Y: 0}},
+	}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"timeout":null,"if":{"!kind":"impl \"two\"","y":7},"ifs":[{"!kind":"Impl1","x":"one"},{"!kind":"Impl2","y":2}],"optional_if":{"!kind":"Impl2","y":0}}`
+	if string(data) != want {
+		t.Fatalf("marshaled owner = %s, want %s", data, want)
+	}
+	if err := (Owner{}).ValidateJSON(data); err != nil {
+		t.Fatalf("marshaled owner fails its own schema: %v", err)
+	}
+
+	var got Owner
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	want2 := Owner{
+		IF:         Impl2{Y: 7},
+		IFaces:     []IFace{Impl1{X: "json:one"}, Impl2{Y: 2}},
+		OptionalIF: jsonschema.Optional[IFace]{Present: true, Value: Impl2{Y: 0}},
+	}
+	if !reflect.DeepEqual(got, want2) {
+		t.Fatalf("round-tripped owner = %#v, want %#v", got, want2)
+	}
+
+	absent, err := json.Marshal(Owner{IF: Impl1{X: "x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(absent) != `{"timeout":null,"if":{"!kind":"impl_one","x":"x"},"ifs":null}` {
+		t.Fatalf("marshaled owner with absent optional = %s", absent)
+	}
+}
+
+type unregisteredIFace struct{}
+
+func (unregisteredIFace) isIface() {}
+
+func TestInterfaceMarshalRejectsUnregisteredImpl(t *testing.T) {
+	_, err := json.Marshal(Owner{IF: Impl1{}, IFaces: []IFace{unregisteredIFace{}}})
+	if err == nil || !strings.Contains(err.Error(), "ifs[0]") || !strings.Contains(err.Error(), "unregistered implementation of IFace") {
+		t.Fatalf("error = %v, want indexed unregistered implementation error", err)
+	}
+}
+
+func TestNullableInterfaceStates(t *testing.T) {
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			AnyOf []struct {
+				Type string `json:"type"`
+			} `json:"anyOf"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(Envelope{}.Schema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	options := schema.Properties["attachment"].AnyOf
+	if len(options) != 3 || options[0].Type != "object" || options[1].Type != "object" || options[2].Type != "null" {
+		t.Fatalf("attachment options = %#v, want two objects and null", options)
+	}
+	if !reflect.DeepEqual(schema.Required, []string{"attachment"}) {
+		t.Fatalf("required = %v", schema.Required)
+	}
+
+	var got Envelope
+	if err := json.Unmarshal([]byte(`{"attachment":{"!kind":"two","y":3}}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := got.Attachment.Value.(Impl2); !got.Attachment.Present || !ok || value.Y != 3 {
+		t.Fatalf("present attachment = %#v", got.Attachment)
+	}
+	if err := json.Unmarshal([]byte(`{"attachment":null}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Attachment.Present || got.Attachment.Value != nil {
+		t.Fatalf("null attachment = %#v", got.Attachment)
+	}
+	if err := json.Unmarshal([]byte(`{"attachment":{"!kind":"Impl1"}}`), &got); err == nil {
+		t.Fatal("unknown attachment discriminator unexpectedly succeeded")
+	}
+
+	data, err := json.Marshal(Envelope{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"attachment":null}` {
+		t.Fatalf("marshaled null attachment = %s", data)
+	}
+	if err := (Envelope{}).ValidateJSON(data); err != nil {
+		t.Fatalf("marshaled null attachment fails its own schema: %v", err)
+	}
+	data, err = json.Marshal(Envelope{Attachment: jsonschema.Nullable[IFace]{Present: true, Value: Impl1{X: "x"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"attachment":{"!kind":"one","x":"x"}}` {
+		t.Fatalf("marshaled attachment = %s", data)
+	}
+}
+
+func TestNullableInterfaceYAML(t *testing.T) {
+	for _, input := range []string{"attachment: null\n", "attachment: ~\n"} {
+		got := Envelope{Attachment: jsonschema.Nullable[IFace]{Present: true, Value: Impl2{Y: 1}}}
+		if err := yaml.Load([]byte(input), &got, yaml.WithV4Defaults()); err != nil {
+			t.Fatalf("yaml.Load(%q): %v", input, err)
+		}
+		if got.Attachment.Present {
+			t.Fatalf("yaml.Load(%q) = %#v, want a null attachment", input, got.Attachment)
+		}
+	}
+
+	envelope, err := ParseEnvelopeYAML([]byte("attachment:\n  \"!kind\": one\n  x: doc\n"))
+	if err != nil {
+		t.Fatalf("ParseEnvelopeYAML: %v", err)
+	}
+	if value, ok := envelope.Attachment.Value.(Impl1); !envelope.Attachment.Present || !ok || value.X != "json:doc" {
+		t.Fatalf("ParseEnvelopeYAML = %#v", envelope)
+	}
+
+	_, err = ParseEnvelopeYAML([]byte("{}\n"))
+	var parseErr *jsonschema.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Kind != jsonschema.ParseSchemaViolation {
+		t.Fatalf("ParseEnvelopeYAML error = %v, want a missing attachment violation", err)
+	}
+}
@@ -16,8 +16,22 @@ func (Plain) Schema() json.RawMessage   { panic("not implemented") }
 func (Plain) ValidateJSON([]byte) error { panic("not implemented") }
 func (Plain) ValidateYAML([]byte) error { panic("not implemented") }
 
+func (Envelope) Schema() json.RawMessage   { panic("not implemented") }
+func (Envelope) ValidateJSON([]byte) error { panic("not implemented") }
+func (Envelope) ValidateYAML([]byte) error { panic("not implemented") }
+
 var _ = jsonschema.NewJSONSchemaMethod(Plain.Schema)
 
+var _ = jsonschema.NewJSONSchemaMethod(
+	Envelope.Schema,
+	jsonschema.WithInterface(
+		Envelope{}.Attachment,
+		jsonschema.Discriminator("!kind"),
+		jsonschema.Impl("one", Impl1{}),
+		jsonschema.Impl("two", Impl2{}),
+	),
+)
+
 var _ = jsonschema.NewJSONSchemaMethod(
 	Owner.Schema,
 	jsonschema.WithInterface(
@@ -61,3 +61,8 @@ type Owner struct {
 	Label      jsonschema.Optional[string] `json:"label,omitzero" yaml:"label"`
 	Timeout    jsonschema.Nullable[int]    `json:"timeout" yaml:"timeout"`
 }
+
+// Envelope requires its attachment key but allows null.
+type Envelope struct {
+	Attachment jsonschema.Nullable[IFace] `json:"attachment" yaml:"attachment"`
+}
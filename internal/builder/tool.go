@@ -0,0 +1,89 @@
+package builder
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/dave/dst"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+type (
+	// ToolInfo is the template data for a function registered with NewTool.
+	ToolInfo struct {
+		FuncName    string
+		Name        string
+		Description string
+		// ParamsType is the generated struct holding the model's arguments.
+		ParamsType string
+		// ImplType is the unexported type implementing genjsonschema.Tool.
+		ImplType    string
+		Constructor string
+		Fields      []ToolField
+		Deps        []ToolField
+		// CallArgs is the argument list Invoke passes to the function.
+		CallArgs string
+		// StringResult is set when the function returns (string, error).
+		StringResult bool
+	}
+
+	// ToolField is a params struct field or an injected dependency.
+	ToolField struct {
+		Name string
+		Type string
+		Tag  string
+		// Comment holds the lines of the parameter's comment.
+		Comment []string
+	}
+)
+
+// toolInfos prepares the registered tools for the template, printing
+// parameter types through importMap.
+func (s SchemaBuilder) toolInfos(importMap *ImportMap) []ToolInfo {
+	qualifier := importMap.Qualifier()
+	infos := make([]ToolInfo, 0, len(s.Scan.Tools))
+	for _, tool := range s.Scan.Tools {
+		info := ToolInfo{
+			FuncName:     tool.FuncName(),
+			Name:         tool.Name,
+			Description:  tool.Description,
+			ParamsType:   tool.Params.Name(),
+			ImplType:     tool.ImplType(),
+			Constructor:  tool.Constructor(),
+			StringResult: types.Identical(tool.Result, types.Typ[types.String]),
+		}
+		var (
+			callArgs []string
+			fields   = tool.Params.Type().Expr().(*dst.StructType).Fields.List
+		)
+		for _, arg := range tool.Args {
+			typeName := types.TypeString(arg.Type, qualifier)
+			switch arg.Kind {
+			case syntax.ToolArgContext:
+				callArgs = append(callArgs, "ctx")
+			case syntax.ToolArgDependency:
+				info.Deps = append(info.Deps, ToolField{Name: arg.Name, Type: typeName})
+				callArgs = append(callArgs, "t."+arg.Name)
+			default:
+				field := fields[len(info.Fields)]
+				info.Fields = append(info.Fields, ToolField{
+					Name:    arg.Field,
+					Type:    typeName,
+					Tag:     field.Tag.Value,
+					Comment: commentLines(syntax.BuildComments(field.Decorations())),
+				})
+				callArgs = append(callArgs, "args."+arg.Field)
+			}
+		}
+		info.CallArgs = strings.Join(callArgs, ", ")
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func commentLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,185 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestToolDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		source    string
+		wantError string
+	}{
+		{
+			name: "variadic",
+			source: `func Sum(values ...int) (int, error) { return 0, nil }
+
+var _ = jsonschema.NewTool(Sum)`,
+			wantError: "tool function Sum cannot be variadic",
+		},
+		{
+			name: "missing error result",
+			source: `func Sum(a, b int) int { return a + b }
+
+var _ = jsonschema.NewTool(Sum)`,
+			wantError: "tool function Sum must return (R, error)",
+		},
+		{
+			name: "params name taken",
+			source: `type SumParams struct{}
+
+func Sum(a, b int) (int, error) { return a + b, nil }
+
+var _ = jsonschema.NewTool(Sum)`,
+			wantError: "tool function Sum needs the name SumParams for its params struct",
+		},
+		{
+			name: "implementation type name taken",
+			source: `type sumTool struct{}
+
+func Sum(a, b int) (int, error) { return a + b, nil }
+
+var _ = jsonschema.NewTool(Sum)`,
+			wantError: "tool function Sum needs the name sumTool for its implementation type",
+		},
+		{
+			name: "constructor name taken",
+			source: `func NewSumTool() {}
+
+func Sum(a, b int) (int, error) { return a + b, nil }
+
+var _ = jsonschema.NewTool(Sum)`,
+			wantError: "tool function Sum needs the name NewSumTool for its constructor",
+		},
+		{
+			name: "unnamed parameter",
+			source: `func Sum(int, int) (int, error) { return 0, nil }
+
+var _ = jsonschema.NewTool(Sum)`,
+			wantError: "tool function Sum has an unnamed parameter",
+		},
+		{
+			name: "duplicate name",
+			source: `func Sum(a, b int) (int, error) { return a + b, nil }
+
+func Add(a, b int) (int, error) { return a + b, nil }
+
+var (
+	_ = jsonschema.NewTool(Sum)
+	_ = jsonschema.NewTool(Add, jsonschema.ToolName("sum"))
+)`,
+			wantError: `tool name "sum" is used by both Sum and Add`,
+		},
+		{
+			name: "duplicate derived name",
+			source: `func GetUserByID(id string) (string, error) { return id, nil }
+
+func GetUserById(id string) (string, error) { return id, nil }
+
+var (
+	_ = jsonschema.NewTool(GetUserByID)
+	_ = jsonschema.NewTool(GetUserById)
+)`,
+			wantError: `tool name "get_user_by_id" is used by both GetUserByID and GetUserById`,
+		},
+		{
+			name: "unlisted dependency",
+			source: `func Log(message string, logger *slog.Logger) (string, error) { return "", nil }
+
+var _ = jsonschema.NewTool(Log)`,
+			wantError: `tool function Log parameter logger has type *log/slog.Logger, which a model cannot supply; list it with ToolDependency("logger")`,
+		},
+		{
+			name: "unknown dependency",
+			source: `func Log(message string, logger *slog.Logger) (string, error) { return "", nil }
+
+var _ = jsonschema.NewTool(Log, jsonschema.ToolDependency("logger"), jsonschema.ToolDependency("db"))`,
+			wantError: "tool function Log has no parameter db named by ToolDependency",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			targetDir := writeToolFixture(t, tc.source)
+			pkgs, err := syntax.Load(targetDir)
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+
+			_, err = New(pkgs[0])
+			require.ErrorContains(t, err, tc.wantError)
+		})
+	}
+}
+
+func TestToolParamsStruct(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeToolFixture(t, `
+// Sum adds two numbers.
+func Sum(
+	ctx context.Context,
+	a int, // The first addend.
+	b jsonschema.Optional[int], // The second addend.
+	logger *slog.Logger,
+) (int, error) {
+	return 0, nil
+}
+
+var _ = jsonschema.NewTool(Sum, jsonschema.ToolDependency("logger"))`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "SumParams")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"a": {"type": "integer", "description": "The first addend."},
+			"b": {"type": "integer", "description": "The second addend."}
+		},
+		"required": ["a"],
+		"additionalProperties": false
+	}`, string(data))
+
+	tools := builder.toolInfos(NewImportMap(pkgs[0]))
+	require.Len(t, tools, 1)
+	require.Equal(t, "sum", tools[0].Name)
+	require.Equal(t, "Sum adds two numbers.", tools[0].Description)
+	require.Equal(t, "NewSumTool", tools[0].Constructor)
+	require.Equal(t, []ToolField{{Name: "logger", Type: "*slog.Logger"}}, tools[0].Deps)
+	require.Equal(t, "ctx, args.A, args.B, t.logger", tools[0].CallArgs)
+}
+
+func writeToolFixture(t *testing.T, source string) string {
+	t.Helper()
+
+	source = `//go:build jsonschema
+
+package fixture
+
+import (
+	"context"
+	"log/slog"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+var (
+	_ context.Context
+	_ *slog.Logger
+)
+
+` + source + "\n"
+	return writeFixture(t, "tools_", map[string]string{"schema.go": source})
+}
@@ -51,6 +51,7 @@ func TestGeneratedUnmarshalFormats(t *testing.T) {
 			require.NoError(t, err)
 			source := string(generated)
 
+			require.Contains(t, source, "func (f FancyStruct) MarshalJSON(")
 			if tt.wantJSON {
 				require.Contains(t, source, "func (f *FancyStruct) UnmarshalJSON(")
 				require.Contains(t, source, "func __jsonUnmarshal__interfaces__TestInterface(")
@@ -1,8 +1,6 @@
 package builder
 
 import (
-	"os"
-	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -23,41 +21,10 @@ func (First) variant() {}
 `
 
 	tests := []struct {
-		name string
-		body string
+		name      string
+		body      string
+		wantError string
 	}{
-		{
-			name: "v1 fixed array field",
-			body: commonTypes + `
-type Owner struct {
-	Values [2]Variant ` + "`json:\"values\"`" + `
-}
-
-func (Owner) Schema() json.RawMessage { panic("not implemented") }
-
-var _ = jsonschema.NewJSONSchemaMethod(
-	Owner.Schema,
-	jsonschema.WithInterface(Owner{}.Values),
-	jsonschema.WithInterfaceImpls(Owner{}.Values, First{}),
-)
-`,
-		},
-		{
-			name: "v1 nested slice field",
-			body: commonTypes + `
-type Owner struct {
-	Values [][]Variant ` + "`json:\"values\"`" + `
-}
-
-func (Owner) Schema() json.RawMessage { panic("not implemented") }
-
-var _ = jsonschema.NewJSONSchemaMethod(
-	Owner.Schema,
-	jsonschema.WithInterface(Owner{}.Values),
-	jsonschema.WithInterfaceImpls(Owner{}.Values, First{}),
-)
-`,
-		},
 		{
 			name: "v1 nullable slice field",
 			body: commonTypes + `
@@ -73,70 +40,7 @@ var _ = jsonschema.NewJSONSchemaMethod(
 	jsonschema.WithInterfaceImpls(Owner{}.Values, First{}),
 )
 `,
-		},
-		{
-			name: "v1 optional slice field",
-			body: commonTypes + `
-type Owner struct {
-	Values jsonschema.Optional[[]Variant] ` + "`json:\"values,omitzero\"`" + `
-}
-
-func (Owner) Schema() json.RawMessage { panic("not implemented") }
-
-var _ = jsonschema.NewJSONSchemaMethod(
-	Owner.Schema,
-	jsonschema.WithInterface(Owner{}.Values),
-	jsonschema.WithInterfaceImpls(Owner{}.Values, First{}),
-)
-`,
-		},
-		{
-			name: "v1 named slice field",
-			body: commonTypes + `
-type Variants []Variant
-
-type Owner struct {
-	Values Variants ` + "`json:\"values\"`" + `
-}
-
-func (Owner) Schema() json.RawMessage { panic("not implemented") }
-
-var _ = jsonschema.NewJSONSchemaMethod(
-	Owner.Schema,
-	jsonschema.WithInterface(Owner{}.Values),
-	jsonschema.WithInterfaceImpls(Owner{}.Values, First{}),
-)
-`,
-		},
-		{
-			name: "legacy fixed array field",
-			body: commonTypes + `
-type Owner struct {
-	Values [2]Variant ` + "`json:\"values\"`" + `
-}
-
-func (Owner) Schema() json.RawMessage { panic("not implemented") }
-
-var (
-	_ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
-	_ = jsonschema.NewInterfaceImpl[Variant](First{})
-)
-`,
-		},
-		{
-			name: "legacy nested slice field",
-			body: commonTypes + `
-type Owner struct {
-	Values [][]Variant ` + "`json:\"values\"`" + `
-}
-
-func (Owner) Schema() json.RawMessage { panic("not implemented") }
-
-var (
-	_ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
-	_ = jsonschema.NewInterfaceImpl[Variant](First{})
-)
-`,
+			wantError: "jsonschema.Nullable does not support containers of registered interfaces",
 		},
 		{
 			name: "legacy nullable slice field",
@@ -152,29 +56,13 @@ var (
 	_ = jsonschema.NewInterfaceImpl[Variant](First{})
 )
 `,
+			wantError: "jsonschema.Nullable does not support containers of registered interfaces",
 		},
 		{
-			name: "legacy optional slice field",
+			name: "legacy pointer elements",
 			body: commonTypes + `
 type Owner struct {
-	Values jsonschema.Optional[[]Variant] ` + "`json:\"values,omitzero\"`" + `
-}
-
-func (Owner) Schema() json.RawMessage { panic("not implemented") }
-
-var (
-	_ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
-	_ = jsonschema.NewInterfaceImpl[Variant](First{})
-)
-`,
-		},
-		{
-			name: "legacy named slice field",
-			body: commonTypes + `
-type Variants []Variant
-
-type Owner struct {
-	Values Variants ` + "`json:\"values\"`" + `
+	Values []*Variant ` + "`json:\"values\"`" + `
 }
 
 func (Owner) Schema() json.RawMessage { panic("not implemented") }
@@ -184,6 +72,7 @@ var (
 	_ = jsonschema.NewInterfaceImpl[Variant](First{})
 )
 `,
+			wantError: "found registered interface type Variant in an unsupported location",
 		},
 		{
 			name: "legacy top-level named slice",
@@ -197,6 +86,7 @@ var (
 	_ = jsonschema.NewInterfaceImpl[Variant](First{})
 )
 `,
+			wantError: "containers of registered interfaces are supported only as struct field types",
 		},
 	}
 
@@ -213,7 +103,7 @@ var (
 			require.NotEmpty(t, scan.SchemaMethods)
 
 			_, err = New(pkgs[0])
-			require.ErrorContains(t, err, "arrays/slices of registered interfaces are not yet supported")
+			require.ErrorContains(t, err, test.wantError)
 			require.Contains(t, err.Error(), targetDir)
 		})
 	}
@@ -257,14 +147,6 @@ var (
 func writeUnsupportedInterfaceFixture(t *testing.T, body string) string {
 	t.Helper()
 
-	cwd, err := os.Getwd()
-	require.NoError(t, err)
-	targetDir, err := os.MkdirTemp(filepath.Join(cwd, "testfixtures"), "unsupported_interface_container_")
-	require.NoError(t, err)
-	t.Cleanup(func() {
-		require.NoError(t, os.RemoveAll(targetDir))
-	})
-
 	source := `//go:build jsonschema
 
 package fixture
@@ -275,6 +157,5 @@ import (
 	jsonschema "github.com/tylergannon/go-gen-jsonschema"
 )
 ` + body
-	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "schema.go"), []byte(source), 0o644))
-	return targetDir
+	return writeFixture(t, "unsupported_interface_container_", map[string]string{"schema.go": source})
 }
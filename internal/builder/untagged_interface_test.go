@@ -0,0 +1,98 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+const untaggedFixtureTypes = `
+type Shape interface{ shape() }
+
+type Circle struct {
+	Radius float64 ` + "`json:\"radius\"`" + `
+}
+
+func (Circle) shape() {}
+
+type Square struct {
+	Side float64 ` + "`json:\"side\"`" + `
+}
+
+func (*Square) shape() {}
+
+type Owner struct {
+	Shape  Shape   ` + "`json:\"shape\"`" + `
+	Shapes []Shape ` + "`json:\"shapes\"`" + `
+}
+`
+
+func TestUntaggedInterface(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, untaggedFixtureTypes, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Shape,
+		jsonschema.Untagged(),
+		jsonschema.Impl("circle", Circle{}),
+		jsonschema.Impl("square", (*Square)(nil)),
+	),
+	jsonschema.WithInterface(Owner{}.Shapes),
+	jsonschema.WithInterfaceImpls(Owner{}.Shapes, Circle{}, (*Square)(nil)),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Owner")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"object","properties":{
+		"shape":{"anyOf":[
+			{"type":"object","properties":{"radius":{"type":"number"}},"required":["radius"],"additionalProperties":false},
+			{"type":"object","properties":{"side":{"type":"number"}},"required":["side"],"additionalProperties":false}
+		]},
+		"shapes":{"type":"array","items":{"anyOf":[
+			{"type":"object","properties":{"type":{"type":"string","const":"Circle"},"radius":{"type":"number"}},"required":["type","radius"],"additionalProperties":false},
+			{"type":"object","properties":{"type":{"type":"string","const":"Square"},"side":{"type":"number"}},"required":["type","side"],"additionalProperties":false}
+		]}}
+	},"required":["shape","shapes"],"additionalProperties":false}`, string(data))
+
+	code, err := builder.renderGoCode()
+	require.NoError(t, err)
+	source := string(code)
+	require.Contains(t, source, "__jsonschema__untaggedOption{name: \"circle\", data: json.RawMessage(`{\"type\":\"object\",\"properties\":{\"radius\":{\"type\":\"number\"}},\"required\":[\"radius\"],\"additionalProperties\":false}`)},")
+	require.Contains(t, source, "value matches more than one implementation of Shape")
+	require.Contains(t, source, "case 1:\n\t\tvar obj Square\n\t\terr = json.Unmarshal(data, &obj)\n\t\tvalue = &obj")
+	// The tagged field keeps its discriminator.
+	require.Contains(t, source, "return __jsonschema__marshalWithDiscriminator(\"type\", discriminator, value)")
+}
+
+func TestUntaggedInterfaceRejectsDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeAnnotationsFixture(t, untaggedFixtureTypes, `
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema,
+	jsonschema.WithInterface(Owner{}.Shape,
+		jsonschema.Untagged(),
+		jsonschema.Discriminator("kind"),
+		jsonschema.Impl("circle", Circle{}),
+	),
+	jsonschema.WithInterface(Owner{}.Shapes),
+	jsonschema.WithInterfaceImpls(Owner{}.Shapes, Circle{}),
+)
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	_, err = New(pkgs[0])
+	require.ErrorContains(t, err, "field Owner.Shape: an Untagged interface has no discriminator property to set")
+}
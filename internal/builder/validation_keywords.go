@@ -0,0 +1,102 @@
+package builder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tylergannon/go-gen-jsonschema/internal/common"
+)
+
+// applyValidationKeywords copies the validation keywords from a field's
+// jsonschema tag onto its rendered schema. A keyword that does not suit the
+// schema's JSON type is an error rather than being silently dropped.
+func applyValidationKeywords(schema JSONSchema, tag common.JSONSchemaTag) (JSONSchema, error) {
+	switch node := schema.(type) {
+	case PropertyNode[int]:
+		return applyScalarKeywords(node, tag)
+	case PropertyNode[float64]:
+		return applyScalarKeywords(node, tag)
+	case PropertyNode[string]:
+		return applyScalarKeywords(node, tag)
+	case PropertyNode[bool]:
+		return applyScalarKeywords(node, tag)
+	case ArrayNode:
+		if err := checkValidationKeywords(tag, "array", false, false, true); err != nil {
+			return nil, err
+		}
+		node.MinItems = tag.MinItems
+		node.MaxItems = tag.MaxItems
+		return node, nil
+	default:
+		return nil, checkValidationKeywords(tag, schemaKind(schema), false, false, false)
+	}
+}
+
+func applyScalarKeywords[T ~int | ~string | ~bool | float32 | float64](p PropertyNode[T], tag common.JSONSchemaTag) (JSONSchema, error) {
+	numeric := p.Typ == "integer" || p.Typ == "number"
+	if err := checkValidationKeywords(tag, p.Typ, numeric, p.Typ == "string", false); err != nil {
+		return nil, err
+	}
+	p.Minimum = tag.Minimum
+	p.Maximum = tag.Maximum
+	p.MinLength = tag.MinLength
+	p.MaxLength = tag.MaxLength
+	p.Pattern = tag.Pattern
+	if tag.Format != "" {
+		if _, ok := stringFormats[tag.Format]; !ok {
+			return nil, fmt.Errorf("jsonschema tag format %q is not supported; use one of %s", tag.Format, formatNames())
+		}
+		p.Formats = []string{tag.Format}
+	}
+	return p, nil
+}
+
+func checkValidationKeywords(tag common.JSONSchemaTag, kind string, numeric, str, array bool) error {
+	keywords := []struct {
+		name    string
+		set, ok bool
+	}{
+		{"minimum", tag.Minimum != nil, numeric},
+		{"maximum", tag.Maximum != nil, numeric},
+		{"minLength", tag.MinLength != nil, str},
+		{"maxLength", tag.MaxLength != nil, str},
+		{"pattern", tag.Pattern != "", str},
+		{"format", tag.Format != "", str},
+		{"minItems", tag.MinItems != nil, array},
+		{"maxItems", tag.MaxItems != nil, array},
+	}
+	for _, keyword := range keywords {
+		if keyword.set && !keyword.ok {
+			return fmt.Errorf("jsonschema tag keyword %s does not apply to %s schemas", keyword.name, kind)
+		}
+	}
+	return nil
+}
+
+// schemaKind names a schema shape for error messages.
+func schemaKind(schema JSONSchema) string {
+	switch schema.(type) {
+	case ObjectNode:
+		return "object"
+	case UnionTypeNode:
+		return "union"
+	case RefNode:
+		return "$ref"
+	case TemplateHoleNode:
+		return "provider"
+	case AnyNode:
+		return "unconstrained"
+	default:
+		return fmt.Sprintf("%T", schema)
+	}
+}
+
+func formatNames() string {
+	names := make([]string, 0, len(stringFormats))
+	for name := range stringFormats {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
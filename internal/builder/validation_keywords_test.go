@@ -0,0 +1,201 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tylergannon/go-gen-jsonschema/internal/syntax"
+)
+
+func TestValidationKeywordsFromTags(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeValidationKeywordsFixture(t, `
+type Owner struct {
+	Age     int                         `+"`json:\"age\" jsonschema:\"minimum=0,maximum=120\"`"+`
+	Ratio   float64                     `+"`json:\"ratio\" jsonschema:\"minimum=-0.5\"`"+`
+	Handle  string                      `+"`json:\"handle\" jsonschema:\"minLength=3,maxLength=16,pattern=^[a-z]{3,16}$\"`"+`
+	Tags    []string                    `+"`json:\"tags\" jsonschema:\"minItems=1,maxItems=4\"`"+`
+	Nick    jsonschema.Nullable[string] `+"`json:\"nick\" jsonschema:\"maxLength=8\"`"+`
+	Untyped string                      `+"`json:\"untyped\"`"+`
+}
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Owner")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.JSONEq(t, `{"type":"integer","minimum":0,"maximum":120}`, string(got.Properties["age"]))
+	require.JSONEq(t, `{"type":"number","minimum":-0.5}`, string(got.Properties["ratio"]))
+	require.JSONEq(t, `{"type":"string","minLength":3,"maxLength":16,"pattern":"^[a-z]{3,16}$"}`, string(got.Properties["handle"]))
+	require.JSONEq(t, `{"type":"array","items":{"type":"string"},"minItems":1,"maxItems":4}`, string(got.Properties["tags"]))
+	require.JSONEq(t, `{"type":["string","null"],"maxLength":8}`, string(got.Properties["nick"]))
+	require.JSONEq(t, `{"type":"string"}`, string(got.Properties["untyped"]))
+}
+
+func TestStringFormats(t *testing.T) {
+	t.Parallel()
+
+	targetDir := writeValidationKeywordsFixture(t, `
+type Owner struct {
+	Created  time.Time                      `+"`json:\"created\"`"+`
+	Deleted  jsonschema.Nullable[time.Time] `+"`json:\"deleted\"`"+`
+	Timeout  time.Duration                  `+"`json:\"timeout\"`"+`
+	// Address is where the server listens.
+	Address  netip.Addr                     `+"`json:\"address\"`"+`
+	Email    string                         `+"`json:\"email\" jsonschema:\"format=email\"`"+`
+	Homepage string                         `+"`json:\"homepage\" jsonschema:\"format=uri,maxLength=200\"`"+`
+}
+`)
+	pkgs, err := syntax.Load(targetDir)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	builder, err := New(pkgs[0])
+	require.NoError(t, err)
+	schema, ok := builder.schemas.Get(pkgs[0].PkgPath, "Owner")
+	require.True(t, ok)
+	data, err := schema.MarshalJSON()
+	require.NoError(t, err)
+
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.JSONEq(t, `{"type":"string","format":"date-time"}`, string(got.Properties["created"]))
+	require.JSONEq(t, `{"type":["string","null"],"format":"date-time"}`, string(got.Properties["deleted"]))
+	require.JSONEq(t, `{"type":"integer","description":"A duration in nanoseconds (e.g., 1500000000 for 1.5s)."}`, string(got.Properties["timeout"]))
+	require.JSONEq(t, `{"type":"string","description":"Address is where the server listens.","anyOf":[{"format":"ipv4"},{"format":"ipv6"}]}`, string(got.Properties["address"]))
+	require.JSONEq(t, `{"type":"string","format":"email"}`, string(got.Properties["email"]))
+	require.JSONEq(t, `{"type":"string","format":"uri","maxLength":200}`, string(got.Properties["homepage"]))
+
+	targetDir = t.TempDir()
+	_, err = builder.writeSchema(syntax.TypeID{PkgPath: pkgs[0].PkgPath, TypeName: "Owner"}, DialectGemini, targetDir, false)
+	require.NoError(t, err)
+	generated, err := os.ReadFile(filepath.Join(targetDir, "Owner.gemini.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(generated, &got))
+	require.JSONEq(t, `{"type":"string","format":"date-time"}`, string(got.Properties["created"]))
+	require.JSONEq(t, `{"type":"string","description":"Address is where the server listens. Must be an IPv4 address (e.g., \"192.0.2.1\") or an IPv6 address (e.g., \"2001:db8::1\")."}`, string(got.Properties["address"]))
+	require.JSONEq(t, `{"type":"string","description":"Must be an email address (e.g., \"user@example.com\")."}`, string(got.Properties["email"]))
+}
+
+func TestValidationKeywordDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		field     string
+		wantError string
+	}{
+		{
+			name:      "string keyword on integer",
+			field:     `Value int ` + "`json:\"value\" jsonschema:\"minLength=1\"`",
+			wantError: "jsonschema tag keyword minLength does not apply to integer schemas",
+		},
+		{
+			name:      "numeric keyword on array",
+			field:     `Value []int ` + "`json:\"value\" jsonschema:\"minimum=1\"`",
+			wantError: "jsonschema tag keyword minimum does not apply to array schemas",
+		},
+		{
+			name:      "array keyword on object",
+			field:     `Value Inner ` + "`json:\"value\" jsonschema:\"minItems=1\"`",
+			wantError: "jsonschema tag keyword minItems does not apply to object schemas",
+		},
+		{
+			name:      "malformed number",
+			field:     `Value int ` + "`json:\"value\" jsonschema:\"maximum=lots\"`",
+			wantError: `maximum must be a number, got "lots"`,
+		},
+		{
+			name:      "negative count",
+			field:     `Value string ` + "`json:\"value\" jsonschema:\"maxLength=-1\"`",
+			wantError: `maxLength must be a non-negative integer, got "-1"`,
+		},
+		{
+			name:      "inverted bounds",
+			field:     `Value int ` + "`json:\"value\" jsonschema:\"minimum=5,maximum=1\"`",
+			wantError: "minimum 5 is greater than maximum 1",
+		},
+		{
+			name:      "unknown format",
+			field:     `Value string ` + "`json:\"value\" jsonschema:\"format=phone\"`",
+			wantError: `jsonschema tag format "phone" is not supported`,
+		},
+		{
+			name:      "format on integer",
+			field:     `Value int ` + "`json:\"value\" jsonschema:\"format=email\"`",
+			wantError: "jsonschema tag keyword format does not apply to integer schemas",
+		},
+		{
+			name:      "invalid pattern",
+			field:     `Value string ` + "`json:\"value\" jsonschema:\"pattern=[a-\"`",
+			wantError: `pattern "[a-"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			targetDir := writeValidationKeywordsFixture(t, `
+type Inner struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+type Owner struct {
+	`+tc.field+`
+}
+`)
+			pkgs, err := syntax.Load(targetDir)
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			require.Empty(t, pkgs[0].Errors)
+
+			_, err = New(pkgs[0])
+			require.ErrorContains(t, err, tc.wantError)
+			require.ErrorContains(t, err, "field value at")
+		})
+	}
+}
+
+func writeValidationKeywordsFixture(t *testing.T, types string) string {
+	t.Helper()
+
+	source := `//go:build jsonschema
+
+package fixture
+
+import (
+	"encoding/json"
+	"net/netip"
+	"time"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+var (
+	_ netip.Addr
+	_ time.Time
+)
+` + types + `
+func (Owner) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
+`
+	return writeFixture(t, "validation_keywords_", map[string]string{"schema.go": source})
+}
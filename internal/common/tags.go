@@ -1,6 +1,10 @@
 package common
 
 import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/tylergannon/structtag"
@@ -12,6 +16,43 @@ type JSONSchemaTag struct {
 	ParamName string
 	ParamIdx  int
 	HasParam  bool
+
+	// Validation keywords. A nil pointer or empty Pattern or Format means the
+	// keyword was not given.
+	Minimum   *float64
+	Maximum   *float64
+	MinLength *int
+	MaxLength *int
+	Pattern   string
+	Format    string
+	MinItems  *int
+	MaxItems  *int
+
+	// Default is the text of the default= option, read as a value of the
+	// field's scalar type.
+	Default    string
+	HasDefault bool
+
+	// Err reports the first malformed validation keyword. The ref and param
+	// options are parsed leniently and never set it.
+	Err error
+}
+
+// HasValidation reports whether any validation keyword was given.
+func (t JSONSchemaTag) HasValidation() bool {
+	return t.Minimum != nil || t.Maximum != nil || t.MinLength != nil || t.MaxLength != nil ||
+		t.Pattern != "" || t.Format != "" || t.MinItems != nil || t.MaxItems != nil
+}
+
+// validationKeys lists the keywords understood by parseValidation. Any other
+// option that follows a pattern is treated as part of the pattern, since the
+// tag splits its options on commas.
+var validationKeys = map[string]bool{
+	"ref": true, "param": true, "idx": true,
+	"minimum": true, "maximum": true,
+	"minLength": true, "maxLength": true, "pattern": true, "format": true,
+	"minItems": true, "maxItems": true,
+	"default": true,
 }
 
 // ParseJSONSchemaTag parses a raw struct tag string (contents between backticks)
@@ -57,6 +98,84 @@ func ParseJSONSchemaTag(raw string) JSONSchemaTag {
 				res.ParamIdx = n
 			}
 		}
+		res.Err = res.parseValidation(t.Options)
 	}
 	return res
 }
+
+func (res *JSONSchemaTag) parseValidation(options []string) error {
+	for i := 0; i < len(options); i++ {
+		key, value, ok := strings.Cut(options[i], "=")
+		if !ok {
+			continue
+		}
+		var err error
+		switch key {
+		case "minimum":
+			res.Minimum, err = parseNumber(key, value)
+		case "maximum":
+			res.Maximum, err = parseNumber(key, value)
+		case "minLength":
+			res.MinLength, err = parseCount(key, value)
+		case "maxLength":
+			res.MaxLength, err = parseCount(key, value)
+		case "minItems":
+			res.MinItems, err = parseCount(key, value)
+		case "maxItems":
+			res.MaxItems, err = parseCount(key, value)
+		case "pattern":
+			for i+1 < len(options) && !isTagKey(options[i+1]) {
+				i++
+				value += "," + options[i]
+			}
+			if value == "" {
+				err = fmt.Errorf("pattern must not be empty")
+			} else if _, err = regexp.Compile(value); err != nil {
+				err = fmt.Errorf("pattern %q: %w", value, err)
+			}
+			res.Pattern = value
+		case "default":
+			res.Default = value
+			res.HasDefault = true
+		case "format":
+			if value == "" {
+				err = fmt.Errorf("format must not be empty")
+			}
+			res.Format = value
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if res.Minimum != nil && res.Maximum != nil && *res.Minimum > *res.Maximum {
+		return fmt.Errorf("minimum %v is greater than maximum %v", *res.Minimum, *res.Maximum)
+	}
+	if res.MinLength != nil && res.MaxLength != nil && *res.MinLength > *res.MaxLength {
+		return fmt.Errorf("minLength %d is greater than maxLength %d", *res.MinLength, *res.MaxLength)
+	}
+	if res.MinItems != nil && res.MaxItems != nil && *res.MinItems > *res.MaxItems {
+		return fmt.Errorf("minItems %d is greater than maxItems %d", *res.MinItems, *res.MaxItems)
+	}
+	return nil
+}
+
+func isTagKey(option string) bool {
+	key, _, ok := strings.Cut(option, "=")
+	return ok && validationKeys[key]
+}
+
+func parseNumber(key, value string) (*float64, error) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return nil, fmt.Errorf("%s must be a number, got %q", key, value)
+	}
+	return &n, nil
+}
+
+func parseCount(key, value string) (*int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer, got %q", key, value)
+	}
+	return &n, nil
+}
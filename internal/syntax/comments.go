@@ -8,7 +8,28 @@ import (
 )
 
 func BuildComments(decs *dst.NodeDecs) string {
-	return formatComments(appendDecorations(clipCommentsString(decs.Start), clipCommentsString(decs.End)))
+	comments := appendDecorations(clipCommentsString(decs.Start), clipCommentsString(decs.End))
+	if slices.ContainsFunc(comments, isEnumNameDirective) {
+		// Drop the directive along with the blank comment lines that set it
+		// apart.
+		comments = slices.DeleteFunc(comments, isEnumNameDirective)
+		for len(comments) > 0 && strings.TrimSpace(strings.TrimPrefix(comments[len(comments)-1], "//")) == "" {
+			comments = comments[:len(comments)-1]
+		}
+	}
+	return formatComments(comments)
+}
+
+// enumNameDirective reports the quoted name of a `jsonschema:"name"` comment,
+// which names an enum constant for WithStringerEnum.
+func enumNameDirective(dec string) (string, bool) {
+	text := strings.TrimSpace(strings.TrimPrefix(dec, "//"))
+	return strings.CutPrefix(text, "jsonschema:")
+}
+
+func isEnumNameDirective(dec string) bool {
+	_, ok := enumNameDirective(dec)
+	return ok
 }
 
 // formatComments removes either "//" or "// " from the front of each
@@ -19,6 +40,9 @@ func formatComments(comments dst.Decorations) string {
 	b := strings.Builder{}
 	var _comments = make([]string, len(comments))
 	for i, dec := range comments {
+		if strings.HasPrefix(dec, "/*") {
+			dec = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(dec, "/*"), "*/"))
+		}
 		trimmed := strings.TrimRight(
 			strings.TrimPrefix(
 				strings.TrimPrefix(dec, "// "), "//"),
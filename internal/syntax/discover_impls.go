@@ -0,0 +1,257 @@
+package syntax
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+
+	"github.com/dave/dst/decorator"
+	"golang.org/x/tools/go/packages"
+)
+
+// discoveredImpls is the result of an Implementations() option: every struct
+// type that implements a registered interface field's interface, with the
+// wire value that names it.
+type discoveredImpls struct {
+	Impls      []TypeID
+	WireValues []string
+}
+
+// importedPackages indexes pkg and every package it imports, directly or
+// not, by import path.
+func importedPackages(pkg *types.Package) map[string]*types.Package {
+	pkgs := map[string]*types.Package{}
+	var visit func(*types.Package)
+	visit = func(p *types.Package) {
+		if _, ok := pkgs[p.Path()]; ok {
+			return
+		}
+		pkgs[p.Path()] = p
+		for _, imported := range p.Imports() {
+			visit(imported)
+		}
+	}
+	visit(pkg)
+	return pkgs
+}
+
+// modulePackages indexes every package of a module by import path. The
+// packages are loaded together from source, so their types can be compared
+// with one another.
+type modulePackages map[string]*packages.Package
+
+// loadModulePackages loads every package in the module of pkg with the
+// jsonschema build tag set. A package that cannot be listed or parsed is an
+// error rather than being left out of the search. Type errors are not: with
+// the build tag set, generated code is left out, so packages that use it do
+// not type check, yet still declare their types.
+func loadModulePackages(pkg *decorator.Package) (modulePackages, error) {
+	if pkg.Module == nil {
+		return nil, fmt.Errorf("package %s is not part of a module, so there are no packages to search; list the implementations with Impl(...)", pkg.PkgPath)
+	}
+	cfg := *DefaultPackageCfg
+	cfg.Dir = pkg.Module.Dir
+	loaded, err := packages.Load(&cfg, pkg.Module.Path+"/...")
+	if err != nil {
+		return nil, fmt.Errorf("loading the packages of module %s: %w", pkg.Module.Path, err)
+	}
+	pkgs := make(modulePackages, len(loaded))
+	for _, p := range loaded {
+		for _, loadErr := range p.Errors {
+			if loadErr.Kind != packages.TypeError {
+				return nil, fmt.Errorf("cannot search package %s of module %s: %v", p.PkgPath, pkg.Module.Path, loadErr)
+			}
+		}
+		pkgs[p.PkgPath] = p
+	}
+	if _, ok := pkgs[pkg.PkgPath]; !ok {
+		return nil, fmt.Errorf("package %s was not loaded with module %s", pkg.PkgPath, pkg.Module.Path)
+	}
+	return pkgs, nil
+}
+
+// discoverImplementations finds the struct types implementing the interface
+// held by field of receiver, in every package of pkg's module, ordered by
+// package path and type name. modPkgs holds the module's packages; when it
+// is nil they are loaded and stored there, so that one registration loads
+// them once. A type that implements the interface only through its pointer
+// is recorded as a pointer. Each wire value is the string constant returned
+// by wireValueMethod when it is set, and otherwise the type name, in
+// snake_case when snake is set.
+func discoverImplementations(modPkgs *modulePackages, pkg *decorator.Package, receiver TypeID, field, wireValueMethod string, snake bool) (discoveredImpls, error) {
+	var found discoveredImpls
+	if *modPkgs == nil {
+		pkgs, err := loadModulePackages(pkg)
+		if err != nil {
+			return found, err
+		}
+		*modPkgs = pkgs
+	}
+	pkgs := *modPkgs
+	module := pkg.Module.Path
+	recvPkg, ok := pkgs[receiver.PkgPath]
+	if !ok {
+		return found, fmt.Errorf("package %s of %s is not in module %s", receiver.PkgPath, receiver.TypeName, module)
+	}
+	iface, err := fieldInterface(recvPkg.Types, receiver, field)
+	if err != nil {
+		return found, err
+	}
+	ifaceType := iface.Underlying().(*types.Interface)
+	paths := make([]string, 0, len(pkgs))
+	for path := range pkgs {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		implPkg := pkgs[path]
+		scope := implPkg.Types.Scope()
+		for _, name := range scope.Names() {
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+			named, ok := typeName.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			if _, ok = named.Underlying().(*types.Struct); !ok {
+				continue
+			}
+			impl := TypeID{PkgPath: path, TypeName: name}
+			switch {
+			case types.Implements(named, ifaceType):
+			case types.Implements(types.NewPointer(named), ifaceType):
+				impl.Indirection = Pointer
+			default:
+				continue
+			}
+			if path != pkg.PkgPath {
+				switch {
+				case !typeName.Exported():
+					return found, fmt.Errorf("implementation %s of %s is unexported, so code generated in %s cannot decode it", impl, iface.Obj().Name(), pkg.PkgPath)
+				case implPkg.Name == "main":
+					return found, fmt.Errorf("implementation %s of %s is in a main package, which code generated in %s cannot import", impl, iface.Obj().Name(), pkg.PkgPath)
+				}
+				if _, cycle := importedPackages(implPkg.Types)[pkg.PkgPath]; cycle {
+					return found, fmt.Errorf("implementation %s of %s is in a package that imports %s, so code generated there cannot import it", impl, iface.Obj().Name(), pkg.PkgPath)
+				}
+			}
+			value := name
+			if wireValueMethod != "" {
+				if value, err = methodWireValue(pkgs, named, wireValueMethod); err != nil {
+					return found, err
+				}
+			} else if snake {
+				value = snakeCase(name)
+			}
+			found.Impls = append(found.Impls, impl)
+			found.WireValues = append(found.WireValues, value)
+		}
+	}
+	if len(found.Impls) == 0 {
+		return found, fmt.Errorf("found no implementations of %s in the packages of module %s", iface.Obj().Name(), module)
+	}
+	return found, nil
+}
+
+// fieldInterface returns the named interface a struct field holds, directly
+// or inside slices, arrays, maps, Optional and Nullable.
+func fieldInterface(recvPkg *types.Package, receiver TypeID, field string) (*types.Named, error) {
+	recvObj := recvPkg.Scope().Lookup(receiver.TypeName)
+	if recvObj == nil {
+		return nil, fmt.Errorf("type %s not found in %s", receiver.TypeName, receiver.PkgPath)
+	}
+	obj, _, _ := types.LookupFieldOrMethod(recvObj.Type(), false, recvPkg, field)
+	fieldVar, ok := obj.(*types.Var)
+	if !ok || !fieldVar.IsField() {
+		return nil, fmt.Errorf("%s has no field %s", receiver.TypeName, field)
+	}
+	typ := fieldVar.Type()
+	for {
+		named, isNamed := types.Unalias(typ).(*types.Named)
+		if isNamed && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == SchemaPackagePath && named.TypeArgs().Len() == 1 {
+			typ = named.TypeArgs().At(0)
+			continue
+		}
+		switch u := typ.Underlying().(type) {
+		case *types.Interface:
+			if !isNamed {
+				return nil, fmt.Errorf("field %s.%s holds an unnamed interface", receiver.TypeName, field)
+			}
+			return named, nil
+		case *types.Slice:
+			typ = u.Elem()
+		case *types.Array:
+			typ = u.Elem()
+		case *types.Map:
+			typ = u.Elem()
+		default:
+			return nil, fmt.Errorf("field %s.%s does not hold an interface", receiver.TypeName, field)
+		}
+	}
+}
+
+// methodWireValue returns the string constant that named's method returns,
+// reading the method's declaration from its package among pkgs.
+func methodWireValue(pkgs modulePackages, named *types.Named, method string) (string, error) {
+	typeName := named.Obj().Name()
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), false, named.Obj().Pkg(), method)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return "", fmt.Errorf("implementation %s has no method %s to name its wire value", typeName, method)
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 || !isStringType(sig.Results().At(0).Type()) {
+		return "", fmt.Errorf("method %s.%s must take no arguments and return a string", typeName, method)
+	}
+	recvType := sig.Recv().Type()
+	if ptr, ok := recvType.(*types.Pointer); ok {
+		recvType = ptr.Elem()
+	}
+	recvName := recvType.(*types.Named).Obj().Name()
+	declPkg, ok := pkgs[fn.Pkg().Path()]
+	if !ok {
+		return "", fmt.Errorf("method %s.%s is declared in %s, outside the module", typeName, method, fn.Pkg().Path())
+	}
+	for _, file := range declPkg.Syntax {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name.Name != method || receiverTypeName(funcDecl) != recvName {
+				continue
+			}
+			if funcDecl.Body != nil && len(funcDecl.Body.List) == 1 {
+				if ret, ok := funcDecl.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+					if value := declPkg.TypesInfo.Types[ret.Results[0]].Value; value != nil && value.Kind() == constant.String {
+						return constant.StringVal(value), nil
+					}
+				}
+			}
+			return "", fmt.Errorf("method %s.%s must return a string constant to name a wire value", typeName, method)
+		}
+	}
+	return "", fmt.Errorf("declaration of %s.%s not found", typeName, method)
+}
+
+// receiverTypeName returns the name of a method's receiver type, without
+// any pointer, or "" for a function.
+func receiverTypeName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return ""
+	}
+	expr := decl.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func isStringType(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
@@ -22,6 +22,8 @@ var DefaultPackageCfg = &packages.Config{
 	BuildFlags: []string{"-tags=" + BuildTag},
 }
 
-func Load(path string) ([]*decorator.Package, error) {
-	return decorator.Load(DefaultPackageCfg, path)
+// Load loads the packages matching patterns, such as a directory or "./...",
+// with the jsonschema build tag set.
+func Load(patterns ...string) ([]*decorator.Package, error) {
+	return decorator.Load(DefaultPackageCfg, patterns...)
 }
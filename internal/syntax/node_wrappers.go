@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/token"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/dave/dst"
@@ -257,6 +258,27 @@ func (v ValueSpec) Comments() string {
 	return buildComments(v.Concrete, v.GenDecl.Concrete)
 }
 
+// EnumName returns the name given to the constant by a `jsonschema:"name"`
+// comment, if it has one.
+func (v ValueSpec) EnumName() (string, bool, error) {
+	decs := appendDecorations(clipCommentsString(v.Concrete.Decs.Start), v.Concrete.Decs.End)
+	if len(v.GenDecl.Concrete.Specs) == 1 {
+		decs = appendDecorations(clipCommentsString(v.GenDecl.Concrete.Decs.Start), append(decs, v.GenDecl.Concrete.Decs.End...))
+	}
+	for _, dec := range decs {
+		quoted, ok := enumNameDirective(dec)
+		if !ok {
+			continue
+		}
+		name, err := strconv.Unquote(quoted)
+		if err != nil || name == "" {
+			return "", false, fmt.Errorf("constant %s at %s: invalid comment directive %s, want jsonschema:\"name\"", v.Concrete.Names[0].Name, v.Position(), dec)
+		}
+		return name, true, nil
+	}
+	return "", false, nil
+}
+
 func (v ValueSpec) HasType() bool {
 	return v.Concrete.Type != nil
 }
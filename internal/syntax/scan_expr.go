@@ -64,6 +64,7 @@ var markerFunctions = []string{
 	MarkerFuncNewJSONSchemaFunc,
 	MarkerFuncNewInterfaceImpl,
 	MarkerFuncNewEnumType,
+	MarkerFuncNewTool,
 }
 
 func ParseValueExprForMarkerFunctionCall(e ValueSpec) []MarkerFunctionCall {
@@ -302,7 +303,10 @@ func (m MarkerFunctionCall) Args() []Expr {
 }
 
 func parseSchemaMethodOptions(args []Expr, receiver TypeID, m MarkerFunctionCall) ([]SchemaMethodOptionInfo, error) {
-	var out []SchemaMethodOptionInfo
+	var (
+		out     []SchemaMethodOptionInfo
+		modPkgs modulePackages
+	)
 	for _, a := range args {
 		ce, ok := a.Expr().(*dst.CallExpr)
 		if !ok {
@@ -345,6 +349,36 @@ func parseSchemaMethodOptions(args []Expr, receiver TypeID, m MarkerFunctionCall
 			}
 			continue
 		}
+		// WithExample(T{...}) takes a whole value of the receiver type.
+		if funID.TypeName == "WithExample" && len(ce.Args) == 1 {
+			value := NewExpr(ce.Args[0], m.CallExpr.pkg, m.CallExpr.file)
+			lit, ok := ce.Args[0].(*dst.CompositeLit)
+			if !ok || parseFuncFromExpr(value.NewExpr(lit.Type)).TypeName != receiver.TypeName {
+				return nil, fmt.Errorf("WithExample expects a %s literal at %s", receiver.TypeName, a.Position())
+			}
+			out = append(out, SchemaMethodOptionInfo{Kind: SchemaMethodOptionKind("WithExample"), Values: []Expr{value}})
+			continue
+		}
+		// WithEnumName(Const, "name") names a constant rather than a field.
+		if funID.TypeName == "WithEnumName" {
+			if len(ce.Args) != 2 {
+				return nil, fmt.Errorf("WithEnumName expects a constant and a name at %s", a.Position())
+			}
+			constID := parseFuncFromExpr(a.NewExpr(ce.Args[0]))
+			if constID.TypeName == "" || constID.Indirection == Pointer {
+				return nil, fmt.Errorf("WithEnumName expects a constant identifier at %s", a.Position())
+			}
+			nameLit, ok := ce.Args[1].(*dst.BasicLit)
+			if !ok || nameLit.Kind != token.STRING {
+				return nil, fmt.Errorf("WithEnumName expects a string literal name at %s", a.Position())
+			}
+			name, err := strconv.Unquote(nameLit.Value)
+			if err != nil || name == "" {
+				return nil, fmt.Errorf("invalid WithEnumName name at %s", a.Position())
+			}
+			out = append(out, SchemaMethodOptionInfo{Kind: SchemaMethodOptionKind("WithEnumName"), EnumConst: constID, EnumName: name})
+			continue
+		}
 		if len(ce.Args) < 1 {
 			continue
 		}
@@ -368,10 +402,15 @@ func parseSchemaMethodOptions(args []Expr, receiver TypeID, m MarkerFunctionCall
 				Kind:      SchemaMethodOptionKind("WithInterface"),
 				FieldName: fieldName,
 			})
+			var (
+				discover        bool
+				snakeCaseValues bool
+				wireValueMethod string
+			)
 			for _, nestedExpr := range ce.Args[1:] {
 				nested, ok := nestedExpr.(*dst.CallExpr)
 				if !ok {
-					return nil, fmt.Errorf("invalid interface option at %s: expected Discriminator(...) or Impl(...)", a.Position())
+					return nil, fmt.Errorf("invalid interface option at %s: expected Discriminator(...), Impl(...) or a tagging option", a.Position())
 				}
 				nestedID := parseFuncFromExpr(a.NewExpr(nested.Fun))
 				if nestedID.PkgPath != SchemaPackagePath {
@@ -417,10 +456,87 @@ func parseSchemaMethodOptions(args []Expr, receiver TypeID, m MarkerFunctionCall
 						DiscriminatorValue: value,
 						ImplTypes:          []TypeID{impl},
 					})
+				case "Untagged":
+					if len(nested.Args) != 0 {
+						return nil, fmt.Errorf("untagged takes no arguments at %s", a.Position())
+					}
+					out = append(out, SchemaMethodOptionInfo{
+						Kind:      SchemaMethodOptionKind("Untagged"),
+						FieldName: fieldName,
+					})
+				case "ExternallyTagged":
+					if len(nested.Args) != 0 {
+						return nil, fmt.Errorf("externally tagged option takes no arguments at %s", a.Position())
+					}
+					out = append(out, SchemaMethodOptionInfo{
+						Kind:      SchemaMethodOptionKind("ExternallyTagged"),
+						FieldName: fieldName,
+					})
+				case "AdjacentlyTagged":
+					if len(nested.Args) != 1 {
+						return nil, fmt.Errorf("adjacently tagged option expects one string at %s", a.Position())
+					}
+					valueLit, ok := nested.Args[0].(*dst.BasicLit)
+					if !ok || valueLit.Kind != token.STRING {
+						return nil, fmt.Errorf("adjacently tagged option expects a string literal at %s", a.Position())
+					}
+					value, err := strconv.Unquote(valueLit.Value)
+					if err != nil {
+						return nil, fmt.Errorf("invalid content property at %s: %w", a.Position(), err)
+					} else if value == "" {
+						return nil, fmt.Errorf("adjacently tagged option requires a content property name at %s", a.Position())
+					}
+					out = append(out, SchemaMethodOptionInfo{
+						Kind:            SchemaMethodOptionKind("AdjacentlyTagged"),
+						FieldName:       fieldName,
+						ContentPropName: value,
+					})
+				case "Implementations":
+					if len(nested.Args) != 0 {
+						return nil, fmt.Errorf("implementations takes no arguments at %s", a.Position())
+					}
+					discover = true
+				case "SnakeCaseWireValues":
+					if len(nested.Args) != 0 {
+						return nil, fmt.Errorf("snake case wire values option takes no arguments at %s", a.Position())
+					}
+					snakeCaseValues = true
+				case "WireValueMethod":
+					if len(nested.Args) != 1 {
+						return nil, fmt.Errorf("wire value method option expects one string at %s", a.Position())
+					}
+					valueLit, ok := nested.Args[0].(*dst.BasicLit)
+					if !ok || valueLit.Kind != token.STRING {
+						return nil, fmt.Errorf("wire value method option expects a string literal at %s", a.Position())
+					}
+					value, err := strconv.Unquote(valueLit.Value)
+					if err != nil || !token.IsIdentifier(value) {
+						return nil, fmt.Errorf("invalid wire value method name at %s", a.Position())
+					}
+					wireValueMethod = value
 				default:
 					return nil, fmt.Errorf("unknown interface option %s at %s", nestedID.TypeName, a.Position())
 				}
 			}
+			if !discover {
+				if snakeCaseValues || wireValueMethod != "" {
+					return nil, fmt.Errorf("SnakeCaseWireValues and WireValueMethod name discovered implementations; add Implementations() at %s", a.Position())
+				}
+				continue
+			}
+			if snakeCaseValues && wireValueMethod != "" {
+				return nil, fmt.Errorf("SnakeCaseWireValues and WireValueMethod cannot be combined at %s", a.Position())
+			}
+			found, err := discoverImplementations(&modPkgs, m.CallExpr.pkg, receiver, fieldName, wireValueMethod, snakeCaseValues)
+			if err != nil {
+				return nil, fmt.Errorf("implementations of %s.%s at %s: %w", receiver.TypeName, fieldName, a.Position(), err)
+			}
+			out = append(out, SchemaMethodOptionInfo{
+				Kind:       SchemaMethodOptionKind("Implementations"),
+				FieldName:  fieldName,
+				ImplTypes:  found.Impls,
+				WireValues: found.WireValues,
+			})
 			continue
 		}
 		var providerName string
@@ -436,10 +552,32 @@ func parseSchemaMethodOptions(args []Expr, receiver TypeID, m MarkerFunctionCall
 			kind = SchemaMethodOptionKind("WithStructFunctionMethod")
 		case "WithInterfaceImpls":
 			kind = SchemaMethodOptionKind("WithInterfaceImpls")
-		case "WithEnum":
-			kind = SchemaMethodOptionKind("WithEnum")
-		case "WithStringerEnum":
-			kind = SchemaMethodOptionKind("WithStringerEnum")
+		case "WithEnum", "WithStringerEnum":
+			info := SchemaMethodOptionInfo{Kind: SchemaMethodOptionKind(funName), FieldName: fieldName}
+			for _, nestedExpr := range ce.Args[1:] {
+				nested, ok := nestedExpr.(*dst.CallExpr)
+				if !ok {
+					return nil, fmt.Errorf("invalid enum option at %s: expected EnumDescriptions()", a.Position())
+				}
+				switch nestedID := parseFuncFromExpr(a.NewExpr(nested.Fun)); {
+				case nestedID.PkgPath == SchemaPackagePath && nestedID.TypeName == "EnumDescriptions":
+					info.EnumDescriptions = true
+				default:
+					return nil, fmt.Errorf("unknown enum option %s at %s", nestedID.TypeName, a.Position())
+				}
+			}
+			out = append(out, info)
+			continue
+		case "WithExamples":
+			if len(ce.Args) < 2 {
+				return nil, fmt.Errorf("WithExamples expects at least one value at %s", a.Position())
+			}
+			var values []Expr
+			for _, value := range ce.Args[1:] {
+				values = append(values, NewExpr(value, m.CallExpr.pkg, m.CallExpr.file))
+			}
+			out = append(out, SchemaMethodOptionInfo{Kind: SchemaMethodOptionKind("WithExamples"), FieldName: fieldName, Values: values})
+			continue
 		default:
 			continue
 		}
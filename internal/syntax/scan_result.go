@@ -3,6 +3,7 @@ package syntax
 import (
 	"fmt"
 	"go/token"
+	"maps"
 	"runtime/debug"
 	"slices"
 
@@ -104,6 +105,20 @@ type (
 		Discriminator      string
 		DiscriminatorValue string
 		ImplTypes          []TypeID
+		// ContentPropName is the content property given to AdjacentlyTagged.
+		ContentPropName string
+		// WireValues holds the wire value of each of ImplTypes found by
+		// Implementations.
+		WireValues []string
+		// Values holds the value expressions of WithExamples and WithExample.
+		Values []Expr
+		// EnumDescriptions is set by the EnumDescriptions option of WithEnum
+		// and WithStringerEnum.
+		EnumDescriptions bool
+		// EnumConst and EnumName are the constant and wire name given to
+		// WithEnumName.
+		EnumConst TypeID
+		EnumName  string
 	}
 
 	TypeDecls struct {
@@ -130,10 +145,8 @@ type (
 	SchemaFunction SchemaMethod
 
 	// IfaceImplementations represents an interface type and its allowed types.
-	// Error in the event that the loader encounters TypeName referenced on any
-	// types declared outside of this package.
-	// That is to say, in order for an interface implementations to work,
-	// all supported references to it must be in the local package.
+	// The implementations may be declared in this package or in any package
+	// it imports; those are scanned as dependencies.
 	IfaceImplementations struct {
 		TypeSpec TypeSpec
 		Impls    []TypeID
@@ -211,9 +224,21 @@ type ScanResult struct {
 	localTypeNames  map[string]bool
 	SchemaMethods   []SchemaMethod
 	SchemaFuncs     []SchemaFunction
+	Tools           []ToolFunc
 	LocalNamedTypes map[string]TypeSpec
 	remoteTypes     typesMap
-	deps            map[string]ScanResult
+	// remoteEnums are the types of other packages registered here with
+	// NewEnumType.
+	remoteEnums []TypeID
+	// enumDeps holds this package's own copies of the dependencies that
+	// declare remoteEnums, with those enums registered. deps is shared by
+	// every package scanned in a run, so registering them there would make
+	// the output depend on which packages are scanned together.
+	enumDeps map[string]ScanResult
+	deps     map[string]ScanResult
+	// loaded holds packages that were loaded up front or while resolving
+	// remote types, by PkgPath, so that no package is loaded twice.
+	loaded map[string]*decorator.Package
 	// temp variable used during resolution only.
 	resolveQueue            []TypeSpec
 	alreadyTraversedLocally map[string]bool
@@ -223,6 +248,9 @@ func (s ScanResult) GetPackage(pkgPath string) (ScanResult, bool) {
 	if pkgPath == s.Pkg.PkgPath {
 		return s, true
 	}
+	if res, ok := s.enumDeps[pkgPath]; ok {
+		return res, true
+	}
 	res, ok := s.deps[pkgPath]
 	return res, ok
 }
@@ -254,6 +282,40 @@ func LoadPackage(pkg *decorator.Package) (res ScanResult, err error) {
 	return
 }
 
+// PackageSet scans packages that were loaded together in one Load call.
+// Every ScanResult it returns shares one dependency cache, so a dependency
+// referenced from several packages is loaded and scanned once per run.
+type PackageSet struct {
+	loaded map[string]*decorator.Package
+	deps   map[string]ScanResult
+}
+
+// NewPackageSet returns a PackageSet over pkgs.
+func NewPackageSet(pkgs []*decorator.Package) *PackageSet {
+	set := &PackageSet{
+		loaded: make(map[string]*decorator.Package, len(pkgs)),
+		deps:   map[string]ScanResult{},
+	}
+	for _, pkg := range pkgs {
+		set.loaded[pkg.PkgPath] = pkg
+	}
+	return set
+}
+
+// Scan is LoadPackage with the set's shared dependency cache.
+func (s *PackageSet) Scan(pkg *decorator.Package) (res ScanResult, err error) {
+	res = newScanResult(pkg, s.deps)
+	res.loaded = s.loaded
+	err = res.loadPackageInternal(seenPackages{}, make(map[string]bool))
+	return
+}
+
+// HasSchemas reports whether the package registers any schema methods or
+// functions.
+func (s ScanResult) HasSchemas() bool {
+	return len(s.SchemaMethods) > 0 || len(s.SchemaFuncs) > 0
+}
+
 func loadPackageForTest(pkg *decorator.Package, typesToInclude ...string) (ScanResult, error) {
 	var types = make(map[string]bool)
 	for _, typeName := range typesToInclude {
@@ -276,6 +338,7 @@ func newScanResult(pkg *decorator.Package, deps map[string]ScanResult) ScanResul
 		remoteTypes:             typesMap{},
 		localTypeNames:          make(map[string]bool),
 		deps:                    deps,
+		loaded:                  make(map[string]*decorator.Package),
 		alreadyTraversedLocally: make(map[string]bool),
 	}
 }
@@ -379,10 +442,19 @@ func (r *ScanResult) loadPackageInternal(seen seenPackages, typesToMap map[strin
 	}
 
 	r.MarkerCalls = _decls.varDecls.MarkerFuncs()
+	var toolMarkers []MarkerFunctionCall
 	for _, decl := range r.MarkerCalls {
 		switch decl.CallExpr.MustIdentifyFunc().TypeName {
 		case MarkerFuncNewEnumType:
-			r.Constants[decl.MustTypeArgument().TypeName] = &EnumSet{}
+			enum := decl.MustTypeArgument()
+			if enum.PkgPath != r.Pkg.PkgPath {
+				// Registered here, declared elsewhere: the enum and its
+				// constants belong to the declaring package.
+				r.remoteEnums = append(r.remoteEnums, enum)
+				r.remoteTypes.addTypeByID(enum)
+				continue
+			}
+			r.Constants[enum.TypeName] = &EnumSet{}
 		case MarkerFuncNewInterfaceImpl:
 			var (
 				err   error
@@ -407,6 +479,7 @@ func (r *ScanResult) loadPackageInternal(seen seenPackages, typesToMap map[strin
 			}
 			r.localTypeNames[method.Receiver.TypeName] = true
 			typesToMap[method.Receiver.TypeName] = true
+			r.requestImplTypes(method.Options, typesToMap)
 			r.SchemaMethods = append(r.SchemaMethods, method)
 		case MarkerFuncNewJSONSchemaBuilder:
 			fn, err := decl.ParseSchemaBuilder()
@@ -423,7 +496,11 @@ func (r *ScanResult) loadPackageInternal(seen seenPackages, typesToMap map[strin
 			}
 			r.localTypeNames[fn.Receiver.TypeName] = true
 			typesToMap[fn.Receiver.TypeName] = true
+			r.requestImplTypes(fn.Options, typesToMap)
 			r.SchemaFuncs = append(r.SchemaFuncs, fn)
+		case MarkerFuncNewTool:
+			// Parsed once local types and interfaces are known.
+			toolMarkers = append(toolMarkers, decl)
 
 		default:
 			return fmt.Errorf("unsupported marker function: %s", decl.CallExpr.MustIdentifyFunc())
@@ -442,33 +519,20 @@ func (r *ScanResult) loadPackageInternal(seen seenPackages, typesToMap map[strin
 			}
 		}
 	}
+	for _, marker := range toolMarkers {
+		tool, err := r.parseTool(marker, _decls.funcDecls)
+		if err != nil {
+			return err
+		}
+		if err = r.registerTool(tool, marker, typesToMap); err != nil {
+			return err
+		}
+	}
 	// Find all locally defined enum values
-	for _, _constDecl := range _decls.constDecls {
-		var lastTypeName string // Track the last type seen in the const block
-		for _, spec := range _constDecl.Specs() {
-			var typeName string
-
-			if spec.HasType() {
-				// This constant has an explicit type
-				if ident, ok := spec.Type().(*dst.Ident); ok {
-					typeName = ident.Name
-					lastTypeName = typeName // Remember this type for subsequent constants
-				}
-			} else if lastTypeName != "" {
-				// This constant doesn't have an explicit type, use the last seen type
-				// This handles iota constants after the first one
-				typeName = lastTypeName
-			} else {
-				continue // No type information available
-			}
-
-			// Now we have a typeName, either explicit or inherited
-			if typeName != "" {
-				// Only append to the enum set if r.Constants[typeName] is non-nil:
-				if e, exists := r.Constants[typeName]; exists && e != nil {
-					e.Values = append(e.Values, spec)
-				}
-			}
+	for typeName, specs := range constSpecsByType(_decls.constDecls) {
+		// Only append to the enum set if r.Constants[typeName] is non-nil:
+		if e, exists := r.Constants[typeName]; exists && e != nil {
+			e.Values = append(e.Values, specs...)
 		}
 	}
 
@@ -481,7 +545,7 @@ func (r *ScanResult) loadPackageInternal(seen seenPackages, typesToMap map[strin
 	if err := r.resolveTypes(); err != nil {
 		return err
 	}
-	return nil
+	return r.registerRemoteEnums()
 }
 
 func (r *ScanResult) resolveTypeExpr(_expr Expr, seen SeenTypes) error {
@@ -498,11 +562,14 @@ func (r *ScanResult) resolveTypeExpr(_expr Expr, seen SeenTypes) error {
 	case *dst.ArrayType:
 		return r.resolveTypeExpr(_expr.NewExpr(expr.Elt), seen)
 	case *dst.MapType:
-		// This is a discovery boundary, not validation or rendering support.
-		// The renderer independently rejects maps. Discovery must not recurse
-		// into a shape that cannot be rendered, but it also must not prevent
-		// unrelated syntax operations such as flattening from loading a package.
-		return nil
+		// The renderer validates key types itself. Only keys declared in other
+		// packages are resolved, so that their enums can restrict property names.
+		if key, ok := expr.Key.(*dst.Ident); ok && key.Path != "" && key.Path != r.Pkg.PkgPath {
+			if err := r.resolveTypeExpr(_expr.NewExpr(key), seen); err != nil {
+				return err
+			}
+		}
+		return r.resolveTypeExpr(_expr.NewExpr(expr.Value), seen)
 	case *dst.InterfaceType:
 		// This is a discovery boundary, not validation or rendering support.
 		// Named interfaces registered through v1 schema options remain in
@@ -541,7 +608,9 @@ func (r *ScanResult) resolveTypeExpr(_expr Expr, seen SeenTypes) error {
 				var added bool
 				seen, added = seen.Add(named.ID())
 				if !added {
-					return fmt.Errorf("cyclic dependency found at %s", named.Position())
+					// Already being resolved further up; the builder renders
+					// the cycle with $defs/$ref.
+					return nil
 				}
 				if r.alreadyTraversedLocally[expr.Name] {
 					return nil
@@ -560,7 +629,7 @@ func (r *ScanResult) resolveTypeExpr(_expr Expr, seen SeenTypes) error {
 			}
 			return fmt.Errorf("undeclared local %s type found: %s at %s", expr.Name, _expr.Details(), _expr.Position())
 		} else {
-			if !IsTimeType(expr.Path, expr.Name) {
+			if !IsWellKnownType(expr.Path, expr.Name) {
 				r.remoteTypes.addType(expr.Path, expr.Name)
 			}
 		}
@@ -572,7 +641,7 @@ func (r *ScanResult) resolveTypeExpr(_expr Expr, seen SeenTypes) error {
 				// Fallback if there's no path, treat the 'X' as the package name.
 				pkgPath = xIdent.Name
 			}
-			if IsTimeType(pkgPath, expr.Sel.Name) {
+			if IsWellKnownType(pkgPath, expr.Sel.Name) {
 				return nil
 			}
 			r.remoteTypes.addType(pkgPath, expr.Sel.Name)
@@ -621,6 +690,25 @@ func skipField(field *dst.Field) bool {
 	return false
 }
 
+// loadPackage loads the package at a single path. Tests replace it to count
+// loads.
+var loadPackage = Load
+
+// loadRemote returns the package at pkgPath, loading it only if it was
+// neither part of the initial load nor loaded by an earlier lookup. Packages
+// that fail to load are cached too, so each is tried once per run.
+func (r *ScanResult) loadRemote(pkgPath string) (*decorator.Package, error) {
+	if pkg, ok := r.loaded[pkgPath]; ok {
+		return pkg, nil
+	}
+	pkgs, err := loadPackage(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	r.loaded[pkgPath] = pkgs[0]
+	return pkgs[0], nil
+}
+
 func (r *ScanResult) resolveTypes() error {
 	var (
 		ts  TypeSpec
@@ -648,10 +736,16 @@ func (r *ScanResult) resolveTypes() error {
 			if err = remote.resolveTypes(); err != nil {
 				return fmt.Errorf("resolving type at %s: %w", pkgPath, err)
 			}
-		} else if pkgs, err := Load(pkgPath); err != nil {
+		} else if pkg, err := r.loadRemote(pkgPath); err != nil {
 			return err
+		} else if len(pkg.Syntax) == 0 {
+			// The package could not be loaded, for example because its module
+			// is not required. Leaving it out of deps lets the builder report
+			// each field that uses it.
+			continue
 		} else {
-			remote = newScanResult(pkgs[0], r.deps)
+			remote = newScanResult(pkg, r.deps)
+			remote.loaded = r.loaded
 			if err = remote.loadPackageInternal(seenPackages{}, typeNames); err != nil {
 				return fmt.Errorf("resolving type at %s: %w", pkgPath, err)
 			}
@@ -661,6 +755,95 @@ func (r *ScanResult) resolveTypes() error {
 	return nil
 }
 
+// requestImplTypes queues the interface implementations named by Impl and
+// WithInterfaceImpls options for scanning, loading the packages of those
+// declared elsewhere.
+func (r *ScanResult) requestImplTypes(opts []SchemaMethodOptionInfo, typesToMap map[string]bool) {
+	for _, opt := range opts {
+		for _, impl := range opt.ImplTypes {
+			if impl.PkgPath == r.Pkg.PkgPath {
+				typesToMap[impl.TypeName] = true
+			} else {
+				r.remoteTypes.addTypeByID(impl)
+			}
+		}
+	}
+}
+
+// constSpecsByType groups the typed constants of a package by type name, in
+// declaration order.
+func constSpecsByType(constDecls []VarConstDecl) map[string][]ValueSpec {
+	byType := map[string][]ValueSpec{}
+	for _, _constDecl := range constDecls {
+		var lastTypeName string // Track the last type seen in the const block
+		for _, spec := range _constDecl.Specs() {
+			var typeName string
+
+			if spec.HasType() {
+				// This constant has an explicit type
+				if ident, ok := spec.Type().(*dst.Ident); ok {
+					typeName = ident.Name
+					lastTypeName = typeName // Remember this type for subsequent constants
+				}
+			} else if lastTypeName != "" {
+				// This constant doesn't have an explicit type, use the last seen type
+				// This handles iota constants after the first one
+				typeName = lastTypeName
+			} else {
+				continue // No type information available
+			}
+
+			// Now we have a typeName, either explicit or inherited
+			if typeName != "" {
+				byType[typeName] = append(byType[typeName], spec)
+			}
+		}
+	}
+	return byType
+}
+
+// registerRemoteEnums applies the NewEnumType calls naming types of other
+// packages to copies of the dependencies that declare them, so that every
+// type this package renders uses those types as enums.
+func (r *ScanResult) registerRemoteEnums() error {
+	for _, enum := range r.remoteEnums {
+		dep, ok := r.GetPackage(enum.PkgPath)
+		if !ok {
+			return fmt.Errorf("NewEnumType: package %s of enum %s could not be loaded", enum.PkgPath, enum.TypeName)
+		}
+		if _, copied := r.enumDeps[enum.PkgPath]; !copied {
+			dep.Constants = maps.Clone(dep.Constants)
+			dep.LocalNamedTypes = maps.Clone(dep.LocalNamedTypes)
+		}
+		if err := dep.registerEnum(enum.TypeName); err != nil {
+			return fmt.Errorf("NewEnumType: %w", err)
+		}
+		if r.enumDeps == nil {
+			r.enumDeps = map[string]ScanResult{}
+		}
+		r.enumDeps[enum.PkgPath] = dep
+	}
+	return nil
+}
+
+// registerEnum turns a type scanned as an ordinary named type into an enum
+// whose values are the package's constants of that type.
+func (r *ScanResult) registerEnum(typeName string) error {
+	if r.Constants[typeName] != nil {
+		return nil
+	}
+	ts, ok := r.LocalNamedTypes[typeName]
+	if !ok {
+		return fmt.Errorf("enum type %s not found in %s", typeName, r.Pkg.PkgPath)
+	}
+	r.Constants[typeName] = &EnumSet{
+		TypeSpec: ts,
+		Values:   constSpecsByType(loadPkgDecls(r.Pkg).constDecls)[typeName],
+	}
+	delete(r.LocalNamedTypes, typeName)
+	return nil
+}
+
 func (r *ScanResult) requestType(typeName string) error {
 	if named, ok := r.LocalNamedTypes[typeName]; ok {
 		alreadyQueued := slices.ContainsFunc(r.resolveQueue, func(queued TypeSpec) bool {
@@ -2,9 +2,12 @@ package syntax
 
 import (
 	"go/token"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
 	"github.com/stretchr/testify/require"
 )
 
@@ -83,10 +86,11 @@ func TestAlreadyLoadedRegisteredEnumResolves(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestIsTimeType(t *testing.T) {
-	require.True(t, IsTimeType("time", "Time"))
-	require.False(t, IsTimeType("time", "Duration"))
-	require.False(t, IsTimeType("example.com/time", "Time"))
+func TestIsWellKnownType(t *testing.T) {
+	require.True(t, IsWellKnownType("time", "Time"))
+	require.True(t, IsWellKnownType("time", "Duration"))
+	require.False(t, IsWellKnownType("time", "Month"))
+	require.False(t, IsWellKnownType("example.com/time", "Time"))
 }
 
 func TestTimeTypeIsNotRegisteredAsRemote(t *testing.T) {
@@ -100,6 +104,8 @@ func TestTimeTypeIsNotRegisteredAsRemote(t *testing.T) {
 	}{
 		{name: "decorated ident", expr: &dst.Ident{Path: "time", Name: "Time"}},
 		{name: "selector fallback", expr: &dst.SelectorExpr{X: dst.NewIdent("time"), Sel: dst.NewIdent("Time")}},
+		{name: "netip address", expr: &dst.Ident{Path: "net/netip", Name: "Addr"}},
+		{name: "uuid", expr: &dst.Ident{Path: "github.com/google/uuid", Name: "UUID"}},
 	}
 
 	for _, tt := range tests {
@@ -138,3 +144,109 @@ func loadTypeScannerResult(t *testing.T) ScanResult {
 	require.NoError(t, err)
 	return scan
 }
+
+func TestPackageSetLoadsEachRemotePackageOnce(t *testing.T) {
+	root := writeSharedDepsFixture(t)
+	pkgs, err := Load(filepath.Join(root, "a"), filepath.Join(root, "b"))
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+
+	loads := map[string]int{}
+	t.Cleanup(func() { loadPackage = Load })
+	loadPackage = func(patterns ...string) ([]*decorator.Package, error) {
+		for _, pattern := range patterns {
+			loads[pattern]++
+		}
+		return Load(patterns...)
+	}
+
+	set := NewPackageSet(pkgs)
+	for _, pkg := range pkgs {
+		_, err := set.Scan(pkg)
+		require.NoError(t, err)
+	}
+	importPath := "github.com/tylergannon/go-gen-jsonschema/internal/syntax/testfixtures/" + filepath.Base(root)
+	require.Equal(t, map[string]int{
+		importPath + "/common": 1,
+		importPath + "/deeper": 1,
+		"example.com/missing":  1,
+	}, loads)
+}
+
+// writeSharedDepsFixture writes packages a and b, which both use types of
+// common, deeper and the unloadable example.com/missing, to a new directory
+// under testfixtures. The directory is removed when the test ends.
+func writeSharedDepsFixture(t *testing.T) string {
+	t.Helper()
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	root, err := os.MkdirTemp(filepath.Join(cwd, "testfixtures"), "shareddeps")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(root))
+	})
+	importPath := "github.com/tylergannon/go-gen-jsonschema/internal/syntax/testfixtures/" + filepath.Base(root)
+	user := func(name string) string {
+		return `package ` + name + `
+
+import (
+	"` + importPath + `/common"
+	"` + importPath + `/deeper"
+	missing "example.com/missing"
+)
+
+type Owner struct {
+	Thing    common.Thing   ` + "`json:\"thing\"`" + `
+	Inner    deeper.Inner   ` + "`json:\"inner\"`" + `
+	Tracking missing.Number ` + "`json:\"tracking\"`" + `
+}
+`
+	}
+	schema := func(name string) string {
+		return `//go:build jsonschema
+
+package ` + name + `
+
+import (
+	"encoding/json"
+
+	jsonschema "github.com/tylergannon/go-gen-jsonschema"
+)
+
+func (Owner) Schema() json.RawMessage { panic("not implemented") }
+
+var _ = jsonschema.NewJSONSchemaMethod(Owner.Schema)
+`
+	}
+	files := map[string]string{
+		"a/types.go":  user("a"),
+		"a/schema.go": schema("a"),
+		"b/types.go":  user("b"),
+		"b/schema.go": schema("b"),
+		"common/thing.go": `package common
+
+import (
+	"` + importPath + `/deeper"
+	missing "example.com/missing"
+)
+
+type Thing struct {
+	Inner deeper.Inner   ` + "`json:\"inner\"`" + `
+	Count missing.Number ` + "`json:\"count\"`" + `
+}
+`,
+		"deeper/inner.go": `package deeper
+
+type Inner struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+	return root
+}
@@ -0,0 +1,328 @@
+package syntax
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dave/dst"
+)
+
+const (
+	MarkerFuncNewTool = "NewTool" // NewTool
+
+	toolOptionName        = "ToolName"
+	toolOptionDescription = "ToolDescription"
+	toolOptionDependency  = "ToolDependency"
+)
+
+// ToolArgKind says where a tool function's parameter gets its value.
+type ToolArgKind uint8
+
+const (
+	// ToolArgParam is supplied by the model, as a property of the params
+	// struct.
+	ToolArgParam ToolArgKind = iota
+	// ToolArgContext receives the context passed to Invoke.
+	ToolArgContext
+	// ToolArgDependency is injected when the tool is constructed.
+	ToolArgDependency
+)
+
+type (
+	// ToolFunc is a function registered with NewTool.
+	ToolFunc struct {
+		Func        FuncDecl
+		Name        string
+		Description string
+		// Params is the struct synthesized from the ToolArgParam parameters.
+		// It is scanned and rendered like a declared type.
+		Params TypeSpec
+		// Args lists every parameter of Func, in order.
+		Args []ToolArg
+		// Result is the type of Func's first result; the second is error.
+		Result types.Type
+		// Dependencies names the parameters listed with ToolDependency.
+		Dependencies []string
+	}
+
+	ToolArg struct {
+		// Name is the parameter name, which may be empty for a context.
+		Name string
+		// Field is the params struct field for a ToolArgParam.
+		Field string
+		Kind  ToolArgKind
+		Type  types.Type
+	}
+)
+
+// FuncName is the name of the registered function.
+func (t ToolFunc) FuncName() string {
+	return t.Func.Concrete.Name.Name
+}
+
+// ImplType is the name of the generated type implementing the tool.
+func (t ToolFunc) ImplType() string {
+	return unexportedName(t.FuncName()) + "Tool"
+}
+
+// Constructor is the name of the generated function returning the tool.
+func (t ToolFunc) Constructor() string {
+	return "New" + exportedName(t.FuncName()) + "Tool"
+}
+
+// parseTool reads a NewTool marker. The function must be declared in this
+// package. Its params struct is added to LocalNamedTypes and registered as a
+// schema method receiver, so it is rendered like any other schema type.
+func (r *ScanResult) parseTool(m MarkerFunctionCall, funcDecls []FuncDecl) (tool ToolFunc, err error) {
+	args := m.CallExpr.Args()
+	if len(args) == 0 {
+		return tool, fmt.Errorf("NewTool requires a function at %s", m.CallExpr.Position())
+	}
+	ident, ok := args[0].Expr().(*dst.Ident)
+	if !ok || (ident.Path != "" && ident.Path != r.Pkg.PkgPath) {
+		return tool, fmt.Errorf("NewTool requires a function declared in this package at %s", args[0].Position())
+	}
+	for _, decl := range funcDecls {
+		if decl.Concrete.Recv == nil && decl.Concrete.Name.Name == ident.Name {
+			tool.Func = decl
+		}
+	}
+	fn, _ := r.Pkg.Types.Scope().Lookup(ident.Name).(*types.Func)
+	if tool.Func.Concrete == nil || fn == nil {
+		return tool, fmt.Errorf("NewTool requires a function declared in this package, found %s at %s", ident.Name, args[0].Position())
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Variadic() {
+		return tool, fmt.Errorf("tool function %s cannot be variadic at %s", ident.Name, tool.Func.Position())
+	}
+	if sig.Results().Len() != 2 || !types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type()) {
+		return tool, fmt.Errorf("tool function %s must return (R, error) at %s", ident.Name, tool.Func.Position())
+	}
+	tool.Result = sig.Results().At(0).Type()
+	tool.Name = snakeCase(ident.Name)
+	tool.Description = BuildComments(tool.Func.Concrete.Decorations())
+	for _, opt := range args[1:] {
+		if err = tool.applyOption(opt); err != nil {
+			return tool, err
+		}
+	}
+
+	paramsName := exportedName(ident.Name) + "Params"
+	if _, exists := r.LocalNamedTypes[paramsName]; exists {
+		return tool, fmt.Errorf("tool function %s needs the name %s for its params struct, but it is already declared", ident.Name, paramsName)
+	}
+	if name := tool.ImplType(); r.Pkg.Types.Scope().Lookup(name) != nil {
+		return tool, fmt.Errorf("tool function %s needs the name %s for its implementation type, but it is already declared", ident.Name, name)
+	}
+	if name := tool.Constructor(); r.Pkg.Types.Scope().Lookup(name) != nil {
+		return tool, fmt.Errorf("tool function %s needs the name %s for its constructor, but it is already declared", ident.Name, name)
+	}
+	var (
+		fields []*dst.Field
+		index  int
+		names  = map[string]bool{}
+	)
+	for _, field := range tool.Func.Concrete.Type.Params.List {
+		fieldNames := field.Names
+		if len(fieldNames) == 0 {
+			fieldNames = []*dst.Ident{{}}
+		}
+		for _, name := range fieldNames {
+			arg := ToolArg{Name: name.Name, Type: sig.Params().At(index).Type()}
+			index++
+			arg.Kind = r.toolArgKind(arg.Type, slices.Contains(tool.Dependencies, arg.Name))
+			pos := NewExpr(field.Type, r.Pkg, tool.Func.file).Position()
+			if arg.Kind != ToolArgContext && (arg.Name == "" || arg.Name == "_") {
+				return tool, fmt.Errorf("tool function %s has an unnamed parameter at %s", ident.Name, pos)
+			}
+			if arg.Kind == ToolArgParam && !r.toolParamType(arg.Type) {
+				return tool, fmt.Errorf("tool function %s parameter %s has type %s, which a model cannot supply; list it with %s(%q) to pass it to the constructor, at %s",
+					ident.Name, arg.Name, arg.Type, toolOptionDependency, arg.Name, pos)
+			}
+			if arg.Kind == ToolArgParam {
+				if arg.Field = exportedName(arg.Name); names[arg.Field] {
+					return tool, fmt.Errorf("tool function %s has parameters that differ only in case at %s", ident.Name, pos)
+				}
+				names[arg.Field] = true
+				fields = append(fields, r.toolParamField(arg, field, tool.Func))
+			}
+			tool.Args = append(tool.Args, arg)
+		}
+	}
+	for _, dep := range tool.Dependencies {
+		if !slices.ContainsFunc(tool.Args, func(arg ToolArg) bool { return arg.Name == dep }) {
+			return tool, fmt.Errorf("tool function %s has no parameter %s named by %s", ident.Name, dep, toolOptionDependency)
+		}
+	}
+	tool.Params = r.synthesizeStruct(paramsName, fields, tool.Func)
+	return tool, nil
+}
+
+func (t *ToolFunc) applyOption(opt Expr) error {
+	call, ok := opt.Expr().(*dst.CallExpr)
+	if !ok {
+		return fmt.Errorf("unsupported NewTool option at %s", opt.Position())
+	}
+	id, ok := NewCallExpr(call, opt.Pkg(), opt.File()).IdentifyFunc()
+	if !ok || id.PkgPath != SchemaPackagePath || len(call.Args) != 1 {
+		return fmt.Errorf("unsupported NewTool option at %s", opt.Position())
+	}
+	lit, ok := call.Args[0].(*dst.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return fmt.Errorf("%s requires a string literal at %s", id.TypeName, opt.Position())
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return fmt.Errorf("%s at %s: %w", id.TypeName, opt.Position(), err)
+	}
+	switch id.TypeName {
+	case toolOptionName:
+		if value == "" {
+			return fmt.Errorf("%s cannot be empty at %s", id.TypeName, opt.Position())
+		}
+		t.Name = value
+	case toolOptionDescription:
+		t.Description = value
+	case toolOptionDependency:
+		if slices.Contains(t.Dependencies, value) {
+			return fmt.Errorf("%s(%q) is repeated at %s", id.TypeName, value, opt.Position())
+		}
+		t.Dependencies = append(t.Dependencies, value)
+	default:
+		return fmt.Errorf("unsupported NewTool option %s at %s", id.TypeName, opt.Position())
+	}
+	return nil
+}
+
+// toolArgKind classifies a parameter type. Parameters listed with
+// ToolDependency are dependencies, whatever their type.
+func (r *ScanResult) toolArgKind(t types.Type, dependency bool) ToolArgKind {
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context" {
+			return ToolArgContext
+		}
+	}
+	if dependency {
+		return ToolArgDependency
+	}
+	return ToolArgParam
+}
+
+// toolParamType reports whether a model can supply a parameter of type t:
+// pointers and interfaces, other than registered interfaces, are left to
+// ToolDependency.
+func (r *ScanResult) toolParamType(t types.Type) bool {
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		if _, registered := r.Interfaces[obj.Name()]; registered && obj.Pkg() == r.Pkg.Types {
+			return true
+		}
+	}
+	_, pointer := t.(*types.Pointer)
+	return !pointer && !types.IsInterface(t)
+}
+
+// toolParamField builds the params struct field for arg, declared by param.
+// The field shares param's type expression, so positions in errors point at
+// the function signature.
+func (r *ScanResult) toolParamField(arg ToolArg, param *dst.Field, decl FuncDecl) *dst.Field {
+	tag := arg.Name
+	if kind, _, ok := wrapperExpr(NewExpr(param.Type, r.Pkg, decl.file)); ok && kind == WrapperOptional {
+		tag += ",omitzero"
+	}
+	field := &dst.Field{
+		Names: []*dst.Ident{dst.NewIdent(arg.Field)},
+		Type:  param.Type,
+		Tag:   &dst.BasicLit{Kind: token.STRING, Value: "`json:\"" + tag + "\"`"},
+	}
+	field.Decs.Start = param.Decs.Start
+	field.Decs.End = param.Decs.End
+	r.mapSynthesized(param, field, field.Names[0], field.Tag)
+	return field
+}
+
+// synthesizeStruct declares a struct type that exists only in the scan. Its
+// nodes report the position of decl.
+func (r *ScanResult) synthesizeStruct(name string, fields []*dst.Field, decl FuncDecl) TypeSpec {
+	spec := &dst.TypeSpec{
+		Name: dst.NewIdent(name),
+		Type: &dst.StructType{Fields: &dst.FieldList{List: fields, Opening: true, Closing: true}},
+	}
+	genDecl := &dst.GenDecl{Tok: token.TYPE, Specs: []dst.Spec{spec}}
+	r.mapSynthesized(decl.Concrete, genDecl, spec, spec.Name, spec.Type, spec.Type.(*dst.StructType).Fields)
+	return NewTypeSpec(genDecl, spec, r.Pkg, decl.file)
+}
+
+func (r *ScanResult) mapSynthesized(from dst.Node, nodes ...dst.Node) {
+	astNodes := r.Pkg.Decorator.Map.Ast.Nodes
+	for _, node := range nodes {
+		astNodes[node] = astNodes[from]
+	}
+}
+
+// registerTool adds tool's params struct to the scan as a schema type.
+func (r *ScanResult) registerTool(tool ToolFunc, marker MarkerFunctionCall, typesToMap map[string]bool) error {
+	for _, other := range r.Tools {
+		if other.Name == tool.Name {
+			return fmt.Errorf("tool name %q is used by both %s and %s", tool.Name, other.FuncName(), tool.FuncName())
+		}
+	}
+	name := tool.Params.Name()
+	r.Tools = append(r.Tools, tool)
+	r.LocalNamedTypes[name] = tool.Params
+	r.localTypeNames[name] = true
+	typesToMap[name] = true
+	r.SchemaMethods = append(r.SchemaMethods, SchemaMethod{
+		Receiver:         tool.Params.ID(),
+		SchemaMethodName: "Schema",
+		MarkerCall:       marker,
+	})
+	return nil
+}
+
+// IsToolParams reports whether typeName is the params struct of a tool.
+func (s ScanResult) IsToolParams(typeName string) bool {
+	for _, tool := range s.Tools {
+		if tool.Params.Name() == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+func unexportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// snakeCase converts a Go identifier such as GetUserByID to get_user_by_id.
+func snakeCase(name string) string {
+	var (
+		b     strings.Builder
+		runes = []rune(name)
+	)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			acronymEnd := i > 0 && unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || acronymEnd {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
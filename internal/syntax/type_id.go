@@ -2,11 +2,19 @@ package syntax
 
 import "fmt"
 
-// IsTimeType reports whether a type identity is the renderer-owned time.Time
-// leaf. Keeping this identity in syntax lets discovery and rendering share the
-// same package boundary.
-func IsTimeType(pkgPath, typeName string) bool {
-	return pkgPath == "time" && typeName == "Time"
+// wellKnownTypes are types from other packages whose schemas the renderer
+// owns, such as time.Time, so discovery never scans their declarations.
+var wellKnownTypes = map[TypeID]bool{
+	{PkgPath: "time", TypeName: "Time"}:                   true,
+	{PkgPath: "time", TypeName: "Duration"}:               true,
+	{PkgPath: "net/netip", TypeName: "Addr"}:              true,
+	{PkgPath: "github.com/google/uuid", TypeName: "UUID"}: true,
+}
+
+// IsWellKnownType reports whether a type identity is rendered from a fixed
+// schema rather than from its declaration.
+func IsWellKnownType(pkgPath, typeName string) bool {
+	return wellKnownTypes[TypeID{PkgPath: pkgPath, TypeName: typeName}]
 }
 
 type Indirection int
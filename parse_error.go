@@ -0,0 +1,64 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParseErrorKind distinguishes why a generated Parse function failed.
+type ParseErrorKind string
+
+const (
+	// ParseSchemaViolation means the input was well-formed but did not
+	// conform to the type's schema. The ParseError wraps a *ValidationError.
+	ParseSchemaViolation ParseErrorKind = "schema violation"
+	// ParseDecodeFailure means the input was malformed, or conformed to the
+	// schema but could not be decoded into the Go type.
+	ParseDecodeFailure ParseErrorKind = "decode failure"
+)
+
+// ParseError is returned by generated Parse<Type> and Parse<Type>YAML
+// functions, which validate their input against the type's schema before
+// decoding it.
+type ParseError struct {
+	// Type is the name of the Go type being parsed.
+	Type string
+	Kind ParseErrorKind
+	// Err is the underlying error: a *ValidationError for schema violations,
+	// or the parser's or decoder's error otherwise.
+	Err error
+}
+
+// NewParseError classifies err, returned while validating or decoding input
+// for typeName. A *ValidationError in err's chain is a schema violation; any
+// other error is a decode failure. A nil err yields nil.
+func NewParseError(typeName string, err error) error {
+	if err == nil {
+		return nil
+	}
+	kind := ParseDecodeFailure
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		kind = ParseSchemaViolation
+	}
+	return &ParseError{Type: typeName, Kind: kind, Err: err}
+}
+
+func (e *ParseError) Error() string {
+	if e.Kind == ParseSchemaViolation {
+		return fmt.Sprintf("parse %s: schema violation:\n%s", e.Type, e.Err)
+	}
+	return fmt.Sprintf("parse %s: %s", e.Type, e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// SchemaViolation returns the validation error when the input did not
+// conform to the schema.
+func (e *ParseError) SchemaViolation() (*ValidationError, bool) {
+	var validationErr *ValidationError
+	if e.Kind == ParseSchemaViolation && errors.As(e.Err, &validationErr) {
+		return validationErr, true
+	}
+	return nil, false
+}
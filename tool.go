@@ -0,0 +1,63 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Tool is a Go function exposed to a model as a callable tool. Implementations
+// are generated for functions registered with NewTool.
+type Tool interface {
+	// Name is the tool name a model calls.
+	Name() string
+	// Description tells the model what the tool does.
+	Description() string
+	// Parameters is the JSON schema of the arguments a model supplies.
+	Parameters() json.RawMessage
+	// Invoke validates and decodes params, a JSON object of arguments, and
+	// calls the function. Arguments that violate the schema are returned as
+	// a *ParseError.
+	Invoke(ctx context.Context, params string) (string, error)
+}
+
+type (
+	ToolMarker struct{}
+
+	ToolOption interface {
+		implementsToolOption()
+	}
+
+	toolOptionObj struct{}
+)
+
+func (toolOptionObj) implementsToolOption() {}
+
+// NewTool registers fn, a function declared in the same package, as a tool.
+// The generator writes a <Func>Params struct whose properties are fn's named
+// parameters, described by their comments, and a New<Func>Tool constructor
+// for a Tool that calls fn.
+//
+// A context.Context parameter receives the context passed to Invoke.
+// Parameters named with ToolDependency, such as a *sql.DB or *slog.Logger,
+// are arguments of New<Func>Tool rather than schema properties; any other
+// pointer or interface parameter, unless it is a registered interface, is an
+// error. fn must return (R, error); a string R is returned from Invoke as is,
+// and any other R is encoded as JSON.
+//
+// The tool name defaults to fn's name in snake_case and the description to
+// fn's doc comment.
+func NewTool(fn any, _ ...ToolOption) ToolMarker {
+	return ToolMarker{}
+}
+
+// ToolName overrides the name of a tool registered with NewTool.
+func ToolName(name string) ToolOption { return toolOptionObj{} }
+
+// ToolDescription overrides the description of a tool registered with
+// NewTool.
+func ToolDescription(description string) ToolOption { return toolOptionObj{} }
+
+// ToolDependency marks the parameter of a tool function called param as a
+// dependency. It is passed to the generated constructor once, rather than
+// supplied by the model on each call. Repeat it for each dependency.
+func ToolDependency(param string) ToolOption { return toolOptionObj{} }
@@ -38,6 +38,12 @@ type InterfaceOption interface {
 	implementsInterfaceOption()
 }
 
+// EnumOption configures an enum field registered with WithEnum or
+// WithStringerEnum.
+type EnumOption interface {
+	implementsEnumOption()
+}
+
 type exampleStruct struct {
 	Field1 string
 	Field2 int
@@ -80,6 +86,10 @@ type InterfaceOptionObj struct{}
 
 func (InterfaceOptionObj) implementsInterfaceOption() {}
 
+type EnumOptionObj struct{}
+
+func (EnumOptionObj) implementsEnumOption() {}
+
 // Interface options (v1) - stubs for scanning/type-checking; parsed by scanner
 func WithInterface[T any](field T, options ...InterfaceOption) SchemaMethodOption {
 	return SchemaMethodOptionObj{}
@@ -89,8 +99,50 @@ func WithInterface[T any](field T, options ...InterfaceOption) SchemaMethodOptio
 func Discriminator(name string) InterfaceOption { return InterfaceOptionObj{} }
 
 // Impl registers an interface implementation with its stable wire value.
+// The implementation may be declared in another package, as in
+// Impl("email", email.Send{}); the generated code imports it.
 func Impl[T any](value string, impl T) InterfaceOption { return InterfaceOptionObj{} }
 
+// Untagged renders the interface as an anyOf of its implementations with no
+// discriminator property. The generated UnmarshalJSON validates a value
+// against each implementation's schema, in registration order, and decodes
+// the single one that matches; no match, or more than one, is an error.
+func Untagged() InterfaceOption { return InterfaceOptionObj{} }
+
+// ExternallyTagged encodes each interface value as an object with a single
+// property, named by the implementation's wire value, that holds the value:
+// {"credit_card": {...}}. It cannot be combined with Discriminator.
+//
+// ExternallyTagged and AdjacentlyTagged unions render their options as anyOf,
+// like every other interface, rather than the oneOf an exclusive tag would
+// allow: strict-mode providers accept anyOf only, and the distinct tags
+// already keep any value from matching two options.
+func ExternallyTagged() InterfaceOption { return InterfaceOptionObj{} }
+
+// AdjacentlyTagged encodes each interface value as an object holding the wire
+// value in the discriminator property and the value itself in the content
+// property: {"type": "credit_card", "<content>": {...}}. Discriminator sets
+// the tag property; content must be a different, non-empty name.
+func AdjacentlyTagged(content string) InterfaceOption { return InterfaceOptionObj{} }
+
+// Implementations registers every struct type that implements the interface,
+// found among the packages of the registering package's module. A type whose methods are on its pointer is registered as a
+// pointer. Each implementation's wire value is its type name, unless
+// SnakeCaseWireValues or WireValueMethod says otherwise; an Impl option for
+// a discovered type sets its wire value explicitly. Two implementations with
+// the same wire value are an error.
+func Implementations() InterfaceOption { return InterfaceOptionObj{} }
+
+// SnakeCaseWireValues names the implementations found by Implementations by
+// their type names in snake_case, such as credit_card for CreditCard.
+func SnakeCaseWireValues() InterfaceOption { return InterfaceOptionObj{} }
+
+// WireValueMethod names each implementation found by Implementations by the
+// string constant its method returns, as in
+//
+//	func (Send) Kind() string { return "email" }
+func WireValueMethod(method string) InterfaceOption { return InterfaceOptionObj{} }
+
 func WithInterfaceImpls[T any](field T, impls ...any) SchemaMethodOption {
 	return SchemaMethodOptionObj{}
 }
@@ -99,8 +151,33 @@ func WithDiscriminator[T any](field T, name string) SchemaMethodOption {
 }
 
 // Enum options (v1) - stubs for scanning/type-checking; parsed by scanner
-func WithEnum[T any](field T) SchemaMethodOption         { return SchemaMethodOptionObj{} }
-func WithStringerEnum[T any](field T) SchemaMethodOption { return SchemaMethodOptionObj{} }
+func WithEnum[T any](field T, options ...EnumOption) SchemaMethodOption {
+	return SchemaMethodOptionObj{}
+}
+func WithStringerEnum[T any](field T, options ...EnumOption) SchemaMethodOption {
+	return SchemaMethodOptionObj{}
+}
+
+// WithEnumName sets the name that WithStringerEnum gives an integer enum
+// constant, such as WithEnumName(LogDebug, "debug"), in place of its Go
+// identifier. The name is used by the schema and by the generated marshalers
+// of every field holding the enum. A `jsonschema:"name"` comment on the
+// constant has the same effect.
+func WithEnumName[T any](value T, name string) SchemaMethodOption { return SchemaMethodOptionObj{} }
+
+// EnumDescriptions renders an enum field as an "anyOf" of "const" values,
+// each described by its constant's doc comment, instead of a bare "enum".
+// Dialects without "const" list the descriptions in the field description.
+func EnumDescriptions() EnumOption { return EnumOptionObj{} }
+
+// WithExamples adds values to a field's "examples". Each value must be a
+// constant or composite literal of the field's type; the generator encodes it
+// as JSON and checks it against the field's schema.
+func WithExamples[T any](field T, values ...T) SchemaMethodOption { return SchemaMethodOptionObj{} }
+
+// WithExample adds a literal value of the registered type to the "examples" of
+// its schema, such as WithExample(Person{Name: "Ada"}).
+func WithExample[T any](value T) SchemaMethodOption { return SchemaMethodOptionObj{} }
 
 // NewJSONSchemaMethod registers a struct method as a stub that will be implemented
 // with a proper json schema and, as needed, unmarshaler functionality.
@@ -127,15 +204,17 @@ var _ SchemaMarker = NewJSONSchemaMethod(
 //  1. If called in the same package as the interface itself, then all global
 //     instances can be replaced.
 //  2. If called somewhere else, only applies to the local package.
+//
+// The implementations may be declared in any package.
 func NewInterfaceImpl[T any](...T) InterfaceMarker {
 	return InterfaceMarker{}
 }
 
-// NewEnumType denotes that the type argument should be an enum.
-// If called in the same package where the type is declared, then
-// it applies globally.
-// In all cases, the const values MUST be declared in the same
-// package as the call to NewEnumType.
+// NewEnumType denotes that the type argument should be an enum whose
+// values are the constants of that type in the package that declares it.
+// Called in the declaring package, the registration applies to every
+// package that uses the type; called in a package that imports it, the
+// registration applies only to the schemas generated for that package.
 //
 // For now, only string types are supported.
 func NewEnumType[T ~string]() EnumType {
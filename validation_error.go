@@ -0,0 +1,421 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	santhosh "github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+var englishPrinter = message.NewPrinter(language.English)
+
+// ValidationError is returned by generated ValidateJSON and ValidateYAML
+// methods when a value does not conform to its schema. Error renders one
+// compact line per problem, suitable for sending back to a model on a retry
+// turn; Problems exposes the same information programmatically. The
+// underlying validator error remains available through errors.As.
+type ValidationError struct {
+	Problems []ValidationProblem
+	cause    *santhosh.ValidationError
+}
+
+// ValidationProblem is one reason a value failed validation.
+type ValidationProblem struct {
+	// InstanceLocation is the JSON pointer of the offending value, such as
+	// "/items/0/quantity". The document root is "".
+	InstanceLocation string
+	// KeywordLocation is the JSON pointer of the failing schema keyword
+	// within the schema document, such as "/properties/quantity/minimum".
+	KeywordLocation string
+	// Problem describes what is wrong with the value.
+	Problem string
+	// Expected describes what the schema allows, when that is known.
+	Expected string
+	// Description is the schema description of the offending property.
+	Description string
+}
+
+// String formats the problem as "path: problem (expected ...)", followed by
+// the property description when the schema has one.
+func (p ValidationProblem) String() string {
+	var sb strings.Builder
+	if p.InstanceLocation == "" {
+		sb.WriteString("(root)")
+	} else {
+		sb.WriteString(p.InstanceLocation)
+	}
+	sb.WriteString(": ")
+	sb.WriteString(p.Problem)
+	if p.Expected != "" {
+		fmt.Fprintf(&sb, " (expected %s)", p.Expected)
+	}
+	if p.Description != "" {
+		sb.WriteString(" -- ")
+		sb.WriteString(strings.Join(strings.Fields(p.Description), " "))
+	}
+	return sb.String()
+}
+
+// Error lists every problem, one per line, ordered by location.
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		lines[i] = problem.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the validator's own error.
+func (e *ValidationError) Unwrap() error { return e.cause }
+
+// InstanceLocations returns the JSON pointer of each problem, in order.
+func (e *ValidationError) InstanceLocations() []string {
+	locations := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		locations[i] = problem.InstanceLocation
+	}
+	return locations
+}
+
+// FormatValidationError renders err for a retry prompt. A *ValidationError
+// in err's chain is formatted as one line per problem; any other error is
+// returned as its Error text.
+func FormatValidationError(err error) string {
+	if err == nil {
+		return ""
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Error()
+	}
+	return err.Error()
+}
+
+// NewValidationError converts an error returned by a compiled santhosh-tekuri
+// schema into a *ValidationError, reading property descriptions from schema,
+// the document the value was validated against. Other errors are returned
+// unchanged, and nil yields nil.
+func NewValidationError(err error, schema json.RawMessage) error {
+	var cause *santhosh.ValidationError
+	if !errors.As(err, &cause) {
+		return err
+	}
+	var doc any
+	if json.Unmarshal(schema, &doc) != nil {
+		doc = nil
+	}
+	e := &ValidationError{cause: cause}
+	for _, leaf := range leafErrors(cause) {
+		e.Problems = append(e.Problems, problems(doc, leaf)...)
+	}
+	slices.SortStableFunc(e.Problems, func(a, b ValidationProblem) int {
+		return strings.Compare(a.InstanceLocation, b.InstanceLocation)
+	})
+	return e
+}
+
+// leafErrors flattens the validator's error tree. When a value fails every
+// option of an anyOf or oneOf, options rejected outright (a wrong
+// discriminator or JSON type) are dropped in favour of the closest remaining
+// option, so a mostly correct union variant reports only its own mistakes.
+func leafErrors(err *santhosh.ValidationError) []*santhosh.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*santhosh.ValidationError{err}
+	}
+	if !isUnion(err.ErrorKind) {
+		var leaves []*santhosh.ValidationError
+		for _, cause := range err.Causes {
+			leaves = append(leaves, leafErrors(cause)...)
+		}
+		return leaves
+	}
+
+	var closest, rejected []*santhosh.ValidationError
+	for _, option := range err.Causes {
+		leaves := leafErrors(option)
+		if slices.ContainsFunc(leaves, func(leaf *santhosh.ValidationError) bool { return rejectsOption(err, leaf) }) {
+			rejected = append(rejected, leaves...)
+		} else if closest == nil || len(leaves) < len(closest) {
+			closest = leaves
+		}
+	}
+	if closest != nil {
+		return closest
+	}
+	return []*santhosh.ValidationError{mergeRejections(err, rejected)}
+}
+
+func isUnion(k santhosh.ErrorKind) bool {
+	switch k := k.(type) {
+	case *kind.AnyOf:
+		return true
+	case *kind.OneOf:
+		return k.Subschemas == nil
+	}
+	return false
+}
+
+// rejectsOption reports whether leaf shows a union option does not apply to
+// the value at all: a const mismatch, such as a discriminator, or a JSON type
+// mismatch at the union's own location.
+func rejectsOption(union, leaf *santhosh.ValidationError) bool {
+	switch leaf.ErrorKind.(type) {
+	case *kind.Const:
+		return true
+	case *kind.Type:
+		return slices.Equal(leaf.InstanceLocation, union.InstanceLocation)
+	}
+	return false
+}
+
+// mergeRejections combines the rejections of every union option into a single
+// error listing what the options accept. Discriminator mismatches at a
+// common location are reported there, as an enum of the allowed values.
+func mergeRejections(union *santhosh.ValidationError, rejected []*santhosh.ValidationError) *santhosh.ValidationError {
+	var (
+		consts   = &kind.Enum{}
+		types    = &kind.Type{}
+		location []string
+		common   = true
+	)
+	for _, leaf := range rejected {
+		switch k := leaf.ErrorKind.(type) {
+		case *kind.Const:
+			if location == nil {
+				location = leaf.InstanceLocation
+			}
+			common = common && slices.Equal(location, leaf.InstanceLocation)
+			consts.Got = k.Got
+			if !slices.Contains(consts.Want, k.Want) {
+				consts.Want = append(consts.Want, k.Want)
+			}
+		case *kind.Type:
+			types.Got = k.Got
+			for _, want := range k.Want {
+				if !slices.Contains(types.Want, want) {
+					types.Want = append(types.Want, want)
+				}
+			}
+		}
+	}
+	merged := &santhosh.ValidationError{SchemaURL: union.SchemaURL, InstanceLocation: union.InstanceLocation, ErrorKind: union.ErrorKind}
+	switch {
+	case len(consts.Want) > 0 && common && len(types.Want) == 0:
+		merged.InstanceLocation, merged.ErrorKind = location, unionRejection{consts, union.ErrorKind.KeywordPath()}
+	case len(types.Want) > 0 && len(consts.Want) == 0:
+		merged.ErrorKind = unionRejection{types, union.ErrorKind.KeywordPath()}
+	}
+	return merged
+}
+
+// unionRejection is an error merged from every option of a union, located
+// at the union keyword rather than the options' own keywords.
+type unionRejection struct {
+	santhosh.ErrorKind
+	keywordPath []string
+}
+
+func (r unionRejection) KeywordPath() []string { return r.keywordPath }
+
+// problems describes one leaf error. Required and additionalProperties
+// errors yield one problem per property, located at that property.
+func problems(doc any, leaf *santhosh.ValidationError) []ValidationProblem {
+	schemaPtr := schemaPointer(leaf.SchemaURL)
+	subschema, _ := lookupPointer(doc, schemaPtr).(map[string]any)
+	base := ValidationProblem{
+		InstanceLocation: instancePointer(leaf.InstanceLocation),
+		KeywordLocation:  schemaPtr + instancePointer(leaf.ErrorKind.KeywordPath()),
+		Description:      describe(doc, schemaPtr),
+	}
+	errorKind := leaf.ErrorKind
+	if r, ok := errorKind.(unionRejection); ok {
+		errorKind = r.ErrorKind
+	}
+	switch k := errorKind.(type) {
+	case *kind.Required:
+		props, _ := subschema["properties"].(map[string]any)
+		result := make([]ValidationProblem, 0, len(k.Missing))
+		for _, name := range k.Missing {
+			p := base
+			p.InstanceLocation += "/" + escapeToken(name)
+			p.Problem = "missing required property"
+			p.Expected, p.Description = "", ""
+			if prop, ok := props[name].(map[string]any); ok {
+				p.Expected = typeNames(prop["type"])
+				p.Description, _ = prop["description"].(string)
+			}
+			result = append(result, p)
+		}
+		return result
+	case *kind.AdditionalProperties:
+		props, _ := subschema["properties"].(map[string]any)
+		allowed := make([]string, 0, len(props))
+		for name := range props {
+			allowed = append(allowed, fmt.Sprintf("%q", name))
+		}
+		slices.Sort(allowed)
+		result := make([]ValidationProblem, 0, len(k.Properties))
+		for _, name := range k.Properties {
+			p := base
+			p.InstanceLocation += "/" + escapeToken(name)
+			p.Problem, p.Description = "property is not allowed", ""
+			if len(allowed) > 0 {
+				p.Expected = "only " + strings.Join(allowed, ", ")
+			}
+			result = append(result, p)
+		}
+		return result
+	case *kind.Type:
+		base.Problem, base.Expected = "got "+k.Got, strings.Join(k.Want, " or ")
+	case *kind.Enum:
+		base.Problem, base.Expected = "got "+display(k.Got), "one of "+displayAll(k.Want)
+	case *kind.Const:
+		base.Problem, base.Expected = "got "+display(k.Got), display(k.Want)
+	case *kind.MinLength:
+		base.Problem, base.Expected = fmt.Sprintf("length %d is too short", k.Got), fmt.Sprintf("at least %d characters", k.Want)
+	case *kind.MaxLength:
+		base.Problem, base.Expected = fmt.Sprintf("length %d is too long", k.Got), fmt.Sprintf("at most %d characters", k.Want)
+	case *kind.MinItems:
+		base.Problem, base.Expected = fmt.Sprintf("%d items are too few", k.Got), fmt.Sprintf("at least %d items", k.Want)
+	case *kind.MaxItems:
+		base.Problem, base.Expected = fmt.Sprintf("%d items are too many", k.Got), fmt.Sprintf("at most %d items", k.Want)
+	case *kind.Minimum:
+		base.Problem, base.Expected = "got "+ratString(k.Got), ">= "+ratString(k.Want)
+	case *kind.Maximum:
+		base.Problem, base.Expected = "got "+ratString(k.Got), "<= "+ratString(k.Want)
+	case *kind.ExclusiveMinimum:
+		base.Problem, base.Expected = "got "+ratString(k.Got), "> "+ratString(k.Want)
+	case *kind.ExclusiveMaximum:
+		base.Problem, base.Expected = "got "+ratString(k.Got), "< "+ratString(k.Want)
+	case *kind.Pattern:
+		base.Problem, base.Expected = fmt.Sprintf("%q does not match the pattern", k.Got), "a match for "+k.Want
+	case *kind.Format:
+		base.Problem, base.Expected = fmt.Sprintf("%s is not a valid %s", display(k.Got), k.Want), k.Want
+	case *kind.AnyOf, *kind.OneOf:
+		base.Problem = "value matches none of the allowed variants"
+	default:
+		base.Problem = k.LocalizedString(englishPrinter)
+	}
+	return []ValidationProblem{base}
+}
+
+// describe returns the description of the schema at ptr. Array items and
+// union options without their own description inherit their property's.
+func describe(doc any, ptr string) string {
+	for {
+		if schema, ok := lookupPointer(doc, ptr).(map[string]any); ok {
+			if description, ok := schema["description"].(string); ok {
+				return description
+			}
+		}
+		parent, last, ok := cutLastToken(ptr)
+		if !ok {
+			return ""
+		}
+		grandparent, keyword, _ := cutLastToken(parent)
+		switch {
+		case last == "items" || last == "additionalProperties":
+			ptr = parent
+		case keyword == "anyOf" || keyword == "oneOf":
+			ptr = grandparent
+		default:
+			return ""
+		}
+	}
+}
+
+// schemaPointer returns the JSON pointer fragment of a schema URL.
+func schemaPointer(schemaURL string) string {
+	_, fragment, _ := strings.Cut(schemaURL, "#")
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		return unescaped
+	}
+	return fragment
+}
+
+func lookupPointer(doc any, ptr string) any {
+	if ptr == "" {
+		return doc
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(ptr, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := doc.(type) {
+		case map[string]any:
+			doc = node[token]
+		case []any:
+			var i int
+			if _, err := fmt.Sscan(token, &i); err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			doc = node[i]
+		default:
+			return nil
+		}
+	}
+	return doc
+}
+
+func cutLastToken(ptr string) (parent, last string, ok bool) {
+	i := strings.LastIndexByte(ptr, '/')
+	if i < 0 {
+		return "", "", false
+	}
+	return ptr[:i], ptr[i+1:], true
+}
+
+func instancePointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(escapeToken(token))
+	}
+	return sb.String()
+}
+
+func escapeToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func typeNames(value any) string {
+	switch t := value.(type) {
+	case string:
+		return t
+	case []any:
+		names := make([]string, len(t))
+		for i, name := range t {
+			names[i] = fmt.Sprint(name)
+		}
+		return strings.Join(names, " or ")
+	}
+	return ""
+}
+
+func ratString(r *big.Rat) string {
+	f, _ := r.Float64()
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func display(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func displayAll(values []any) string {
+	shown := make([]string, len(values))
+	for i, value := range values {
+		shown[i] = display(value)
+	}
+	return strings.Join(shown, ", ")
+}
@@ -0,0 +1,88 @@
+package jsonschema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	santhosh "github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+)
+
+const shapesSchema = `{
+	"type": "object",
+	"properties": {
+		"shapes": {
+			"type": "array",
+			"description": "Shapes to draw, in order.",
+			"items": {"anyOf": [
+				{"type": "object", "properties": {
+					"type": {"type": "string", "const": "circle"},
+					"radius": {"type": "number", "description": "Radius in pixels.", "minimum": 1}
+				}, "required": ["type", "radius"], "additionalProperties": false},
+				{"type": "object", "properties": {
+					"type": {"type": "string", "const": "square"},
+					"side": {"type": "number"}
+				}, "required": ["type", "side"], "additionalProperties": false}
+			]}
+		},
+		"label": {"anyOf": [{"type": "string"}, {"type": "null"}], "description": "Optional caption."}
+	},
+	"required": ["shapes", "label"],
+	"additionalProperties": false
+}`
+
+func validateShapes(t *testing.T, instance string) error {
+	t.Helper()
+	doc, err := santhosh.UnmarshalJSON(strings.NewReader(shapesSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := santhosh.NewCompiler()
+	if err = c.AddResource("Shapes.json", doc); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := c.Compile("Shapes.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst, err := santhosh.UnmarshalJSON(strings.NewReader(instance))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewValidationError(schema.Validate(inst), []byte(shapesSchema))
+}
+
+func TestValidationErrorReportsTheClosestUnionVariant(t *testing.T) {
+	err := validateShapes(t, `{"shapes":[{"type":"circle","radius":0}],"label":null}`)
+
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "/shapes/0/radius: got 0 (expected >= 1) -- Radius in pixels.", FormatValidationError(err))
+	assert.Equal(t, "/properties/shapes/items/anyOf/0/properties/radius/minimum", validationErr.Problems[0].KeywordLocation)
+
+	var cause *santhosh.ValidationError
+	assert.True(t, errors.As(err, &cause), "the validator's error stays reachable")
+}
+
+func TestValidationErrorMergesRejectedUnionVariants(t *testing.T) {
+	err := validateShapes(t, `{"shapes":[{"type":"triangle"}],"label":5}`)
+
+	assert.Equal(t, strings.Join([]string{
+		`/label: got number (expected string or null) -- Optional caption.`,
+		`/shapes/0/type: got "triangle" (expected one of "circle", "square") -- Shapes to draw, in order.`,
+	}, "\n"), FormatValidationError(err))
+
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []string{"/label", "/shapes/0/type"}, validationErr.InstanceLocations())
+	assert.Equal(t, "/properties/label/anyOf", validationErr.Problems[0].KeywordLocation)
+}
+
+func TestFormatValidationErrorPassesOtherErrorsThrough(t *testing.T) {
+	other := errors.New("unexpected EOF")
+	assert.Equal(t, other, NewValidationError(other, nil))
+	assert.NoError(t, NewValidationError(nil, nil))
+	assert.Equal(t, "unexpected EOF", FormatValidationError(other))
+	assert.Equal(t, "", FormatValidationError(nil))
+}